@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"context"
+	"errors"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc"
+)
+
+// Acknowledger peut être implémentée par un NodeExecutor dont les
+// déclencheurs lisent une file de messages, pour une livraison au moins une
+// fois : le plugin ne retire un message de la file qu'une fois l'événement
+// confirmé par Ack, c'est-à-dire enregistré durablement par le moteur. Nack
+// signale les événements que le moteur n'a pu accepter ; le plugin les rend à
+// la file pour qu'ils soient relevés à nouveau. Un événement ni confirmé ni
+// refusé, parce que le moteur a redémarré, doit finir par être relivré. ids
+// sont des PollEvent.ID.
+type Acknowledger interface {
+	Ack(node Node, ctx ExecutionContext, ids []string) error
+	Nack(node Node, ctx ExecutionContext, ids []string) error
+}
+
+// Ack confirme au plugin l'acceptation durable des événements ids du
+// déclencheur node. Les plugins qui ne le supportent pas renvoient une
+// erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) Ack(node Node, ctx ExecutionContext, ids []string) error {
+	return m.ack("Ack", m.client.Ack, node, ctx, ids)
+}
+
+// Nack signale au plugin que les événements ids du déclencheur node n'ont pu
+// être acceptés. Les plugins qui ne le supportent pas renvoient une erreur
+// errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) Nack(node Node, ctx ExecutionContext, ids []string) error {
+	return m.ack("Nack", m.client.Nack, node, ctx, ids)
+}
+
+func (m *NodeExecutorGRPC) ack(method string, call func(context.Context, *proto.AckRequest, ...grpc.CallOption) (*proto.Empty, error), node Node, ctx ExecutionContext, ids []string) error {
+	pNode, pCtx, err := m.nodeRequest(node, ctx)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	if _, err := call(callCtx, &proto.AckRequest{Node: pNode, Context: pCtx, Ids: ids}); err != nil {
+		return withTimeout(fromRPCError(method, err), m.opts.callTimeout())
+	}
+	return nil
+}
+
+func (s *NodeExecutorGRPCServer) Ack(ctx context.Context, req *proto.AckRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	a, ok := implementation[Acknowledger](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.Ack(ctx, req)
+	}
+	return s.ack(req, a.Ack)
+}
+
+func (s *NodeExecutorGRPCServer) Nack(ctx context.Context, req *proto.AckRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	a, ok := implementation[Acknowledger](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.Nack(ctx, req)
+	}
+	return s.ack(req, a.Nack)
+}
+
+func (s *NodeExecutorGRPCServer) ack(req *proto.AckRequest, fn func(Node, ExecutionContext, []string) error) (*proto.Empty, error) {
+	c := codecOrDefault(s.options.Codec)
+	node, err := fromProtoNode(c, req.Node, s.options.MaxNodeDepth)
+	if err != nil {
+		return nil, err
+	}
+	var execCtx ExecutionContext
+	if req.Context != nil {
+		if execCtx, err = fromProtoExecutionContext(c, req.Context); err != nil {
+			return nil, err
+		}
+	}
+	if err := fn(node, execCtx, req.Ids); err != nil {
+		return nil, toRPCError(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// acknowledge confirme ou refuse ids auprès de src s'il implémente
+// Acknowledger. L'absence de support chez le plugin n'est pas une erreur.
+func acknowledge(src PollSource, t PollTrigger, ids []string, ok bool) error {
+	a, isAck := src.(Acknowledger)
+	if !isAck || len(ids) == 0 {
+		return nil
+	}
+	var err error
+	if ok {
+		err = a.Ack(t.Node, t.Context, ids)
+	} else {
+		err = a.Nack(t.Node, t.Context, ids)
+	}
+	if errors.Is(err, errors.ErrUnsupported) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,300 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/orkestra-io/orkestra-shared/broker"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultArtifactThreshold est la taille sérialisée d'une sortie de nœud
+// au-delà de laquelle elle est transmise par référence, lorsque
+// ClientOptions.ArtifactThreshold n'est pas renseigné.
+const DefaultArtifactThreshold = 4 << 20
+
+// artifactChunkSize est la taille des fragments échangés avec le magasin.
+const artifactChunkSize = 1 << 20
+
+// MaxArtifactBytes borne la taille d'un artefact déposé par un plugin via
+// le broker : le moteur le reçoit en mémoire avant de le conserver.
+const MaxArtifactBytes = 1 << 30
+
+// ErrArtifactNotFound est renvoyée par ArtifactStore.Get pour une référence
+// inconnue.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// ArtifactStore conserve des contenus adressés par leur empreinte. Le moteur
+// en fournit une implémentation (ClientOptions.Artifacts) : les sorties
+// volumineuses y sont déposées une fois et les plugins qui acceptent les
+// références (OutputRefReader) les y lisent, au lieu d'en recevoir une copie
+// à chaque exécution.
+type ArtifactStore interface {
+	// Put conserve data et renvoie sa référence, ArtifactRef(data).
+	Put(data []byte) (string, error)
+	Get(ref string) ([]byte, error)
+}
+
+// ArtifactRef renvoie la référence d'un contenu : « sha256: » suivi de son
+// empreinte en hexadécimal.
+func ArtifactRef(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// OutputRef désigne une sortie de nœud laissée dans le magasin d'artefacts.
+type OutputRef struct {
+	Ref  string
+	Size int64
+	// hints sont les indications de type de la sortie.
+	hints []byte
+}
+
+// OutputRefReader peut être implémentée par un NodeExecutor qui lit les
+// sorties des nœuds précédents via ExecutionContext.Output. Le moteur peut
+// alors lui transmettre les sorties volumineuses par référence
+// (ExecutionContext.OutputRefs) ; les autres plugins les reçoivent toujours
+// dans NodeOutputs.
+type OutputRefReader interface {
+	AcceptsOutputRefs() bool
+}
+
+// Output renvoie la sortie du nœud id, lue dans le magasin d'artefacts si
+// elle a été transmise par référence.
+func (c ExecutionContext) Output(id string) (interface{}, error) {
+	if v, ok := c.NodeOutputs[id]; ok {
+		return v, nil
+	}
+	ref, ok := c.OutputRefs[id]
+	if !ok {
+		return nil, nil
+	}
+	if c.resolve == nil {
+		return nil, fmt.Errorf("output of %q: no artifact store to resolve %s", id, ref.Ref)
+	}
+	v, err := c.resolve(ref)
+	if err != nil {
+		return nil, fmt.Errorf("output of %q: %w", id, err)
+	}
+	return v, nil
+}
+
+func (o ClientOptions) artifactThreshold() int {
+	if o.ArtifactThreshold != 0 {
+		return o.ArtifactThreshold
+	}
+	return DefaultArtifactThreshold
+}
+
+// attachArtifactStore sert store via le broker de go-plugin et transmet son
+// identifiant au plugin. Les sorties ne sont transmises par référence qu'aux
+// plugins qui l'acceptent.
+func (m *NodeExecutorGRPC) attachArtifactStore(b *plugin.GRPCBroker, store ArtifactStore) error {
+	srv := broker.Register[proto.ArtifactStoreServer](b, proto.RegisterArtifactStoreServer, &artifactStoreServer{store: store})
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	_, err := m.client.SetArtifactStore(callCtx, &proto.SetArtifactStoreRequest{BrokerId: srv.ID})
+	if err = fromRPCError("SetArtifactStore", err); err != nil {
+		srv.Close()
+		if errors.Is(err, errors.ErrUnsupported) {
+			return nil
+		}
+		return withTimeout(err, m.opts.callTimeout())
+	}
+	m.artifacts = store
+	return nil
+}
+
+// offloadOutputs dépose dans le magasin les sorties de ctx plus grandes que
+// le seuil et renvoie le contexte allégé, avec leurs références.
+func (m *NodeExecutorGRPC) offloadOutputs(ctx ExecutionContext) (ExecutionContext, map[string]*proto.OutputRef, error) {
+	threshold := m.opts.artifactThreshold()
+	if m.artifacts == nil || threshold < 0 || len(ctx.NodeOutputs) == 0 {
+		return ctx, nil, nil
+	}
+	c := m.codec()
+	var outputs map[string]interface{}
+	var refs map[string]*proto.OutputRef
+	for id, v := range ctx.NodeOutputs {
+		if _, ok := ctx.OutputRefs[id]; ok {
+			// Déjà dans le magasin : ni réencodée ni déposée une seconde fois.
+			continue
+		}
+		data, hints, err := encodeValue(c, v)
+		if err != nil {
+			return ctx, nil, err
+		}
+		if len(data) <= threshold {
+			continue
+		}
+		ref, err := m.artifacts.Put(data)
+		if err != nil {
+			return ctx, nil, fmt.Errorf("failed to store output of %q: %w", id, err)
+		}
+		if outputs == nil {
+			outputs = make(map[string]interface{}, len(ctx.NodeOutputs))
+			for k, v := range ctx.NodeOutputs {
+				outputs[k] = v
+			}
+			refs = map[string]*proto.OutputRef{}
+		}
+		delete(outputs, id)
+		refs[id] = &proto.OutputRef{Ref: ref, Size: int64(len(data)), Hints: hints}
+	}
+	if outputs != nil {
+		ctx.NodeOutputs = outputs
+	}
+	return ctx, refs, nil
+}
+
+func (s *NodeExecutorGRPCServer) SetArtifactStore(ctx context.Context, req *proto.SetArtifactStoreRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	r, ok := implementation[OutputRefReader](s.Impl)
+	if !ok || !r.AcceptsOutputRefs() || s.broker == nil {
+		return s.UnimplementedNodeExecutorServer.SetArtifactStore(ctx, req)
+	}
+	c, err := broker.Dial(s.broker, req.BrokerId, proto.NewArtifactStoreClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial artifact store: %w", err)
+	}
+	s.artifacts.Store(&artifactStoreClient{client: c.Service})
+	return &proto.Empty{}, nil
+}
+
+// outputResolver renvoie la fonction qui lit les sorties transmises par
+// référence, nil si le moteur n'a pas fourni de magasin.
+func (s *NodeExecutorGRPCServer) outputResolver() func(OutputRef) (interface{}, error) {
+	store := s.artifacts.Load()
+	if store == nil {
+		return nil
+	}
+	codec := codecOrDefault(s.options.Codec)
+	return func(ref OutputRef) (interface{}, error) {
+		data, err := store.Get(ref.Ref)
+		if err != nil {
+			return nil, err
+		}
+		return decodeValue(codec, data, ref.hints)
+	}
+}
+
+// artifactStoreServer expose l'ArtifactStore du moteur au plugin.
+type artifactStoreServer struct {
+	proto.UnimplementedArtifactStoreServer
+	store ArtifactStore
+}
+
+func (s *artifactStoreServer) PutArtifact(stream proto.ArtifactStore_PutArtifactServer) error {
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if buf.Len()+len(chunk.Data) > MaxArtifactBytes {
+			return status.Errorf(codes.ResourceExhausted, "artifact exceeds %d bytes", MaxArtifactBytes)
+		}
+		buf.Write(chunk.Data)
+	}
+	ref, err := s.store.Put(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&proto.ArtifactRef{Ref: ref})
+}
+
+func (s *artifactStoreServer) GetArtifact(req *proto.ArtifactRef, stream proto.ArtifactStore_GetArtifactServer) error {
+	data, err := s.store.Get(req.Ref)
+	if errors.Is(err, ErrArtifactNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := min(len(data), artifactChunkSize)
+		if err := stream.Send(&proto.ArtifactChunk{Data: data[:n]}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// artifactStoreClient est l'ArtifactStore utilisé par le plugin.
+type artifactStoreClient struct {
+	client proto.ArtifactStoreClient
+}
+
+func (c *artifactStoreClient) Put(data []byte) (string, error) {
+	if len(data) > MaxArtifactBytes {
+		return "", fmt.Errorf("artifact of %d bytes exceeds %d bytes", len(data), MaxArtifactBytes)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	stream, err := c.client.PutArtifact(ctx)
+	if err != nil {
+		return "", err
+	}
+	for len(data) > 0 {
+		n := min(len(data), artifactChunkSize)
+		if err := stream.Send(&proto.ArtifactChunk{Data: data[:n]}); err != nil {
+			return "", err
+		}
+		data = data[n:]
+	}
+	ref, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	return ref.Ref, nil
+}
+
+func (c *artifactStoreClient) Get(ref string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	stream, err := c.client.GetArtifact(ctx, &proto.ArtifactRef{Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrArtifactNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(chunk.Data)
+	}
+}
+
+func fromProtoOutputRefs(p map[string]*proto.OutputRef) map[string]OutputRef {
+	if len(p) == 0 {
+		return nil
+	}
+	refs := make(map[string]OutputRef, len(p))
+	for id, r := range p {
+		if r != nil {
+			refs[id] = OutputRef{Ref: r.Ref, Size: r.Size, hints: r.Hints}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,208 @@
+package shared
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// TriggerDataAttachments est la clé de TriggerData sous laquelle le moteur
+// liste les fichiers reçus par un déclencheur, sans leur contenu (nom, type,
+// taille), pour que les expressions puissent y faire référence. Le contenu
+// est dans ExecutionContext.Attachments.
+const TriggerDataAttachments = "attachments"
+
+// Attachment est un fichier reçu par un déclencheur, par exemple une partie
+// d'un envoi multipart. Son contenu est soit en ligne (Data), soit déposé
+// dans le magasin d'artefacts du moteur (Ref) ; ReadAttachment lit l'un ou
+// l'autre.
+type Attachment struct {
+	// Field est le champ du formulaire qui portait le fichier.
+	Field       string `json:"field,omitempty"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	// Ref est la référence du contenu dans le magasin d'artefacts, vide s'il
+	// est en ligne.
+	Ref string `json:"ref,omitempty"`
+	// Data est le contenu en ligne, encodé en base64 en JSON.
+	Data []byte `json:"data,omitempty"`
+}
+
+// SetTriggerDataAttachments liste attachments sous TriggerDataAttachments
+// dans data : champ, nom, type et taille de chaque fichier, sans contenu.
+func SetTriggerDataAttachments(data map[string]interface{}, attachments []Attachment) {
+	list := make([]interface{}, len(attachments))
+	for i, a := range attachments {
+		entry := map[string]interface{}{"name": a.Name, "size": a.Size}
+		if a.Field != "" {
+			entry["field"] = a.Field
+		}
+		if a.ContentType != "" {
+			entry["contentType"] = a.ContentType
+		}
+		list[i] = entry
+	}
+	data[TriggerDataAttachments] = list
+}
+
+// AttachmentsFromMultipart convertit les fichiers d'un formulaire multipart
+// déjà analysé, triés par champ puis dans l'ordre du formulaire. Ceux de plus
+// de inlineLimit octets sont déposés dans store ; sans store, ils sont
+// refusés plutôt que lus en mémoire.
+func AttachmentsFromMultipart(form *multipart.Form, store ArtifactStore, inlineLimit int64) ([]Attachment, error) {
+	fields := make([]string, 0, len(form.File))
+	for field := range form.File {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var attachments []Attachment
+	for _, field := range fields {
+		for _, h := range form.File[field] {
+			data, err := readAttachment(h, store, inlineLimit)
+			if err != nil {
+				return nil, err
+			}
+			a := Attachment{Field: field, Name: h.Filename, ContentType: h.Header.Get("Content-Type"), Size: int64(len(data)), Data: data}
+			if store != nil && a.Size > inlineLimit {
+				if a.Ref, err = store.Put(data); err != nil {
+					return nil, fmt.Errorf("failed to store attachment %q: %w", h.Filename, err)
+				}
+				a.Data = nil
+			}
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+// readAttachment lit le fichier h. Sans store, il n'en lit pas plus de
+// inlineLimit octets.
+func readAttachment(h *multipart.FileHeader, store ArtifactStore, inlineLimit int64) ([]byte, error) {
+	if store == nil && h.Size > inlineLimit {
+		return nil, fmt.Errorf("attachment %q exceeds inline limit of %d bytes", h.Filename, inlineLimit)
+	}
+	f, err := h.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment %q: %w", h.Filename, err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if store == nil {
+		r = io.LimitReader(f, inlineLimit+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %q: %w", h.Filename, err)
+	}
+	if store == nil && int64(len(data)) > inlineLimit {
+		return nil, fmt.Errorf("attachment %q exceeds inline limit of %d bytes", h.Filename, inlineLimit)
+	}
+	return data, nil
+}
+
+// Attachment renvoie le premier fichier reçu nommé name, ou à défaut porté
+// par le champ name du formulaire.
+func (c ExecutionContext) Attachment(name string) (Attachment, bool) {
+	for _, a := range c.Attachments {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	for _, a := range c.Attachments {
+		if a.Field == name {
+			return a, true
+		}
+	}
+	return Attachment{}, false
+}
+
+// ReadAttachment renvoie le contenu de a, lu dans le magasin d'artefacts du
+// moteur s'il a été transmis par référence.
+func (c ExecutionContext) ReadAttachment(a Attachment) ([]byte, error) {
+	if a.Ref == "" {
+		return a.Data, nil
+	}
+	if c.fetch == nil {
+		return nil, fmt.Errorf("attachment %q: no artifact store to resolve %s", a.Name, a.Ref)
+	}
+	data, err := c.fetch(a.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("attachment %q: %w", a.Name, err)
+	}
+	return data, nil
+}
+
+// OpenAttachment est comme ReadAttachment mais renvoie un io.Reader.
+func (c ExecutionContext) OpenAttachment(a Attachment) (io.Reader, error) {
+	data, err := c.ReadAttachment(a)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// offloadAttachments dépose dans le magasin les fichiers en ligne de ctx plus
+// grands que le seuil des sorties.
+func (m *NodeExecutorGRPC) offloadAttachments(ctx ExecutionContext) (ExecutionContext, error) {
+	threshold := m.opts.artifactThreshold()
+	if m.artifacts == nil || threshold < 0 {
+		return ctx, nil
+	}
+	var attachments []Attachment
+	for i, a := range ctx.Attachments {
+		if a.Ref != "" || len(a.Data) <= threshold {
+			continue
+		}
+		ref, err := m.artifacts.Put(a.Data)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to store attachment %q: %w", a.Name, err)
+		}
+		if attachments == nil {
+			attachments = append([]Attachment(nil), ctx.Attachments...)
+		}
+		attachments[i].Ref, attachments[i].Data = ref, nil
+	}
+	if attachments != nil {
+		ctx.Attachments = attachments
+	}
+	return ctx, nil
+}
+
+// artifactFetcher renvoie la fonction qui lit le magasin d'artefacts du
+// moteur, nil s'il n'en a pas fourni.
+func (s *NodeExecutorGRPCServer) artifactFetcher() func(ref string) ([]byte, error) {
+	store := s.artifacts.Load()
+	if store == nil {
+		return nil
+	}
+	return store.Get
+}
+
+func toProtoAttachments(attachments []Attachment) []*proto.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	p := make([]*proto.Attachment, len(attachments))
+	for i, a := range attachments {
+		p[i] = &proto.Attachment{Field: a.Field, Name: a.Name, ContentType: a.ContentType, Size: a.Size, Ref: a.Ref, Data: a.Data}
+	}
+	return p
+}
+
+func fromProtoAttachments(p []*proto.Attachment) []Attachment {
+	if len(p) == 0 {
+		return nil
+	}
+	attachments := make([]Attachment, 0, len(p))
+	for _, a := range p {
+		if a != nil {
+			attachments = append(attachments, Attachment{Field: a.Field, Name: a.Name, ContentType: a.ContentType, Size: a.Size, Ref: a.Ref, Data: a.Data})
+		}
+	}
+	return attachments
+}
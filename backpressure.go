@@ -0,0 +1,160 @@
+package shared
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBackpressure signale un plugin surchargé qui refuse du travail plutôt
+// que de le laisser expirer.
+var ErrBackpressure = errors.New("plugin is overloaded")
+
+// MaxBackpressureDelay borne le délai qu'un plugin peut imposer au moteur,
+// quel que soit le RetryAfter demandé.
+const MaxBackpressureDelay = 5 * time.Minute
+
+// BackpressureError est renvoyée par un plugin surchargé pour demander au
+// moteur de différer ses appels de RetryAfter. Elle enveloppe ErrBackpressure
+// et traverse gRPC avec son délai ; le client la retient (voir
+// BackpressureTracker) pour que l'ordonnanceur retarde les nœuds suivants.
+type BackpressureError struct {
+	// RetryAfter est le délai souhaité, zéro si le plugin n'en propose pas.
+	// Le moteur le ramène à MaxBackpressureDelay.
+	RetryAfter time.Duration
+	// Err est la cause, facultative.
+	Err error
+}
+
+func (e *BackpressureError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return ErrBackpressure.Error()
+}
+
+func (e *BackpressureError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrBackpressure, e.Err}
+	}
+	return []error{ErrBackpressure}
+}
+
+// Code renvoie CodeRateLimited : voir CodeOf.
+func (e *BackpressureError) Code() ErrorCode { return CodeRateLimited }
+
+func (e *BackpressureError) FailureCode() string { return string(e.Code()) }
+
+func (e *BackpressureError) Retryable() bool { return true }
+
+// backpressureRetryAfter est la clé des détails d'un maillon
+// BackpressureError, et de l'attribut d'un avis NoticeBackpressure, en
+// millisecondes.
+const backpressureRetryAfter = "backpressure.retryAfterMs"
+
+func (e *BackpressureError) ErrorDetails() map[string]string {
+	return map[string]string{backpressureRetryAfter: strconv.FormatInt(e.RetryAfter.Milliseconds(), 10)}
+}
+
+// BackpressureOf renvoie le délai demandé par err, qu'elle soit une
+// *BackpressureError ou son équivalent reçu d'un plugin.
+func BackpressureOf(err error) (time.Duration, bool) {
+	var be *BackpressureError
+	if errors.As(err, &be) {
+		return clampBackpressure(be.RetryAfter), true
+	}
+	var re *RemoteError
+	if !errors.As(err, &re) {
+		return 0, false
+	}
+	for _, l := range re.Chain {
+		if ms, ok := l.Details[backpressureRetryAfter]; ok {
+			return parseBackpressure(ms), true
+		}
+	}
+	return 0, false
+}
+
+// parseBackpressure lit un délai en millisecondes reçu d'un plugin, ramené
+// entre zéro et MaxBackpressureDelay sans déborder de time.Duration.
+func parseBackpressure(ms string) time.Duration {
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if n >= MaxBackpressureDelay.Milliseconds() {
+		return MaxBackpressureDelay
+	}
+	return time.Duration(n) * time.Millisecond
+}
+
+// clampBackpressure ramène d entre zéro et MaxBackpressureDelay.
+func clampBackpressure(d time.Duration) time.Duration {
+	return min(max(d, 0), MaxBackpressureDelay)
+}
+
+// NoticeBackpressure est l'avis par lequel un plugin demande, hors de tout
+// appel, que le moteur ralentisse (voir BackpressureNotice).
+const NoticeBackpressure NoticeKind = "backpressure"
+
+// BackpressureNotice renvoie l'avis demandant au moteur de différer ses
+// appels de retryAfter, à émettre par Notifier.Notify.
+func BackpressureNotice(retryAfter time.Duration) Notice {
+	return Notice{
+		Kind:       NoticeBackpressure,
+		Severity:   NoticeWarning,
+		Message:    "plugin is overloaded",
+		Attributes: map[string]string{backpressureRetryAfter: strconv.FormatInt(retryAfter.Milliseconds(), 10)},
+	}
+}
+
+// BackpressureTracker est implémentée par les clients qui retiennent les
+// demandes de ralentissement du plugin, comme NodeExecutorGRPC. Le moteur
+// consulte BackpressureDelay avant de planifier un nœud sur le plugin.
+type BackpressureTracker interface {
+	BackpressureDelay() time.Duration
+}
+
+// backpressure retient la fin de la dernière demande de ralentissement.
+type backpressure struct {
+	until atomic.Int64 // en nanosecondes Unix
+}
+
+func (b *backpressure) record(retryAfter time.Duration) {
+	until := time.Now().Add(clampBackpressure(retryAfter)).UnixNano()
+	for {
+		cur := b.until.Load()
+		if cur >= until || b.until.CompareAndSwap(cur, until) {
+			return
+		}
+	}
+}
+
+func (b *backpressure) delay() time.Duration {
+	if d := time.Until(time.Unix(0, b.until.Load())); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// observeBackpressure retient la demande de ralentissement portée par err.
+func (m *NodeExecutorGRPC) observeBackpressure(err error) {
+	if d, ok := BackpressureOf(err); ok {
+		m.backpressure.record(d)
+	}
+}
+
+// observeNotice retient la demande de ralentissement portée par un avis.
+func (m *NodeExecutorGRPC) observeNotice(n Notice) {
+	if n.Kind != NoticeBackpressure {
+		return
+	}
+	m.backpressure.record(parseBackpressure(n.Attributes[backpressureRetryAfter]))
+}
+
+// BackpressureDelay renvoie le temps à attendre avant d'appeler à nouveau le
+// plugin, nul s'il n'a pas demandé de ralentir.
+func (m *NodeExecutorGRPC) BackpressureDelay() time.Duration {
+	return m.backpressure.delay()
+}
@@ -0,0 +1,111 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BatchResult est la sortie canonique des nœuds qui traitent des éléments
+// par lot (insertions en masse, envois groupés) et peuvent réussir
+// partiellement.
+type BatchResult struct {
+	Succeeded []BatchItem    `json:"succeeded"`
+	Failed    []BatchFailure `json:"failed"`
+	Summary   BatchSummary   `json:"summary"`
+}
+
+// BatchItem est un élément traité avec succès.
+type BatchItem struct {
+	Index  int         `json:"index"`
+	Key    string      `json:"key,omitempty"`
+	Output interface{} `json:"output,omitempty"`
+}
+
+// BatchFailure est un élément en échec, avec l'entrée d'origine pour
+// permettre de ne rejouer que les échecs.
+type BatchFailure struct {
+	Index     int         `json:"index"`
+	Key       string      `json:"key,omitempty"`
+	Error     string      `json:"error"`
+	Code      string      `json:"code,omitempty"`
+	Retryable bool        `json:"retryable"`
+	Input     interface{} `json:"input,omitempty"`
+}
+
+// BatchSummary résume le lot.
+type BatchSummary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// NewBatchResult prépare un résultat pour un lot de total éléments.
+func NewBatchResult(total int) *BatchResult {
+	return &BatchResult{
+		Succeeded: []BatchItem{},
+		Failed:    []BatchFailure{},
+		Summary:   BatchSummary{Total: total},
+	}
+}
+
+// AddSuccess enregistre la réussite de l'élément index.
+func (b *BatchResult) AddSuccess(index int, key string, output interface{}) {
+	b.Succeeded = append(b.Succeeded, BatchItem{Index: index, Key: key, Output: output})
+	b.Summary.Succeeded++
+}
+
+// AddFailure enregistre l'échec de l'élément index.
+func (b *BatchResult) AddFailure(index int, key string, err error, retryable bool, input interface{}) {
+	b.Failed = append(b.Failed, BatchFailure{Index: index, Key: key, Error: err.Error(), Retryable: retryable, Input: input})
+	b.Summary.Failed++
+}
+
+// HasFailures indique si au moins un élément a échoué.
+func (b *BatchResult) HasFailures() bool {
+	return len(b.Failed) > 0
+}
+
+// RetryableInputs renvoie les entrées des échecs qui peuvent être rejoués.
+func (b *BatchResult) RetryableInputs() []interface{} {
+	var inputs []interface{}
+	for _, f := range b.Failed {
+		if f.Retryable {
+			inputs = append(inputs, f.Input)
+		}
+	}
+	return inputs
+}
+
+// Err renvoie une *BatchError si au moins un élément a échoué, nil sinon.
+// Un plugin qui veut déclencher Retries/OnFailure sur un succès partiel
+// renvoie cette erreur plutôt que le résultat.
+func (b *BatchResult) Err() error {
+	if !b.HasFailures() {
+		return nil
+	}
+	return &BatchError{Result: b}
+}
+
+// BatchError signale un lot partiellement ou totalement en échec. Le
+// résultat complet reste disponible via errors.As.
+type BatchError struct {
+	Result *BatchResult
+}
+
+func (e *BatchError) Error() string {
+	s := e.Result.Summary
+	return fmt.Sprintf("batch partially failed: %d of %d items failed", s.Failed, s.Total)
+}
+
+// BatchResultFromValue relit un BatchResult depuis une sortie de nœud.
+func BatchResultFromValue(v interface{}) (*BatchResult, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res BatchResult
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("value is not a batch result: %w", err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,125 @@
+// Package broker simplifie l'exposition de services de rappel entre le
+// moteur et un plugin via le broker gRPC de go-plugin.
+//
+// Côté exposant :
+//
+//	srv := broker.Register(b, proto.RegisterTokenStoreServer, impl)
+//	defer srv.Close()
+//	// transmettre srv.ID à l'autre partie
+//
+// Côté appelant :
+//
+//	c, err := broker.Dial(b, id, proto.NewTokenStoreClient)
+//	defer c.Close()
+//	c.Service.GetToken(ctx, req)
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Server est un service exposé sur le broker.
+type Server struct {
+	// ID est l'identifiant à transmettre à l'autre partie pour Dial.
+	ID uint32
+
+	mu     sync.Mutex
+	server *grpc.Server
+	closed bool
+	done   chan struct{}
+}
+
+// Register expose impl sur le broker b sous un nouvel identifiant. register
+// est la fonction d'enregistrement générée par protoc
+// (proto.RegisterXxxServer). Le service est servi en arrière-plan jusqu'à
+// Close ou la fermeture du broker.
+func Register[S any](b *plugin.GRPCBroker, register func(grpc.ServiceRegistrar, S), impl S) *Server {
+	s := &Server{ID: b.NextId(), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		b.AcceptAndServe(s.ID, func(opts []grpc.ServerOption) *grpc.Server {
+			gs := grpc.NewServer(opts...)
+			register(gs, impl)
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.server = gs
+			if s.closed {
+				// Close a précédé la connexion : Serve échouera aussitôt.
+				gs.Stop()
+			}
+			return gs
+		})
+	}()
+	return s
+}
+
+// Close arrête le service après la fin des appels en cours. Il n'attend pas
+// qu'une connexion ait été établie.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	gs := s.server
+	s.mu.Unlock()
+	if gs != nil {
+		gs.GracefulStop()
+	}
+}
+
+// Done est fermé lorsque le service a cessé d'être servi.
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// Client est une connexion à un service exposé sur le broker.
+type Client[C any] struct {
+	// Service est le client gRPC typé.
+	Service C
+	conn    *grpc.ClientConn
+}
+
+// Dial se connecte au service id exposé par l'autre partie. newClient est
+// le constructeur généré par protoc (proto.NewXxxClient).
+func Dial[C any](b *plugin.GRPCBroker, id uint32, newClient func(grpc.ClientConnInterface) C) (*Client[C], error) {
+	conn, err := b.Dial(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial broker service %d: %w", id, err)
+	}
+	return &Client[C]{Service: newClient(conn), conn: conn}, nil
+}
+
+// Close ferme la connexion.
+func (c *Client[C]) Close() error {
+	return c.conn.Close()
+}
+
+// CloseOnDisconnect ferme la connexion, en arrière-plan, dès que l'autre
+// partie cesse de servir le service (arrêt du service ou du processus) : la
+// connexion ne fuit pas lorsque son détenteur ne sait pas quand la fermer.
+func (c *Client[C]) CloseOnDisconnect() {
+	c.conn.Connect()
+	go func() {
+		for {
+			state := c.conn.GetState()
+			switch state {
+			case connectivity.TransientFailure:
+				c.conn.Close()
+				return
+			case connectivity.Shutdown:
+				return
+			}
+			if !c.conn.WaitForStateChange(context.Background(), state) {
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,111 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// Budget est ce qu'il reste à une exécution de workflow. Le moteur le
+// décrémente au fil des nœuds ; les plugins itératifs le consultent pour
+// s'arrêter proprement à sa limite plutôt que d'être interrompus en pleine
+// écriture. Chaque dimension absente n'est pas bornée.
+type Budget struct {
+	// Until est l'heure à laquelle le temps alloué est épuisé, zéro s'il
+	// n'est pas borné.
+	Until time.Time
+	// Items est le nombre d'éléments restant à traiter, nil s'il n'est pas
+	// borné.
+	Items *int64
+	// Spend est la dépense restante, nil si elle n'est pas bornée.
+	Spend *Money
+}
+
+// TimeLeft renvoie le temps restant, et false s'il n'est pas borné.
+func (b *Budget) TimeLeft() (time.Duration, bool) {
+	if b == nil || b.Until.IsZero() {
+		return 0, false
+	}
+	return time.Until(b.Until), true
+}
+
+// AllowItems indique si n éléments de plus restent dans le budget.
+func (b *Budget) AllowItems(n int64) bool {
+	return b == nil || b.Items == nil || *b.Items >= n
+}
+
+// AllowSpend indique si la dépense m reste dans le budget. Une dépense dans
+// une autre devise que le budget n'est pas admise.
+func (b *Budget) AllowSpend(m Money) bool {
+	if b == nil || b.Spend == nil {
+		return true
+	}
+	return m.Currency == b.Spend.Currency && m.Amount.Cmp(b.Spend.Amount) <= 0
+}
+
+// Exhausted indique qu'une dimension du budget est épuisée.
+func (b *Budget) Exhausted() bool {
+	if b == nil {
+		return false
+	}
+	if left, ok := b.TimeLeft(); ok && left <= 0 {
+		return true
+	}
+	if b.Items != nil && *b.Items <= 0 {
+		return true
+	}
+	return b.Spend != nil && b.Spend.Amount.Cmp(Decimal{}) <= 0
+}
+
+// ConsumeItems retire n éléments du budget, côté moteur.
+func (b *Budget) ConsumeItems(n int64) {
+	if b != nil && b.Items != nil {
+		*b.Items -= n
+	}
+}
+
+// ConsumeSpend retire la dépense m du budget, côté moteur.
+func (b *Budget) ConsumeSpend(m Money) error {
+	if b == nil || b.Spend == nil {
+		return nil
+	}
+	if m.Currency != b.Spend.Currency {
+		return fmt.Errorf("cannot spend %s from a %s budget", m.Currency, b.Spend.Currency)
+	}
+	b.Spend.Amount = b.Spend.Amount.Sub(m.Amount)
+	return nil
+}
+
+func toProtoBudget(b *Budget) *proto.Budget {
+	if b == nil {
+		return nil
+	}
+	p := &proto.Budget{UntilUnixMs: unixMillis(b.Until)}
+	if b.Items != nil {
+		p.ItemsLimited, p.Items = true, *b.Items
+	}
+	if b.Spend != nil {
+		p.SpendAmount, p.SpendCurrency = b.Spend.Amount.String(), b.Spend.Currency
+	}
+	return p
+}
+
+func fromProtoBudget(p *proto.Budget) (*Budget, error) {
+	if p == nil {
+		return nil, nil
+	}
+	b := &Budget{Until: fromUnixMillis(p.UntilUnixMs)}
+	if p.ItemsLimited {
+		items := p.Items
+		b.Items = &items
+	}
+	if p.SpendCurrency != "" {
+		amount, err := ParseDecimal(p.SpendAmount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget spend: %w", err)
+		}
+		b.Spend = &Money{Amount: amount, Currency: p.SpendCurrency}
+	}
+	return b, nil
+}
@@ -0,0 +1,69 @@
+package shared
+
+// Règles canoniques des collections vides, appliquées à la réception de
+// chaque nœud et contexte d'exécution, pour qu'un aller-retour ne change pas
+// leur forme :
+//
+//   - Node.With, ExecutionContext.TriggerData, NodeOutputs et Secrets ne
+//     sont jamais nil : absents, ils valent une map vide, si bien que les
+//     expressions voient {} et non null.
+//   - Les autres collections de Node (Needs, Do, OnFailure, Labels,
+//     Annotations, Env, Matrix) valent nil lorsqu'elles sont vides.
+//   - ExecutionContext.FailureData reste nil en l'absence d'échec : sa
+//     présence a un sens.
+//   - ExecutionContext.OutputRefs et Attachments valent nil lorsqu'elles
+//     sont vides.
+//
+// Les valeurs imbriquées (contenu de With, sorties des nœuds) ne sont pas
+// modifiées.
+
+// Canonicalize applique les règles canoniques à n et à ses descendants.
+func (n *Node) Canonicalize() {
+	walkNodes([]*Node{n}, (*Node).canonicalizeFields)
+}
+
+func (n *Node) canonicalizeFields() {
+	if n.With == nil {
+		n.With = map[string]interface{}{}
+	}
+	if len(n.Needs) == 0 {
+		n.Needs = nil
+	}
+	if len(n.Do) == 0 {
+		n.Do = nil
+	}
+	if len(n.OnFailure) == 0 {
+		n.OnFailure = nil
+	}
+	if len(n.Labels) == 0 {
+		n.Labels = nil
+	}
+	if len(n.Annotations) == 0 {
+		n.Annotations = nil
+	}
+	if len(n.Env) == 0 {
+		n.Env = nil
+	}
+	if len(n.Matrix) == 0 {
+		n.Matrix = nil
+	}
+}
+
+// Canonicalize applique les règles canoniques à c.
+func (c *ExecutionContext) Canonicalize() {
+	if c.TriggerData == nil {
+		c.TriggerData = map[string]interface{}{}
+	}
+	if c.NodeOutputs == nil {
+		c.NodeOutputs = map[string]interface{}{}
+	}
+	if c.Secrets == nil {
+		c.Secrets = map[string]string{}
+	}
+	if len(c.OutputRefs) == 0 {
+		c.OutputRefs = nil
+	}
+	if len(c.Attachments) == 0 {
+		c.Attachments = nil
+	}
+}
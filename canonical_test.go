@@ -0,0 +1,156 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodeCanonicalRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Node
+		want Node
+	}{
+		{
+			name: "nil collections",
+			in:   Node{ID: "a", Uses: "x/y"},
+			want: Node{ID: "a", Uses: "x/y", With: map[string]interface{}{}},
+		},
+		{
+			name: "empty collections",
+			in: Node{
+				ID:          "a",
+				Uses:        "x/y",
+				With:        map[string]interface{}{},
+				Needs:       []string{},
+				Do:          []*Node{},
+				OnFailure:   []*Node{},
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				Env:         map[string]string{},
+				Matrix:      map[string][]interface{}{},
+			},
+			want: Node{ID: "a", Uses: "x/y", With: map[string]interface{}{}},
+		},
+		{
+			name: "non-empty collections are kept",
+			in: Node{
+				ID:     "a",
+				Uses:   "x/y",
+				With:   map[string]interface{}{"k": "v"},
+				Needs:  []string{"b"},
+				Labels: map[string]string{"team": "core"},
+			},
+			want: Node{
+				ID:     "a",
+				Uses:   "x/y",
+				With:   map[string]interface{}{"k": "v"},
+				Needs:  []string{"b"},
+				Labels: map[string]string{"team": "core"},
+			},
+		},
+		{
+			name: "children are canonicalized",
+			in:   Node{ID: "a", Uses: "x/y", Do: []*Node{{ID: "b", Uses: "x/z", Needs: []string{}}}},
+			want: Node{ID: "a", Uses: "x/y", With: map[string]interface{}{}, Do: []*Node{{ID: "b", Uses: "x/z", With: map[string]interface{}{}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := toProtoNode(JSONCodec{}, &tt.in, 0)
+			if err != nil {
+				t.Fatalf("toProtoNode: %v", err)
+			}
+			got, err := fromProtoNode(JSONCodec{}, p, 0)
+			if err != nil {
+				t.Fatalf("fromProtoNode: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionContextCanonicalRoundTrip(t *testing.T) {
+	empty := func() ExecutionContext {
+		return ExecutionContext{
+			TriggerData: map[string]interface{}{},
+			NodeOutputs: map[string]interface{}{},
+			Secrets:     map[string]string{},
+		}
+	}
+	tests := []struct {
+		name  string
+		in    ExecutionContext
+		check func(t *testing.T, got ExecutionContext)
+	}{
+		{
+			name: "nil maps become empty",
+			in:   ExecutionContext{},
+			check: func(t *testing.T, got ExecutionContext) {
+				if got.TriggerData == nil || got.NodeOutputs == nil || got.Secrets == nil {
+					t.Errorf("expected non-nil maps, got %#v", got)
+				}
+			},
+		},
+		{
+			name: "empty maps stay empty",
+			in:   empty(),
+			check: func(t *testing.T, got ExecutionContext) {
+				if len(got.TriggerData) != 0 || got.TriggerData == nil {
+					t.Errorf("TriggerData = %#v, want empty map", got.TriggerData)
+				}
+			},
+		},
+		{
+			name: "failure data stays nil without a failure",
+			in:   ExecutionContext{},
+			check: func(t *testing.T, got ExecutionContext) {
+				if got.FailureData != nil {
+					t.Errorf("FailureData = %#v, want nil", got.FailureData)
+				}
+			},
+		},
+		{
+			name: "failure data is kept",
+			in:   ExecutionContext{FailureData: map[string]interface{}{"error": "boom"}},
+			check: func(t *testing.T, got ExecutionContext) {
+				if got.FailureData["error"] != "boom" {
+					t.Errorf("FailureData = %#v", got.FailureData)
+				}
+			},
+		},
+		{
+			name: "empty attachments and output refs become nil",
+			in:   ExecutionContext{Attachments: []Attachment{}, OutputRefs: map[string]OutputRef{}},
+			check: func(t *testing.T, got ExecutionContext) {
+				if got.Attachments != nil || got.OutputRefs != nil {
+					t.Errorf("Attachments = %#v, OutputRefs = %#v, want nil", got.Attachments, got.OutputRefs)
+				}
+			},
+		},
+		{
+			name: "non-object current item",
+			in:   ExecutionContext{CurrentItem: "item"},
+			check: func(t *testing.T, got ExecutionContext) {
+				if got.CurrentItem != "item" {
+					t.Errorf("CurrentItem = %#v, want %q", got.CurrentItem, "item")
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := toProtoExecutionContext(JSONCodec{}, &tt.in)
+			if err != nil {
+				t.Fatalf("toProtoExecutionContext: %v", err)
+			}
+			got, err := fromProtoExecutionContext(JSONCodec{}, p)
+			if err != nil {
+				t.Fatalf("fromProtoExecutionContext: %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
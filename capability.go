@@ -0,0 +1,130 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability est la forme analysée d'une valeur de Node.Uses, par exemple
+// "vendor/service/action@v1". Le segment "*" sert de joker dans les motifs.
+type Capability struct {
+	Segments []string
+	// Version est la version demandée ("v1"), vide si non précisée.
+	Version string
+}
+
+// ParseCapability analyse et normalise une capacité : casse, espaces et
+// barres obliques superflues sont ignorés, et "@1" est équivalent à "@v1".
+func ParseCapability(s string) (Capability, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	var c Capability
+	if i := strings.LastIndexByte(s, '@'); i >= 0 {
+		s, c.Version = s[:i], s[i+1:]
+		if c.Version == "" {
+			return Capability{}, fmt.Errorf("invalid capability %q: empty version", raw)
+		}
+		if !strings.HasPrefix(c.Version, "v") {
+			c.Version = "v" + c.Version
+		}
+		if !validCapabilityToken(c.Version[1:]) {
+			return Capability{}, fmt.Errorf("invalid capability %q: bad version %q", raw, c.Version)
+		}
+	}
+	for _, seg := range strings.Split(s, "/") {
+		if seg == "" {
+			continue
+		}
+		if seg != "*" && !validCapabilityToken(seg) {
+			return Capability{}, fmt.Errorf("invalid capability %q: bad segment %q", raw, seg)
+		}
+		c.Segments = append(c.Segments, seg)
+	}
+	if len(c.Segments) == 0 {
+		return Capability{}, fmt.Errorf("invalid capability %q: empty name", raw)
+	}
+	return c, nil
+}
+
+// MustParseCapability est comme ParseCapability mais panique en cas d'erreur.
+func MustParseCapability(s string) Capability {
+	c, err := ParseCapability(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func validCapabilityToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// Name renvoie la capacité sans sa version.
+func (c Capability) Name() string {
+	return strings.Join(c.Segments, "/")
+}
+
+// String renvoie la forme normalisée de la capacité.
+func (c Capability) String() string {
+	if c.Version == "" {
+		return c.Name()
+	}
+	return c.Name() + "@" + c.Version
+}
+
+// Equal compare deux capacités normalisées.
+func (c Capability) Equal(o Capability) bool {
+	return c.String() == o.String()
+}
+
+// Match indique si c est couverte par le motif pattern. Un segment "*"
+// correspond à exactement un segment, sauf en dernière position où il couvre
+// tous les segments restants ("http/*" couvre "http/get" et "http/v2/get").
+// Un motif sans version couvre toutes les versions.
+func (c Capability) Match(pattern Capability) bool {
+	if pattern.Version != "" && pattern.Version != c.Version {
+		return false
+	}
+	for i, seg := range pattern.Segments {
+		if seg == "*" && i == len(pattern.Segments)-1 {
+			return len(c.Segments) > i
+		}
+		if i >= len(c.Segments) || (seg != "*" && seg != c.Segments[i]) {
+			return false
+		}
+	}
+	return len(c.Segments) == len(pattern.Segments)
+}
+
+// MatchUses est un raccourci pour comparer une valeur de Uses à un motif.
+// Les valeurs invalides ne correspondent à rien.
+func MatchUses(pattern, uses string) bool {
+	p, err := ParseCapability(pattern)
+	if err != nil {
+		return false
+	}
+	c, err := ParseCapability(uses)
+	if err != nil {
+		return false
+	}
+	return c.Match(p)
+}
+
+// SameCapability indique si deux valeurs de Uses désignent la même capacité
+// une fois normalisées.
+func SameCapability(a, b string) bool {
+	ca, errA := ParseCapability(a)
+	cb, errB := ParseCapability(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ca.Equal(cb)
+}
@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// capabilitiesETag calcule l'empreinte d'une liste de capacités.
+func capabilitiesETag(uses []string) string {
+	h := sha256.New()
+	for _, u := range uses {
+		h.Write([]byte(u))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// capabilitiesCache conserve la dernière réponse de GetCapabilities côté
+// client.
+type capabilitiesCache struct {
+	mu      sync.Mutex
+	uses    []string
+	etag    string
+	fetched time.Time
+	valid   bool
+}
+
+// get renvoie la liste en cache si elle a moins de ttl, ainsi que l'ETag à
+// présenter au plugin sinon.
+func (c *capabilitiesCache) get(ttl time.Duration) (uses []string, etag string, fresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.valid {
+		return nil, "", false
+	}
+	if time.Since(c.fetched) < ttl {
+		return append([]string(nil), c.uses...), c.etag, true
+	}
+	return nil, c.etag, false
+}
+
+// revalidate prolonge l'entrée en cache si son ETag est toujours etag.
+func (c *capabilitiesCache) revalidate(etag string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.valid || c.etag != etag {
+		return nil, false
+	}
+	c.fetched = time.Now()
+	return append([]string(nil), c.uses...), true
+}
+
+func (c *capabilitiesCache) store(uses []string, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uses = append([]string(nil), uses...)
+	c.etag = etag
+	c.fetched = time.Now()
+	c.valid = true
+}
+
+func (c *capabilitiesCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uses, c.etag, c.valid = nil, "", false
+}
@@ -0,0 +1,211 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// ErrCaptureNotFound est renvoyée par CaptureStore.Load pour une clé
+// inconnue.
+var ErrCaptureNotFound = errors.New("capture not found")
+
+// CaptureKey identifie une requête capturée.
+type CaptureKey struct {
+	RunID   string
+	NodeID  string
+	Attempt int
+}
+
+func (k CaptureKey) String() string {
+	return k.RunID + "/" + k.NodeID + "/" + strconv.Itoa(k.Attempt)
+}
+
+// CaptureStore conserve les requêtes capturées, sérialisées.
+type CaptureStore interface {
+	Save(key CaptureKey, data []byte) error
+	Load(key CaptureKey) ([]byte, error)
+}
+
+// CaptureOptions paramètre la capture des requêtes Execute et ExecuteStream
+// par le client, pour reproduire un échec avec la requête exacte qui l'a
+// provoqué (voir ReplayCapture). Les valeurs des secrets sont masquées avant l'écriture,
+// où qu'elles apparaissent : données, configuration et environnement des
+// nœuds, paramètres de connexion et cassette.
+type CaptureOptions struct {
+	// Store reçoit les requêtes capturées. Nil : pas de capture.
+	Store CaptureStore
+	// All capture aussi les exécutions réussies. Par défaut, seules les
+	// exécutions en échec le sont.
+	All bool
+	// OnError est appelée lorsqu'une capture échoue ; l'exécution n'en est
+	// pas affectée.
+	OnError func(key CaptureKey, err error)
+}
+
+// capture enregistre la requête de node si les options le demandent.
+func (m *NodeExecutorGRPC) capture(node Node, ctx ExecutionContext, failed bool) {
+	opts := m.opts.Capture
+	if opts.Store == nil || (!failed && !opts.All) {
+		return
+	}
+	key := CaptureKey{RunID: ctx.RunID, NodeID: node.ID, Attempt: ctx.Attempt}
+	data, err := captureRequest(m.opts.Codec, node, ctx, m.opts.MaxNodeDepth)
+	if err == nil {
+		err = opts.Store.Save(key, data)
+	}
+	if err != nil && opts.OnError != nil {
+		opts.OnError(key, err)
+	}
+}
+
+// captureRequest sérialise la requête complète de node, sans delta ni
+// référence d'artefact, et masque les secrets.
+func captureRequest(c Codec, node Node, ctx ExecutionContext, maxDepth int) ([]byte, error) {
+	req, err := toProtoExecuteRequest(c, node, ctx, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	secrets := req.Context.Secrets
+	masked := make(map[string]string, len(secrets))
+	for k := range secrets {
+		masked[k] = redacted
+	}
+	req.Context.Secrets = masked
+	pc := req.Context
+	c = codecOrDefault(c)
+	for _, b := range []*[]byte{&pc.TriggerData, &pc.NodeOutputs, &pc.CurrentItem, &pc.FailureData} {
+		*b = redactEncoded(c, *b, secrets)
+	}
+	pc.Cassette = redactEncoded(JSONCodec{}, pc.Cassette, secrets)
+	if pc.Connection != nil {
+		pc.Connection.Settings = redactEncoded(JSONCodec{}, pc.Connection.Settings, secrets)
+	}
+	stack := []*proto.Node{req.Node}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		n.With = redactEncoded(c, n.With, secrets)
+		for k, v := range n.Env {
+			n.Env[k] = string(redactSecrets([]byte(v), secrets))
+		}
+		stack = append(stack, n.Do...)
+		stack = append(stack, n.OnFailure...)
+		stack = append(stack, n.Compensate)
+	}
+	return protobuf.Marshal(req)
+}
+
+// redactEncoded masque les secrets dans les chaînes de la valeur encodée b,
+// qui reste ainsi décodable même lorsqu'un secret très court figure aussi
+// dans sa syntaxe. Une valeur que c ne sait pas décoder est masquée octet par
+// octet.
+func redactEncoded(c Codec, b []byte, secrets map[string]string) []byte {
+	if len(b) == 0 || len(secrets) == 0 {
+		return b
+	}
+	var v interface{}
+	if err := c.Unmarshal(b, &v); err != nil {
+		return redactSecrets(b, secrets)
+	}
+	out, err := c.Marshal(redactValue(v, secrets))
+	if err != nil {
+		return redactSecrets(b, secrets)
+	}
+	return out
+}
+
+// redactValue masque les secrets dans les chaînes de v, clés comprises.
+func redactValue(v interface{}, secrets map[string]string) interface{} {
+	switch x := v.(type) {
+	case string:
+		return string(redactSecrets([]byte(x), secrets))
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			out[string(redactSecrets([]byte(k), secrets))] = redactValue(e, secrets)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			out[i] = redactValue(e, secrets)
+		}
+		return out
+	}
+	return v
+}
+
+// LoadCapture relit la requête capturée sous key.
+func LoadCapture(store CaptureStore, key CaptureKey) (Node, ExecutionContext, error) {
+	data, err := store.Load(key)
+	if err != nil {
+		return Node{}, ExecutionContext{}, err
+	}
+	req := &proto.ExecuteRequest{}
+	if err := protobuf.Unmarshal(data, req); err != nil {
+		return Node{}, ExecutionContext{}, fmt.Errorf("invalid capture %s: %w", key, err)
+	}
+	// La requête a déjà passé la limite de profondeur du client qui l'a
+	// capturée, quel qu'en soit le réglage.
+	return fromProtoExecuteRequest(nil, req, math.MaxInt)
+}
+
+// ReplayCapture réémet contre exec la requête capturée sous key. Les secrets
+// ayant été masqués, ceux à utiliser sont fournis par secrets.
+func ReplayCapture(exec NodeExecutor, store CaptureStore, key CaptureKey, secrets map[string]string) (*Result, error) {
+	node, ctx, err := LoadCapture(store, key)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Secrets = secrets
+	return ExecuteResult(exec, node, ctx)
+}
+
+// DirCaptureStore conserve les captures dans un répertoire, un fichier par
+// requête : <run>/<nœud>/<tentative>.pb.
+type DirCaptureStore string
+
+func (d DirCaptureStore) path(key CaptureKey) string {
+	return filepath.Join(string(d), pathComponent(key.RunID), pathComponent(key.NodeID), strconv.Itoa(key.Attempt)+".pb")
+}
+
+// pathComponent rend s utilisable comme nom de fichier, sans séparateur ni
+// renvoi au répertoire parent.
+func pathComponent(s string) string {
+	e := url.PathEscape(s)
+	switch e {
+	case "":
+		return "_"
+	case ".", "..":
+		return strings.ReplaceAll(e, ".", "%2E")
+	}
+	return e
+}
+
+func (d DirCaptureStore) Save(key CaptureKey, data []byte) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (d DirCaptureStore) Load(key CaptureKey) ([]byte, error) {
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", key, ErrCaptureNotFound)
+	}
+	return data, err
+}
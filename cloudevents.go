@@ -0,0 +1,148 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encodage des événements au format CloudEvents 1.0 pour le transport HTTP.
+// Le type CloudEvents est le type de l'événement préfixé par
+// CloudEventTypePrefix ; les champs propres à Orkestra sont portés par des
+// attributs d'extension.
+const (
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsContentType est le type MIME du mode structuré.
+	CloudEventsContentType = "application/cloudevents+json"
+	CloudEventTypePrefix   = "io.orkestra."
+
+	ceRunID    = "orkestrarunid"
+	ceWorkflow = "orkestraworkflow"
+	ceNodeID   = "orkestranodeid"
+	ceVersion  = "orkestraversion"
+)
+
+// cloudEventAttributes renvoie les attributs de e, données exclues. source
+// identifie l'émetteur (« /engines/eu-1 ») et ne peut être vide.
+func cloudEventAttributes(e Event, source string) map[string]string {
+	attrs := map[string]string{
+		"specversion": CloudEventsSpecVersion,
+		"id":          e.ID,
+		"source":      source,
+		"type":        CloudEventTypePrefix + string(e.Type),
+		"time":        e.Time.UTC().Format(time.RFC3339Nano),
+		"subject":     e.RunID,
+		ceRunID:       e.RunID,
+		ceVersion:     strconv.Itoa(e.Version),
+	}
+	if e.NodeID != "" {
+		attrs["subject"] = e.RunID + "/" + e.NodeID
+		attrs[ceNodeID] = e.NodeID
+	}
+	if e.Workflow != "" {
+		attrs[ceWorkflow] = e.Workflow
+	}
+	return attrs
+}
+
+// EncodeCloudEvent encode e en mode structuré : le corps est l'événement
+// complet, à envoyer avec l'en-tête Content-Type CloudEventsContentType.
+func EncodeCloudEvent(e Event, source string) ([]byte, error) {
+	if source == "" {
+		return nil, fmt.Errorf("cloudevents: empty source")
+	}
+	doc := map[string]interface{}{}
+	for k, v := range cloudEventAttributes(e, source) {
+		doc[k] = v
+	}
+	if len(e.Data) > 0 {
+		doc["datacontenttype"] = "application/json"
+		doc["data"] = e.Data
+	}
+	return json.Marshal(doc)
+}
+
+// EncodeCloudEventBinary encode e en mode binaire : les attributs sont
+// écrits dans h (en-têtes ce-*) et le corps renvoyé contient les données.
+func EncodeCloudEventBinary(e Event, source string, h http.Header) ([]byte, error) {
+	if source == "" {
+		return nil, fmt.Errorf("cloudevents: empty source")
+	}
+	for k, v := range cloudEventAttributes(e, source) {
+		h.Set("ce-"+k, v)
+	}
+	h.Set("Content-Type", "application/json")
+	return e.Data, nil
+}
+
+// DecodeCloudEvent décode un événement reçu en HTTP, en mode structuré ou
+// binaire selon le Content-Type, et renvoie aussi sa source.
+func DecodeCloudEvent(h http.Header, body []byte) (Event, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	attrs := map[string]string{}
+	var data json.RawMessage
+	if mediaType == CloudEventsContentType {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return Event{}, "", fmt.Errorf("cloudevents: invalid structured event: %w", err)
+		}
+		for k, raw := range doc {
+			if k == "data" {
+				data = raw
+				continue
+			}
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return Event{}, "", fmt.Errorf("cloudevents: attribute %s is not a string", k)
+			}
+			attrs[k] = s
+		}
+	} else {
+		for k, v := range h {
+			if name := strings.ToLower(k); strings.HasPrefix(name, "ce-") && len(v) > 0 {
+				attrs[strings.TrimPrefix(name, "ce-")] = v[0]
+			}
+		}
+		if len(body) > 0 {
+			data = body
+		}
+	}
+	return fromCloudEventAttributes(attrs, data)
+}
+
+func fromCloudEventAttributes(attrs map[string]string, data json.RawMessage) (Event, string, error) {
+	if v := attrs["specversion"]; v != CloudEventsSpecVersion {
+		return Event{}, "", fmt.Errorf("cloudevents: unsupported specversion %q", v)
+	}
+	typ := attrs["type"]
+	if !strings.HasPrefix(typ, CloudEventTypePrefix) {
+		return Event{}, "", fmt.Errorf("cloudevents: unexpected type %q", typ)
+	}
+	e := Event{
+		Version:  EventVersion,
+		ID:       attrs["id"],
+		Type:     EventType(strings.TrimPrefix(typ, CloudEventTypePrefix)),
+		RunID:    attrs[ceRunID],
+		Workflow: attrs[ceWorkflow],
+		NodeID:   attrs[ceNodeID],
+		Data:     data,
+	}
+	if v, ok := attrs[ceVersion]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > EventVersion {
+			return Event{}, "", fmt.Errorf("cloudevents: unsupported event version %q", v)
+		}
+		e.Version = n
+	}
+	if t := attrs["time"]; t != "" {
+		var err error
+		if e.Time, err = time.Parse(time.RFC3339Nano, t); err != nil {
+			return Event{}, "", fmt.Errorf("cloudevents: invalid time: %w", err)
+		}
+	}
+	return e, attrs["source"], nil
+}
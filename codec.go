@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Codec sérialise les charges utiles échangées avec le plugin (With,
+// TriggerData, NodeOutputs, résultats, éléments de flux). Les octets produits
+// restent du JSON : le pair peut utiliser un autre codec, et les tables
+// d'indications de type s'appliquent au document décodé. Une implémentation
+// plus rapide (jsoniter, segmentio/encoding...) s'adapte en quelques lignes ;
+// elle doit décoder les nombres en float64 et les objets en
+// map[string]interface{}, comme encoding/json. Seul ProtoCodec produit un
+// autre format, négocié avec le pair.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec est le codec par défaut, fondé sur encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// ProtoCodec encode les charges utiles en google.protobuf.Value binaire,
+// plus compact que JSON. Un client ne l'utilise qu'avec les plugins qui
+// annoncent FeatureProtoPayloads, et un serveur ne répond ainsi qu'aux
+// clients qui l'ont utilisé pour leur requête ; sinon, les deux se replient
+// sur JSONCodec. Toute version de la bibliothèque qui annonce
+// FeatureProtoPayloads lit ces charges, quel que soit son propre codec.
+type ProtoCodec struct{}
+
+// protoPayloadMarker précède les charges encodées par ProtoCodec ; aucun
+// document JSON ne commence par un octet nul.
+const protoPayloadMarker = 0x00
+
+func (ProtoCodec) Marshal(v interface{}) ([]byte, error) {
+	pv, err := structpb.NewValue(v)
+	if err != nil {
+		// Types que structpb ne connaît pas (structures, entiers non
+		// signés...) : leur forme JSON.
+		var plain interface{}
+		if err := jsonRoundTrip(v, &plain); err != nil {
+			return nil, err
+		}
+		if pv, err = structpb.NewValue(plain); err != nil {
+			return nil, err
+		}
+	}
+	b, err := proto.Marshal(pv)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{protoPayloadMarker}, b...), nil
+}
+
+// Unmarshal lit aussi les charges JSON, envoyées par un pair qui n'utilise
+// pas ProtoCodec.
+func (ProtoCodec) Unmarshal(data []byte, v interface{}) error {
+	if !isProtoPayload(data) {
+		return json.Unmarshal(data, v)
+	}
+	var pv structpb.Value
+	if err := proto.Unmarshal(data[1:], &pv); err != nil {
+		return err
+	}
+	if p, ok := v.(*interface{}); ok {
+		*p = pv.AsInterface()
+		return nil
+	}
+	return jsonRoundTrip(pv.AsInterface(), v)
+}
+
+func isProtoPayload(data []byte) bool {
+	return len(data) > 0 && data[0] == protoPayloadMarker
+}
+
+func jsonRoundTrip(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// payloadCodec est un codec JSON qui lit en plus les charges de ProtoCodec.
+type payloadCodec struct {
+	Codec
+}
+
+func (c payloadCodec) Unmarshal(data []byte, v interface{}) error {
+	if isProtoPayload(data) {
+		return ProtoCodec{}.Unmarshal(data, v)
+	}
+	return c.Codec.Unmarshal(data, v)
+}
+
+// codecOrDefault renvoie c, ou JSONCodec s'il n'est pas renseigné, capable
+// de lire les charges de ProtoCodec.
+func codecOrDefault(c Codec) Codec {
+	switch c.(type) {
+	case nil:
+		return payloadCodec{JSONCodec{}}
+	case ProtoCodec, payloadCodec:
+		return c
+	}
+	return payloadCodec{c}
+}
+
+// isProtoCodec indique si c est ProtoCodec.
+func isProtoCodec(c Codec) bool {
+	_, ok := c.(ProtoCodec)
+	return ok
+}
+
+// codec renvoie le codec des charges envoyées au plugin : ClientOptions.Codec,
+// sauf ProtoCodec tant que le plugin n'a pas annoncé FeatureProtoPayloads.
+// Les fonctionnalités ne sont pas lues ici : avant le premier GetInfo, les
+// charges sont en JSON.
+func (m *NodeExecutorGRPC) codec() Codec {
+	if isProtoCodec(m.opts.Codec) && !m.protoPayloads() {
+		return codecOrDefault(nil)
+	}
+	return codecOrDefault(m.opts.Codec)
+}
+
+// protoPayloads indique que les charges sont envoyées avec ProtoCodec.
+func (m *NodeExecutorGRPC) protoPayloads() bool {
+	if !isProtoCodec(m.opts.Codec) {
+		return false
+	}
+	features := m.features.Load()
+	return features != nil && slices.Contains(*features, FeatureProtoPayloads)
+}
+
+// codec renvoie le codec des réponses à l'appel ctx : ServerOptions.Codec,
+// sauf ProtoCodec si le client ne l'a pas utilisé pour sa requête.
+func (s *NodeExecutorGRPCServer) codec(ctx context.Context) Codec {
+	if isProtoCodec(s.options.Codec) && CallMetadataFromContext(ctx).PayloadCodec != PayloadCodecProto {
+		return codecOrDefault(nil)
+	}
+	return codecOrDefault(s.options.Codec)
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"fmt"
+)
+
+// CompatibilityError est renvoyée par CheckCompatibility lorsqu'un plugin ne
+// peut pas être chargé par la version courante du moteur.
+type CompatibilityError struct {
+	Plugin          string
+	EngineVersion   string
+	RequiredVersion string
+	// Feature est la fonctionnalité à l'origine de l'exigence, vide si elle
+	// provient de Manifest.MinEngineVersion.
+	Feature Feature
+}
+
+func (e *CompatibilityError) Error() string {
+	if e.Feature == "" {
+		return fmt.Sprintf("plugin %q requires engine >= %s (running %s)", e.Plugin, e.RequiredVersion, e.EngineVersion)
+	}
+	spec, ok := features[e.Feature]
+	if !ok {
+		return fmt.Sprintf("plugin %q requires feature %q unknown to engine %s; upgrade the engine", e.Plugin, e.Feature, e.EngineVersion)
+	}
+	return fmt.Sprintf("plugin %q requires engine >= %s for %s (running %s)", e.Plugin, e.RequiredVersion, spec.description, e.EngineVersion)
+}
+
+// CheckCompatibility vérifie qu'un plugin peut être utilisé par un moteur en
+// version engineVersion : version minimale déclarée dans le manifeste et
+// fonctionnalités du protocole requises. Elle renvoie une *CompatibilityError
+// décrivant la mise à jour nécessaire.
+func CheckCompatibility(engineVersion string, info PluginInfo) error {
+	engine, err := parseSemver(engineVersion)
+	if err != nil {
+		return fmt.Errorf("invalid engine version: %w", err)
+	}
+
+	if minVersion := info.Manifest.MinEngineVersion; minVersion != "" {
+		required, err := parseSemver(minVersion)
+		if err != nil {
+			return fmt.Errorf("plugin %q declares an invalid minimum engine version: %w", info.Name, err)
+		}
+		if engine.compare(required) < 0 {
+			return &CompatibilityError{Plugin: info.Name, EngineVersion: engineVersion, RequiredVersion: minVersion}
+		}
+	}
+
+	for _, f := range info.Manifest.Requires {
+		spec, ok := features[f]
+		if !ok {
+			return &CompatibilityError{Plugin: info.Name, EngineVersion: engineVersion, Feature: f}
+		}
+		required, err := parseSemver(spec.since)
+		if err != nil {
+			return err
+		}
+		if engine.compare(required) < 0 {
+			return &CompatibilityError{Plugin: info.Name, EngineVersion: engineVersion, RequiredVersion: spec.since, Feature: f}
+		}
+	}
+	return nil
+}
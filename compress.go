@@ -0,0 +1,173 @@
+package shared
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// DefaultCompressionThreshold est la taille cumulée de TriggerData et
+// NodeOutputs au-delà de laquelle le client les compresse, lorsque
+// ClientOptions.CompressionThreshold n'est pas renseigné.
+const DefaultCompressionThreshold = 1 << 20
+
+// MaxDecompressedPayload borne la taille décompressée de TriggerData comme
+// de NodeOutputs : au-delà, la requête est refusée plutôt que de laisser un
+// pair épuiser la mémoire du plugin avec quelques kilo-octets compressés.
+const MaxDecompressedPayload = 256 << 20
+
+// Valeurs de payload_encoding. Zstd, plus rapide et plus efficace, est
+// utilisé avec les plugins qui annoncent FeatureZstd ; gzip avec ceux qui
+// n'annoncent que FeatureCompression.
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+func (o ClientOptions) compressionThreshold() int {
+	if o.CompressionThreshold != 0 {
+		return o.CompressionThreshold
+	}
+	return DefaultCompressionThreshold
+}
+
+// compressContext compresse TriggerData et NodeOutputs lorsqu'ils dépassent
+// le seuil et que le plugin annonce FeatureCompression. Le GetInfo qui le
+// vérifie n'est émis qu'à la première charge volumineuse.
+func (m *NodeExecutorGRPC) compressContext(pCtx *proto.ExecutionContext) error {
+	threshold := m.opts.compressionThreshold()
+	if pCtx == nil || threshold < 0 || len(pCtx.TriggerData)+len(pCtx.NodeOutputs) < threshold {
+		return nil
+	}
+	if m.loadManifest() == nil {
+		return nil
+	}
+	encoding, compress := encodingGzip, gzipBytes
+	switch {
+	case m.zstd.Load():
+		encoding, compress = encodingZstd, zstdBytes
+	case !m.compression.Load():
+		return nil
+	}
+	var err error
+	if pCtx.TriggerData, err = compress(pCtx.TriggerData); err != nil {
+		return err
+	}
+	if pCtx.NodeOutputs, err = compress(pCtx.NodeOutputs); err != nil {
+		return err
+	}
+	pCtx.PayloadEncoding = encoding
+	return nil
+}
+
+// decompressContext est l'inverse de compressContext.
+func decompressContext(pCtx *proto.ExecutionContext) error {
+	if pCtx.PayloadEncoding == "" {
+		return nil
+	}
+	decompress := decompressor(pCtx.PayloadEncoding)
+	if decompress == nil {
+		return fmt.Errorf("unsupported payload encoding %q", pCtx.PayloadEncoding)
+	}
+	var err error
+	if pCtx.TriggerData, err = decompress(pCtx.TriggerData); err != nil {
+		return fmt.Errorf("failed to decompress trigger data: %w", err)
+	}
+	if pCtx.NodeOutputs, err = decompress(pCtx.NodeOutputs); err != nil {
+		return fmt.Errorf("failed to decompress node outputs: %w", err)
+	}
+	pCtx.PayloadEncoding = ""
+	return nil
+}
+
+// decompressor renvoie la fonction de décompression de encoding, nil s'il
+// est inconnu.
+func decompressor(encoding string) func([]byte) ([]byte, error) {
+	switch encoding {
+	case encodingGzip:
+		return gunzipBytes
+	case encodingZstd:
+		return unzstdBytes
+	}
+	return nil
+}
+
+func gzipBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gunzipBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, MaxDecompressedPayload+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxDecompressedPayload {
+		return nil, fmt.Errorf("decompressed payload exceeds %d bytes", MaxDecompressedPayload)
+	}
+	return out, nil
+}
+
+// L'encodeur et le décodeur zstd sont partagés : EncodeAll et DecodeAll
+// peuvent être appelées en parallèle.
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxDecompressedPayload), zstd.WithDecoderConcurrency(0))
+	})
+)
+
+func zstdBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	enc, err := zstdEncoder()
+	if err != nil {
+		return nil, err
+	}
+	return enc.EncodeAll(b, nil), nil
+}
+
+func unzstdBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	dec, err := zstdDecoder()
+	if err != nil {
+		return nil, err
+	}
+	out, err := dec.DecodeAll(b, nil)
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) || len(out) > MaxDecompressedPayload {
+		return nil, fmt.Errorf("decompressed payload exceeds %d bytes", MaxDecompressedPayload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,90 @@
+package shared
+
+import (
+	"context"
+	"sync"
+)
+
+// Modèle de concurrence : le moteur peut appeler Execute (et ExecuteStream)
+// depuis plusieurs goroutines à la fois sur un même plugin. Un plugin qui ne
+// le supporte pas le déclare via Manifest.MaxConcurrency ; la limite est alors
+// appliquée côté serveur, et côté client dès que GetInfo a été appelé.
+
+// limiter borne le nombre d'appels simultanés. Sa limite peut changer à tout
+// moment : elle s'applique aux nouveaux appels, qui attendent que les appels
+// en cours, admis sous l'ancienne limite, soient repassés en dessous.
+type limiter struct {
+	mu     sync.Mutex
+	limit  int
+	active int
+	// wake est fermé, puis remplacé, à chaque place libérée ou changement de
+	// limite, pour réveiller les appels en attente.
+	wake chan struct{}
+}
+
+func newLimiter(n int) *limiter {
+	l := &limiter{}
+	l.setLimit(n)
+	return l
+}
+
+// setLimit fixe la limite, zéro ou négatif pour aucune limite.
+func (l *limiter) setLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = n
+	l.broadcast()
+}
+
+// acquire attend une place ou l'annulation de ctx.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	for {
+		l.mu.Lock()
+		if l.limit <= 0 || l.active < l.limit {
+			l.active++
+			l.mu.Unlock()
+			var once sync.Once
+			return func() { once.Do(l.release) }, nil
+		}
+		if l.wake == nil {
+			l.wake = make(chan struct{})
+		}
+		wake := l.wake
+		l.mu.Unlock()
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	l.broadcast()
+}
+
+// broadcast réveille les appels en attente ; l.mu doit être tenu.
+func (l *limiter) broadcast() {
+	if l.wake != nil {
+		close(l.wake)
+		l.wake = nil
+	}
+}
+
+// minLimit renvoie la plus petite des limites strictement positives, zéro si
+// aucune ne l'est.
+func minLimit(limits ...int) int {
+	min := 0
+	for _, n := range limits {
+		if n > 0 && (min == 0 || n < min) {
+			min = n
+		}
+	}
+	return min
+}
@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Configuration est une modification de la configuration d'un plugin en
+// cours d'exécution. Les champs nil sont inchangés.
+type Configuration struct {
+	WireLog *WireLogOptions
+	// Settings sont les réglages globaux du plugin, décrits par
+	// Manifest.Settings et reçus par Configurable. Les réglages absents
+	// reprennent leur valeur par défaut.
+	Settings map[string]interface{}
+}
+
+// Configure modifie la configuration du plugin à chaud. Les plugins qui ne
+// le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) Configure(cfg Configuration) error {
+	req, err := toProtoConfigureRequest(cfg)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	if _, err := m.client.Configure(callCtx, req); err != nil {
+		return withTimeout(fromRPCError("Configure", err), m.opts.callTimeout())
+	}
+	return nil
+}
+
+func (s *NodeExecutorGRPCServer) Configure(ctx context.Context, req *proto.ConfigureRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.configure(req); err != nil {
+		return nil, err
+	}
+	return &proto.Empty{}, nil
+}
+
+// configure applique req, reçue par Configure ou par le flux de contrôle, et
+// renvoie la configuration appliquée : réglages validés et complétés de leurs
+// valeurs par défaut.
+func (s *NodeExecutorGRPCServer) configure(req *proto.ConfigureRequest) (Configuration, error) {
+	var cfg Configuration
+	if req.WireLog != nil {
+		opts := fromProtoWireLog(req.WireLog)
+		if opts.Path != "" && opts.Path != s.options.WireLog.Path {
+			return Configuration{}, status.Error(codes.PermissionDenied, "wire log path can only be set in the plugin's server options")
+		}
+		opts.Path = s.options.WireLog.Path
+		if err := s.setWireLog(opts); err != nil {
+			return Configuration{}, err
+		}
+		cfg.WireLog = &opts
+	}
+	if len(req.Settings) > 0 {
+		settings, err := s.applySettings(req.Settings)
+		if err != nil {
+			return Configuration{}, err
+		}
+		cfg.Settings = settings
+	}
+	return cfg, nil
+}
+
+// setWireLog active, reconfigure ou désactive le journal des échanges.
+func (s *NodeExecutorGRPCServer) setWireLog(opts WireLogOptions) error {
+	var l *wireLogger
+	if opts.Enabled {
+		var err error
+		if l, err = newWireLogger(opts, s.options.Codec); err != nil {
+			return err
+		}
+	}
+	if old := s.wireLog.Swap(l); old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+func toProtoConfigureRequest(cfg Configuration) (*proto.ConfigureRequest, error) {
+	req := &proto.ConfigureRequest{}
+	if cfg.WireLog != nil {
+		req.WireLog = toProtoWireLog(*cfg.WireLog)
+	}
+	if cfg.Settings != nil {
+		var err error
+		if req.Settings, err = json.Marshal(cfg.Settings); err != nil {
+			return nil, fmt.Errorf("failed to encode settings: %w", err)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,137 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// Connection est un profil de connexion : des identifiants et des réglages
+// nommés, configurés une fois pour une intégration et partagés par les nœuds
+// de tous les workflows qui le désignent (Node.Connection, « connection:
+// my-stripe »). Il se distingue de With, qui décrit l'appel lui-même.
+type Connection struct {
+	// Name est le nom sous lequel les nœuds désignent la connexion.
+	Name string `json:"name"`
+	// Integration est le motif des capacités auxquelles la connexion est
+	// destinée (voir MatchUses), par exemple "stripe/*" ; vide si elle
+	// convient à toutes.
+	Integration string `json:"integration,omitempty"`
+	// Settings sont les réglages non secrets (région, URL de base, compte).
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	// Secrets sont les identifiants. Ils sont ajoutés à
+	// ExecutionContext.Secrets, et ne sont donc jamais journalisés en clair.
+	Secrets map[string]string `json:"-"`
+}
+
+// ErrConnectionNotFound est renvoyée par ConnectionResolver pour une
+// connexion inconnue.
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// ConnectionResolver fournit au client les connexions désignées par les
+// nœuds, pour le locataire tenant (ExecutionContext.TenantID).
+type ConnectionResolver interface {
+	ResolveConnection(tenant, name string) (Connection, error)
+}
+
+// Connections est un ConnectionResolver en mémoire, indexé par nom et
+// commun à tous les locataires.
+type Connections map[string]Connection
+
+func (c Connections) ResolveConnection(tenant, name string) (Connection, error) {
+	conn, ok := c[name]
+	if !ok {
+		return Connection{}, fmt.Errorf("%w: %s", ErrConnectionNotFound, name)
+	}
+	return conn, nil
+}
+
+// ConnectionError signale qu'un nœud désigne une connexion qui ne peut être
+// utilisée : inconnue, ou destinée à une autre intégration.
+type ConnectionError struct {
+	Connection string
+	Uses       string
+	Err        error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("connection %q cannot be used by %s: %v", e.Connection, e.Uses, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error { return e.Err }
+
+// Code classe l'erreur dans le catalogue : celui de la cause s'il est
+// connu, CodeNotFound pour une connexion inconnue, CodeUpstreamUnavailable
+// pour un ConnectionResolver en échec.
+func (e *ConnectionError) Code() ErrorCode {
+	if c := CodeOf(e.Err); c != "" {
+		return c
+	}
+	if errors.Is(e.Err, ErrConnectionNotFound) {
+		return CodeNotFound
+	}
+	return CodeUpstreamUnavailable
+}
+
+func (e *ConnectionError) FailureCode() string { return string(e.Code()) }
+
+func (e *ConnectionError) Retryable() bool { return e.Code().Retryable() }
+
+// resolveConnection renseigne ctx.Connection d'après node.Connection et
+// ajoute ses secrets à ctx.Secrets, sans remplacer ceux que le contexte
+// fournit déjà.
+func (m *NodeExecutorGRPC) resolveConnection(node Node, ctx ExecutionContext) (ExecutionContext, error) {
+	if node.Connection == "" {
+		return ctx, nil
+	}
+	connErr := func(err error) error {
+		return &ConnectionError{Connection: node.Connection, Uses: node.Uses, Err: err}
+	}
+	if m.opts.Connections == nil {
+		return ctx, connErr(ErrConnectionNotFound)
+	}
+	conn, err := m.opts.Connections.ResolveConnection(ctx.TenantID, node.Connection)
+	if err != nil {
+		return ctx, connErr(err)
+	}
+	if conn.Integration != "" && !MatchUses(conn.Integration, node.Uses) {
+		return ctx, connErr(fmt.Errorf("connection is for integration %s: %w", conn.Integration, ErrInvalidInput))
+	}
+	secrets := make(map[string]string, len(ctx.Secrets)+len(conn.Secrets))
+	maps.Copy(secrets, conn.Secrets)
+	maps.Copy(secrets, ctx.Secrets)
+	ctx.Secrets = secrets
+	conn.Secrets = nil
+	ctx.Connection = &conn
+	return ctx, nil
+}
+
+func toProtoConnection(c *Connection) (*proto.Connection, error) {
+	if c == nil {
+		return nil, nil
+	}
+	var settings []byte
+	if len(c.Settings) > 0 {
+		var err error
+		if settings, err = json.Marshal(c.Settings); err != nil {
+			return nil, fmt.Errorf("invalid connection settings: %w", err)
+		}
+	}
+	return &proto.Connection{Name: c.Name, Integration: c.Integration, Settings: settings}, nil
+}
+
+func fromProtoConnection(p *proto.Connection) (*Connection, error) {
+	if p == nil {
+		return nil, nil
+	}
+	c := &Connection{Name: p.Name, Integration: p.Integration}
+	if len(p.Settings) > 0 {
+		if err := json.Unmarshal(p.Settings, &c.Settings); err != nil {
+			return nil, fmt.Errorf("invalid connection settings: %w", err)
+		}
+	}
+	return c, nil
+}
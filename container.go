@@ -0,0 +1,205 @@
+package shared
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-plugin/runner"
+)
+
+// ContainerOptions paramètre l'exécution d'un plugin dans un conteneur.
+//
+// Le plugin communique avec le moteur par une socket Unix créée dans un
+// répertoire temporaire de l'hôte, monté au même chemin dans le conteneur :
+// l'hôte et le conteneur doivent donc partager un noyau (Docker ou Podman sous
+// Linux).
+type ContainerOptions struct {
+	Image string
+	Args  []string
+	// Runtime est la commande du moteur de conteneurs, "docker" par défaut
+	// ("podman" est compatible).
+	Runtime string
+	// Network est le réseau du conteneur, "none" par défaut : un plugin qui
+	// appelle des API externes doit le renseigner explicitement ("bridge").
+	Network string
+	// User est l'utilisateur du conteneur ("uid:gid"). Il doit pouvoir écrire
+	// dans le répertoire de la socket ; par défaut, celui du moteur.
+	User string
+	// ReadOnly monte le système de fichiers du conteneur en lecture seule.
+	ReadOnly bool
+	Env      []string
+	// Limits est traduit en --memory, --cpus et --pids-limit.
+	Limits ResourceLimits
+	// ExtraArgs est ajouté tel quel à la commande run, avant l'image.
+	ExtraArgs []string
+	PluginKey string
+	// Executors : voir LaunchOptions.Executors.
+	Executors     []string
+	ClientOptions ClientOptions
+}
+
+// LaunchContainer démarre un plugin dans un conteneur et renvoie son
+// exécuteur, utilisable comme celui d'un plugin local.
+func LaunchContainer(opts ContainerOptions) (*LaunchedPlugin, error) {
+	if opts.Image == "" {
+		return nil, fmt.Errorf("container plugin requires an image")
+	}
+	key := opts.PluginKey
+	if key == "" {
+		key = DefaultPluginKey
+	}
+	p := &LaunchedPlugin{}
+	err := p.start(opts.Image, key, opts.Executors, &plugin.ClientConfig{
+		HandshakeConfig:  HandshakeConfig,
+		Plugins:          pluginSet(key, opts.Executors, opts.ClientOptions),
+		SkipHostEnv:      true,
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		RunnerFunc: func(l hclog.Logger, cmd *exec.Cmd, tmpDir string) (runner.Runner, error) {
+			return newContainerRunner(opts, cmd, tmpDir)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// runtimeEnv sont les variables de l'hôte dont la commande du moteur de
+// conteneurs a besoin ; elles ne sont pas transmises au plugin.
+var runtimeEnv = []string{"PATH", "HOME", "DOCKER_HOST", "DOCKER_CONFIG", "DOCKER_CONTEXT", "XDG_RUNTIME_DIR"}
+
+var invalidContainerName = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// containerRunner implémente runner.Runner en pilotant la CLI du moteur de
+// conteneurs. Le handshake go-plugin passe par la sortie standard de
+// "run -i", qui relaie celle du conteneur.
+type containerRunner struct {
+	runtime string
+	name    string
+	image   string
+	cmd     *exec.Cmd
+	stdout  io.ReadCloser
+	stderr  io.ReadCloser
+}
+
+func newContainerRunner(opts ContainerOptions, spec *exec.Cmd, tmpDir string) (*containerRunner, error) {
+	rt := opts.Runtime
+	if rt == "" {
+		rt = "docker"
+	}
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	base := opts.Image[strings.LastIndex(opts.Image, "/")+1:]
+	if i := strings.IndexAny(base, ":@"); i >= 0 {
+		base = base[:i]
+	}
+	name := "orkestra-" + invalidContainerName.ReplaceAllString(base, "-") + "-" + hex.EncodeToString(suffix)
+
+	network := opts.Network
+	if network == "" {
+		network = "none"
+	}
+	user := opts.User
+	if user == "" {
+		user = fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	}
+	args := []string{"run", "--rm", "-i", "--name", name,
+		"--network", network,
+		"--user", user,
+		"-v", tmpDir + ":" + tmpDir,
+	}
+	if opts.ReadOnly {
+		args = append(args, "--read-only")
+	}
+	if opts.Limits.MemoryBytes > 0 {
+		args = append(args, "--memory", fmt.Sprint(opts.Limits.MemoryBytes))
+	}
+	if opts.Limits.CPUs > 0 {
+		args = append(args, "--cpus", fmt.Sprint(opts.Limits.CPUs))
+	}
+	if opts.Limits.MaxProcesses > 0 {
+		args = append(args, "--pids-limit", fmt.Sprint(opts.Limits.MaxProcesses))
+	}
+
+	// Les variables sont transmises par nom (-e NOM) : le moteur de
+	// conteneurs lit leur valeur dans son propre environnement, ce qui évite
+	// d'exposer les secrets dans la liste des processus.
+	var env []string
+	for _, name := range runtimeEnv {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	for _, kv := range append(append([]string(nil), spec.Env...), opts.Env...) {
+		name, _, _ := strings.Cut(kv, "=")
+		args = append(args, "-e", name)
+		env = append(env, kv)
+	}
+	args = append(args, opts.ExtraArgs...)
+	args = append(args, opts.Image)
+	args = append(args, opts.Args...)
+
+	cmd := exec.Command(rt, args...)
+	cmd.Env = env
+	return &containerRunner{runtime: rt, name: name, image: opts.Image, cmd: cmd}, nil
+}
+
+func (r *containerRunner) Start(ctx context.Context) error {
+	var err error
+	if r.stdout, err = r.cmd.StdoutPipe(); err != nil {
+		return err
+	}
+	if r.stderr, err = r.cmd.StderrPipe(); err != nil {
+		return err
+	}
+	if err := r.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", r.runtime, err)
+	}
+	return nil
+}
+
+func (r *containerRunner) Diagnose(ctx context.Context) string {
+	out, err := exec.CommandContext(ctx, r.runtime, "inspect", "--format",
+		"status={{.State.Status}} exit={{.State.ExitCode}} error={{.State.Error}}", r.name).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("container %s: %s", r.name, strings.TrimSpace(string(out)))
+}
+
+func (r *containerRunner) Stdout() io.ReadCloser { return r.stdout }
+func (r *containerRunner) Stderr() io.ReadCloser { return r.stderr }
+func (r *containerRunner) Name() string          { return r.image }
+func (r *containerRunner) ID() string            { return r.name }
+
+func (r *containerRunner) Wait(ctx context.Context) error {
+	return r.cmd.Wait()
+}
+
+func (r *containerRunner) Kill(ctx context.Context) error {
+	// Tuer la CLI ne suffit pas toujours à arrêter le conteneur.
+	_ = exec.CommandContext(ctx, r.runtime, "kill", r.name).Run()
+	if r.cmd.Process != nil {
+		_ = r.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// Le répertoire de la socket est monté au même chemin : les adresses sont
+// identiques des deux côtés.
+func (r *containerRunner) PluginToHost(network, addr string) (string, string, error) {
+	return network, addr, nil
+}
+
+func (r *containerRunner) HostToPlugin(network, addr string) (string, string, error) {
+	return network, addr, nil
+}
@@ -0,0 +1,190 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// MaxContinuationDelay borne ScheduleContinuation.After.
+const MaxContinuationDelay = 366 * 24 * time.Hour
+
+// MaxContinuations borne le nombre de reprises d'un nœud par
+// ExecuteContinuations.
+const MaxContinuations = 1000
+
+// ScheduleContinuation, renvoyée dans Result.Continuation, demande au moteur
+// de rappeler le nœud après After, avec State dans
+// ExecutionContext.Continuation. Un nœud peut ainsi attendre des jours
+// (relance d'une campagne, délai de rétractation) sans qu'un processus reste
+// ouvert : le moteur suspend le nœud et conserve State avec l'exécution.
+// Seules les capacités marquées CapabilitySpec.Continuable peuvent en
+// renvoyer.
+//
+// En JSON, After est exprimé en millisecondes : {"after_ms": ..., "state": ...}.
+type ScheduleContinuation struct {
+	After time.Duration
+	// State est sérialisé par le codec des charges utiles ; il doit rester
+	// petit, le moteur le conservant jusqu'à la reprise.
+	State interface{}
+}
+
+type scheduleContinuationJSON struct {
+	AfterMs int64       `json:"after_ms"`
+	State   interface{} `json:"state,omitempty"`
+}
+
+func (c ScheduleContinuation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(scheduleContinuationJSON{AfterMs: c.After.Milliseconds(), State: c.State})
+}
+
+func (c *ScheduleContinuation) UnmarshalJSON(b []byte) error {
+	var v scheduleContinuationJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.AfterMs < 0 || v.AfterMs > MaxContinuationDelay.Milliseconds() {
+		return fmt.Errorf("continuation delay %dms out of range [0, %s]", v.AfterMs, MaxContinuationDelay)
+	}
+	c.After, c.State = time.Duration(v.AfterMs)*time.Millisecond, v.State
+	return nil
+}
+
+// Continuation est la reprise en cours d'un nœud qui a renvoyé une
+// ScheduleContinuation.
+type Continuation struct {
+	State interface{}
+	// Count est le numéro de la reprise, à partir de 1.
+	Count int
+	// ScheduledAt est l'heure à laquelle la reprise était prévue ; le
+	// moteur peut rappeler le nœud plus tard.
+	ScheduledAt time.Time
+}
+
+// ContinueAfter renvoie un résultat demandant la reprise du nœud après
+// after avec state. value est la sortie provisoire du nœud, remplacée par
+// celle de la dernière reprise.
+func ContinueAfter(after time.Duration, state, value interface{}) *Result {
+	return &Result{Value: value, Continuation: &ScheduleContinuation{After: after, State: state}}
+}
+
+// Validate vérifie le délai de la reprise.
+func (c ScheduleContinuation) Validate() error {
+	if c.After < 0 || c.After > MaxContinuationDelay {
+		return fmt.Errorf("continuation delay %s out of range [0, %s]", c.After, MaxContinuationDelay)
+	}
+	return nil
+}
+
+// Resume renvoie ctx tel que le moteur doit le transmettre à la reprise
+// prévue à scheduledAt : State dans ctx.Continuation et le compte de
+// reprises incrémenté.
+func (c ScheduleContinuation) Resume(ctx ExecutionContext, scheduledAt time.Time) ExecutionContext {
+	count := 1
+	if ctx.Continuation != nil {
+		count = ctx.Continuation.Count + 1
+	}
+	ctx.Continuation = &Continuation{State: c.State, Count: count, ScheduledAt: scheduledAt}
+	return ctx
+}
+
+// ExecuteContinuations exécute node puis ses reprises successives jusqu'à
+// un résultat sans Continuation, qu'elle renvoie, au plus MaxContinuations
+// reprises. wait attend le délai de chaque reprise ; nil : time.Sleep. Elle
+// sert aux moteurs en processus et aux tests ; le moteur planifie plutôt la
+// reprise et libère le nœud.
+func ExecuteContinuations(exec NodeExecutor, node Node, ctx ExecutionContext, wait func(time.Duration) error) (*Result, error) {
+	for resumes := 0; ; resumes++ {
+		res, err := ExecuteResult(exec, node, ctx)
+		if err != nil {
+			return nil, err
+		}
+		c := res.Continuation
+		if c == nil {
+			return res, nil
+		}
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.ID, err)
+		}
+		if resumes >= MaxContinuations {
+			return nil, fmt.Errorf("node %q: more than %d continuations", node.ID, MaxContinuations)
+		}
+		scheduledAt := time.Now().Add(c.After)
+		if wait == nil {
+			time.Sleep(c.After)
+		} else if err := wait(c.After); err != nil {
+			return nil, err
+		}
+		ctx = c.Resume(ctx, scheduledAt)
+	}
+}
+
+// checkContinuation refuse la ScheduleContinuation de res si la capacité de
+// node n'est pas marquée Continuable dans le manifeste du plugin, ou si le
+// manifeste ne peut pas être lu.
+func (m *NodeExecutorGRPC) checkContinuation(node Node, res *Result) error {
+	if res.Continuation == nil {
+		return nil
+	}
+	manifest, err := m.manifestOrErr()
+	if err != nil {
+		return fmt.Errorf("cannot accept continuation of node %q: %w", node.ID, err)
+	}
+	if spec := manifest.Capability(node.Uses); spec == nil || !spec.Continuable {
+		return fmt.Errorf("node %q: capability %s is not continuable but returned a continuation", node.ID, node.Uses)
+	}
+	return nil
+}
+
+func toProtoScheduleContinuation(c Codec, sc *ScheduleContinuation) (*proto.ScheduleContinuation, error) {
+	if sc == nil {
+		return nil, nil
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	state, err := c.Marshal(sc.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode continuation state: %w", err)
+	}
+	return &proto.ScheduleContinuation{AfterMs: sc.After.Milliseconds(), State: state}, nil
+}
+
+func fromProtoScheduleContinuation(c Codec, p *proto.ScheduleContinuation) (*ScheduleContinuation, error) {
+	if p == nil {
+		return nil, nil
+	}
+	sc := &ScheduleContinuation{After: time.Duration(p.AfterMs) * time.Millisecond}
+	if len(p.State) > 0 {
+		if err := c.Unmarshal(p.State, &sc.State); err != nil {
+			return nil, fmt.Errorf("invalid continuation state: %w", err)
+		}
+	}
+	return sc, sc.Validate()
+}
+
+func toProtoContinuation(c Codec, cont *Continuation) (*proto.Continuation, error) {
+	if cont == nil {
+		return nil, nil
+	}
+	state, err := c.Marshal(cont.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode continuation state: %w", err)
+	}
+	return &proto.Continuation{State: state, Count: int32(cont.Count), ScheduledUnixMs: unixMillis(cont.ScheduledAt)}, nil
+}
+
+func fromProtoContinuation(c Codec, p *proto.Continuation) (*Continuation, error) {
+	if p == nil {
+		return nil, nil
+	}
+	cont := &Continuation{Count: int(p.Count), ScheduledAt: fromUnixMillis(p.ScheduledUnixMs)}
+	if len(p.State) > 0 {
+		if err := c.Unmarshal(p.State, &cont.State); err != nil {
+			return nil, fmt.Errorf("invalid continuation state: %w", err)
+		}
+	}
+	return cont, nil
+}
@@ -0,0 +1,51 @@
+// Package contracttest rejoue les exemples déclarés dans le manifeste d'un
+// plugin contre une instance en cours d'exécution, transformant la
+// documentation en tests de contrat :
+//
+//	func TestContract(t *testing.T) {
+//		contracttest.Run(t, &myplugin.Executor{})
+//	}
+package contracttest
+
+import (
+	"testing"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+)
+
+// Executor est un NodeExecutor qui expose son manifeste, comme le client
+// gRPC ou une implémentation de plugin qui implémente InfoProvider.
+type Executor interface {
+	shared.NodeExecutor
+	shared.InfoProvider
+}
+
+// Run exécute chaque exemple du manifeste de exec dans un sous-test nommé
+// « <uses>/<exemple> » et vérifie que la sortie a la forme attendue. Les
+// capacités sans exemple sont ignorées.
+func Run(t *testing.T, exec Executor) {
+	t.Helper()
+	info, err := exec.GetInfo()
+	if err != nil {
+		t.Fatalf("GetInfo: %v", err)
+	}
+	for _, c := range info.Manifest.Capabilities {
+		for _, ex := range c.Examples {
+			t.Run(c.Uses+"/"+ex.Name, func(t *testing.T) {
+				RunExample(t, exec, c.Uses, ex)
+			})
+		}
+	}
+}
+
+// RunExample exécute un exemple et vérifie la forme de sa sortie.
+func RunExample(t *testing.T, exec shared.NodeExecutor, uses string, ex shared.Example) {
+	t.Helper()
+	res, err := shared.ExecuteResult(exec, ex.Node(uses), ex.Context())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if err := shared.MatchShape(ex.Output, res.Value); err != nil {
+		t.Errorf("output does not match example: %v", err)
+	}
+}
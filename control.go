@@ -0,0 +1,259 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultNoticeBuffer est le nombre d'avis conservés par le plugin en
+// attendant que le moteur les lise.
+const DefaultNoticeBuffer = 64
+
+// ErrNoticeDropped est renvoyée par Notifier.Notify lorsque la file des avis
+// est pleine, le moteur ne les lisant pas assez vite (ou pas du tout).
+var ErrNoticeDropped = errors.New("notice queue is full")
+
+// ControlMessage est poussé par le moteur au plugin sur le flux de contrôle.
+// Les champs vides sont ignorés.
+type ControlMessage struct {
+	// Configuration est appliquée comme par Configure.
+	Configuration *Configuration
+	// Invalidate liste les clés de cache que le plugin doit oublier
+	// (schéma d'une API distante, jeton révoqué...). Leur signification est
+	// propre au plugin.
+	Invalidate []string
+}
+
+// ControlHandler peut être implémentée par un NodeExecutor pour recevoir les
+// messages du flux de contrôle, après application de leur configuration.
+type ControlHandler interface {
+	HandleControl(msg ControlMessage)
+}
+
+// NoticeKind identifie un avis émis spontanément par un plugin.
+type NoticeKind string
+
+const (
+	NoticeQuotaWarning     NoticeKind = "quota-warning"
+	NoticeCredentialExpiry NoticeKind = "credential-expiry"
+	NoticeDegraded         NoticeKind = "degraded"
+)
+
+// NoticeSeverity est la gravité d'un avis.
+type NoticeSeverity string
+
+const (
+	NoticeInfo    NoticeSeverity = "info"
+	NoticeWarning NoticeSeverity = "warning"
+	NoticeError   NoticeSeverity = "error"
+)
+
+// Notice est un avis envoyé par le plugin au moteur sans sollicitation.
+type Notice struct {
+	Kind     NoticeKind
+	Severity NoticeSeverity
+	Message  string
+	// Attributes précise l'avis (quota restant, secret concerné...).
+	Attributes map[string]string
+	// Time est renseigné par Notify s'il est nul.
+	Time time.Time
+}
+
+// Notifier transmet des avis au moteur.
+type Notifier interface {
+	// Notify met l'avis en file sans bloquer. Les avis émis avant que le
+	// moteur n'ouvre le flux de contrôle lui sont remis à l'ouverture.
+	Notify(n Notice) error
+}
+
+// NotifierReceiver peut être implémentée par un NodeExecutor pour recevoir,
+// au démarrage, le Notifier par lequel émettre ses avis.
+type NotifierReceiver interface {
+	SetNotifier(n Notifier)
+}
+
+// noticeQueue est le Notifier du serveur.
+type noticeQueue chan Notice
+
+func (q noticeQueue) Notify(n Notice) error {
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	select {
+	case q <- n:
+		return nil
+	default:
+		return ErrNoticeDropped
+	}
+}
+
+// ControlStream est le flux de contrôle bidirectionnel ouvert par le moteur
+// au démarrage d'un plugin.
+type ControlStream struct {
+	stream proto.NodeExecutor_ControlClient
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	done   chan struct{}
+	err    error
+}
+
+// OpenControl ouvre le flux de contrôle. onNotice est appelée, depuis une
+// goroutine dédiée, pour chaque avis du plugin ; les avis NoticeBackpressure
+// sont en outre pris en compte par BackpressureDelay. Les plugins qui ne le
+// supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) OpenControl(onNotice func(Notice)) (*ControlStream, error) {
+	ctx, cancel := m.callContext(0, "")
+	stream, err := m.client.Control(ctx)
+	if err == nil {
+		// Le serveur envoie ses en-têtes dès l'ouverture. Un flux terminé
+		// sans en-têtes signale un plugin qui ne connaît pas la RPC, dont
+		// Recv renvoie alors le statut.
+		var md metadata.MD
+		if md, err = stream.Header(); err == nil && md == nil {
+			_, err = stream.Recv()
+		}
+	}
+	if err != nil {
+		cancel()
+		return nil, fromRPCError("Control", err)
+	}
+	c := &ControlStream{stream: stream, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		for {
+			n, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					c.err = fromRPCError("Control", err)
+				}
+				return
+			}
+			notice := fromProtoNotice(n)
+			m.observeNotice(notice)
+			onNotice(notice)
+		}
+	}()
+	return c, nil
+}
+
+// Send pousse msg au plugin.
+func (c *ControlStream) Send(msg ControlMessage) error {
+	req := &proto.ControlMessage{Invalidate: msg.Invalidate}
+	if msg.Configuration != nil {
+		var err error
+		if req.Configure, err = toProtoConfigureRequest(*msg.Configuration); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stream.Send(req)
+}
+
+// Done est fermé lorsque le flux est terminé ; Err renvoie alors sa cause.
+func (c *ControlStream) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err renvoie l'erreur qui a interrompu le flux, nil s'il a été fermé
+// normalement ou est encore ouvert.
+func (c *ControlStream) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Close ferme le flux et attend la fin de la réception des avis.
+func (c *ControlStream) Close() error {
+	c.mu.Lock()
+	c.stream.CloseSend()
+	c.mu.Unlock()
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// Control sert le flux de contrôle. Les avis en file sont envoyés au moteur
+// tant que le flux est ouvert ; le moteur n'en ouvre qu'un à la fois.
+func (s *NodeExecutorGRPCServer) Control(stream proto.NodeExecutor_ControlServer) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				errc <- nil
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+			if err := s.handleControl(msg); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case err := <-errc:
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case n := <-s.notices:
+			if err := stream.Send(toProtoNotice(n)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *NodeExecutorGRPCServer) handleControl(msg *proto.ControlMessage) error {
+	out := ControlMessage{Invalidate: msg.Invalidate}
+	if msg.Configure != nil {
+		cfg, err := s.configure(msg.Configure)
+		if err != nil {
+			return err
+		}
+		out.Configuration = &cfg
+	}
+	if h, ok := implementation[ControlHandler](s.Impl); ok {
+		h.HandleControl(out)
+	}
+	return nil
+}
+
+func toProtoNotice(n Notice) *proto.PluginNotice {
+	return &proto.PluginNotice{
+		Kind:       string(n.Kind),
+		Severity:   string(n.Severity),
+		Message:    n.Message,
+		Attributes: n.Attributes,
+		TimeUnixMs: unixMillis(n.Time),
+	}
+}
+
+func fromProtoNotice(p *proto.PluginNotice) Notice {
+	return Notice{
+		Kind:       NoticeKind(p.Kind),
+		Severity:   NoticeSeverity(p.Severity),
+		Message:    p.Message,
+		Attributes: p.Attributes,
+		Time:       fromUnixMillis(p.TimeUnixMs),
+	}
+}
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// CostEstimate est le coût attendu de l'exécution d'un nœud, en crédits
+// d'API et, s'il est connu, en montant.
+type CostEstimate struct {
+	Credits float64
+	// Cost est le montant attendu ; sa devise est vide s'il est inconnu.
+	Cost Money
+}
+
+// Add cumule deux estimations, pour évaluer un workflow entier. Les montants
+// doivent être dans la même devise.
+func (e CostEstimate) Add(o CostEstimate) (CostEstimate, error) {
+	sum := CostEstimate{Credits: e.Credits + o.Credits, Cost: e.Cost}
+	switch {
+	case o.Cost.Currency == "":
+	case e.Cost.Currency == "":
+		sum.Cost = o.Cost
+	default:
+		cost, err := e.Cost.Add(o.Cost)
+		if err != nil {
+			return CostEstimate{}, err
+		}
+		sum.Cost = cost
+	}
+	return sum, nil
+}
+
+// CostEstimator peut être implémentée par un NodeExecutor pour annoncer le
+// coût d'un nœud selon sa configuration, sans l'exécuter. Le moteur affiche
+// ces estimations et les confronte aux budgets avant de lancer un workflow.
+type CostEstimator interface {
+	EstimateCost(node Node) (CostEstimate, error)
+}
+
+// EstimateCost demande au plugin le coût attendu de node. Les plugins qui ne
+// le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) EstimateCost(node Node) (CostEstimate, error) {
+	pNode, err := toProtoNode(m.codec(), &node, m.opts.MaxNodeDepth)
+	if err != nil {
+		return CostEstimate{}, fmt.Errorf("failed to convert node for gRPC: %w", err)
+	}
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.EstimateCost(callCtx, &proto.EstimateCostRequest{Node: pNode})
+	if err != nil {
+		return CostEstimate{}, withTimeout(fromRPCError("EstimateCost", err), m.opts.callTimeout())
+	}
+	return fromProtoCostEstimate(resp)
+}
+
+func (s *NodeExecutorGRPCServer) EstimateCost(ctx context.Context, req *proto.EstimateCostRequest) (*proto.CostEstimate, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	e, ok := implementation[CostEstimator](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.EstimateCost(ctx, req)
+	}
+	node, err := fromProtoNode(codecOrDefault(s.options.Codec), req.Node, s.options.MaxNodeDepth)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := e.EstimateCost(node)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	return toProtoCostEstimate(estimate), nil
+}
+
+func toProtoCostEstimate(e CostEstimate) *proto.CostEstimate {
+	p := &proto.CostEstimate{Credits: e.Credits, Currency: e.Cost.Currency}
+	if e.Cost.Currency != "" {
+		p.Amount = e.Cost.Amount.String()
+	}
+	return p
+}
+
+func fromProtoCostEstimate(p *proto.CostEstimate) (CostEstimate, error) {
+	e := CostEstimate{Credits: p.Credits}
+	if p.Currency == "" {
+		return e, nil
+	}
+	amount, err := ParseDecimal(p.Amount)
+	if err != nil {
+		return CostEstimate{}, fmt.Errorf("invalid cost estimate: %w", err)
+	}
+	e.Cost = Money{Amount: amount, Currency: p.Currency}
+	return e, nil
+}
@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// DefaultDebugHistory est le nombre d'exécutions conservées par le service de
+// débogage lorsque ServerOptions.DebugHistory n'est pas renseigné.
+const DefaultDebugHistory = 20
+
+// maxDebugNodeBytes borne la taille d'un nœud conservé par le service de
+// débogage.
+const maxDebugNodeBytes = 64 << 10
+
+// requestRecorder conserve les dernières exécutions dans un tampon
+// circulaire. Le contexte d'exécution n'est pas conservé, seulement le nœud.
+type requestRecorder struct {
+	mu      sync.Mutex
+	entries []*proto.DebugRequest
+	next    int
+	full    bool
+}
+
+func newRequestRecorder(n int) *requestRecorder {
+	if n <= 0 {
+		n = DefaultDebugHistory
+	}
+	return &requestRecorder{entries: make([]*proto.DebugRequest, n)}
+}
+
+// record ajoute une exécution, en masquant les valeurs de secrets. Il est
+// sans effet sur un enregistreur nil.
+func (r *requestRecorder) record(node Node, secrets map[string]string, start time.Time, err error) {
+	if r == nil {
+		return
+	}
+	entry := &proto.DebugRequest{
+		NodeId:        node.ID,
+		Uses:          node.Uses,
+		StartedUnixMs: start.UnixMilli(),
+		DurationMs:    time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = string(redactSecrets([]byte(err.Error()), secrets))
+	}
+	if b, _, encErr := encodeValue(JSONCodec{}, map[string]interface{}{"id": node.ID, "uses": node.Uses, "with": node.With}); encErr == nil {
+		b = redactSecrets(b, secrets)
+		if len(b) > maxDebugNodeBytes {
+			b, entry.Truncated = b[:maxDebugNodeBytes], true
+		}
+		entry.Node = b
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// recent renvoie au plus limit exécutions, de la plus récente à la plus
+// ancienne.
+func (r *requestRecorder) recent(limit int) []*proto.DebugRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	out := make([]*proto.DebugRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return out
+}
+
+// debugServer implémente le service PluginDebug.
+type debugServer struct {
+	proto.UnimplementedPluginDebugServer
+	server  *NodeExecutorGRPCServer
+	options ServerOptions
+}
+
+func (d *debugServer) RecentRequests(ctx context.Context, req *proto.RecentRequestsRequest) (*proto.RecentRequestsResponse, error) {
+	if err := d.server.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return &proto.RecentRequestsResponse{Requests: d.server.recorder.recent(int(req.Limit))}, nil
+}
+
+func (d *debugServer) GetConfig(ctx context.Context, req *proto.Empty) (*proto.DebugConfig, error) {
+	if err := d.server.authorize(ctx); err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{
+		"sharedVersion": Version,
+		"features":      d.server.features(),
+		"options":       d.options,
+	}
+	if p, ok := implementation[InfoProvider](d.server.Impl); ok {
+		if info, err := p.GetInfo(); err == nil {
+			config["plugin"] = info
+		}
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DebugConfig{Config: b}, nil
+}
+
+// maxGoroutineDumpBytes borne la taille d'un dump de goroutines.
+const maxGoroutineDumpBytes = 1 << 20
+
+func (d *debugServer) DumpGoroutines(ctx context.Context, req *proto.Empty) (*proto.GoroutineDump, error) {
+	if err := d.server.authorize(ctx); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		return nil, err
+	}
+	dump := &proto.GoroutineDump{Dump: buf.Bytes()}
+	if len(dump.Dump) > maxGoroutineDumpBytes {
+		dump.Dump, dump.Truncated = dump.Dump[:maxGoroutineDumpBytes], true
+	}
+	return dump, nil
+}
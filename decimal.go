@@ -0,0 +1,262 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal est un nombre décimal exact (valeur entière non mise à l'échelle et
+// nombre de chiffres après la virgule). Il est transporté sous forme de chaîne
+// pour ne pas perdre de précision dans la couche JSON, où tous les nombres
+// deviennent des float64.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal renvoie unscaled × 10^-scale : NewDecimal(1234, 2) vaut 12.34.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// MaxDecimalScale borne, en valeur absolue, la puissance de dix d'un Decimal
+// analysé par ParseDecimal : "1e2000000000" coûterait sinon un calcul de
+// plusieurs gigaoctets, et l'échelle pourrait déborder de son int32.
+const MaxDecimalScale = 1000
+
+// ParseDecimal analyse une chaîne décimale ("-12.340", "1e-3").
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(str[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		str = str[:i]
+	}
+	digits := str
+	scale := int64(0)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		digits = str[:i] + str[i+1:]
+		scale = int64(len(str) - i - 1)
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok || digits == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale -= exp
+	if scale > MaxDecimalScale || scale < -MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q exceeds scale limit %d", s, MaxDecimalScale)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(-scale), nil))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal est comme ParseDecimal mais panique en cas d'erreur.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromValue convertit une valeur décodée (chaîne, nombre ou Decimal).
+// Les nombres flottants sont convertis par leur représentation la plus courte.
+func DecimalFromValue(v interface{}) (Decimal, error) {
+	switch d := v.(type) {
+	case Decimal:
+		return d, nil
+	case string:
+		return ParseDecimal(d)
+	case json.Number:
+		return ParseDecimal(d.String())
+	case float64:
+		return ParseDecimal(fmt.Sprint(d))
+	case int:
+		return NewDecimal(int64(d), 0), nil
+	case int64:
+		return NewDecimal(d, 0), nil
+	}
+	return Decimal{}, fmt.Errorf("cannot convert %T to decimal", v)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Scale renvoie le nombre de chiffres après la virgule.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// rescale renvoie la valeur non mise à l'échelle de d exprimée avec scale
+// chiffres après la virgule (scale >= d.scale).
+func (d Decimal) rescale(scale int32) *big.Int {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+	return factor.Mul(factor, d.int())
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add renvoie d + o.
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{unscaled: x.Add(x, y), scale: scale}
+}
+
+// Sub renvoie d - o.
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{unscaled: x.Sub(x, y), scale: scale}
+}
+
+// Mul renvoie d × o, avec la somme des deux échelles.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Neg renvoie -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Cmp renvoie -1, 0 ou 1 selon que d est inférieur, égal ou supérieur à o.
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+// IsZero indique si d vaut zéro.
+func (d Decimal) IsZero() bool {
+	return d.int().Sign() == 0
+}
+
+// Round arrondit d à scale chiffres après la virgule, au plus proche (les
+// demis s'éloignant de zéro).
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{unscaled: d.rescale(scale), scale: scale}
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil)
+	q, r := new(big.Int).QuoRem(d.int(), factor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(factor) >= 0 {
+		q.Add(q, big.NewInt(int64(d.int().Sign())))
+	}
+	return Decimal{unscaled: q, scale: scale}
+}
+
+// Float64 renvoie l'approximation flottante de d.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.int(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)).Float64()
+	return f
+}
+
+// String renvoie la forme décimale exacte, zéros de fin compris ("12.340").
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(s) <= int(d.scale) {
+			s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.int().Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encode d sous forme de chaîne JSON.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepte une chaîne ou un nombre JSON ; le texte du nombre est
+// analysé tel quel, sans passer par float64.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Money est un montant dans une devise ISO 4217.
+type Money struct {
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// Add additionne deux montants de même devise.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("cannot add %s and %s amounts", m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// MoneyFromMinor construit un montant à partir d'unités mineures, comme les
+// renvoient la plupart des API de paiement : MoneyFromMinor(1234, "EUR", 2)
+// vaut 12.34 EUR.
+func MoneyFromMinor(minor int64, currency string, exponent int32) Money {
+	return Money{Amount: NewDecimal(minor, exponent), Currency: currency}
+}
+
+// Minor renvoie le montant en unités mineures. Une erreur est renvoyée si le
+// montant a plus de décimales que exponent ou ne tient pas dans un int64.
+func (m Money) Minor(exponent int32) (int64, error) {
+	if m.Amount.Round(exponent).Cmp(m.Amount) != 0 {
+		return 0, fmt.Errorf("amount %s %s has more than %d decimal places", m.Amount, m.Currency, exponent)
+	}
+	v := m.Amount.Round(exponent).int()
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("amount %s %s overflows int64", m.Amount, m.Currency)
+	}
+	return v.Int64(), nil
+}
+
+// String renvoie "12.34 EUR".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// MoneyFromValue convertit une valeur décodée ({"amount", "currency"}) en
+// Money.
+func MoneyFromValue(v interface{}) (Money, error) {
+	switch m := v.(type) {
+	case Money:
+		return m, nil
+	case map[string]interface{}:
+		amount, err := DecimalFromValue(m["amount"])
+		if err != nil {
+			return Money{}, fmt.Errorf("invalid money amount: %w", err)
+		}
+		currency, _ := m["currency"].(string)
+		return Money{Amount: amount, Currency: currency}, nil
+	}
+	return Money{}, fmt.Errorf("cannot convert %T to money", v)
+}
@@ -0,0 +1,194 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/hash"
+)
+
+// DefaultDedupeWindow est la durée pendant laquelle un événement déjà vu
+// est écarté, lorsque Deduplicator.Window n'est pas renseigné.
+const DefaultDedupeWindow = 24 * time.Hour
+
+// HeaderDuplicate est ajouté à la réponse d'un webhook écarté comme doublon
+// par Deduplicator.WebhookHandler.
+const HeaderDuplicate = "X-Orkestra-Duplicate"
+
+// webhookDeliveryHeaders sont les en-têtes où les sources courantes placent
+// l'identifiant d'une livraison, repris dans TriggerSource.EventID par
+// NewWebhookPayload.
+var webhookDeliveryHeaders = []string{
+	"X-Orkestra-Delivery",
+	"Idempotency-Key",
+	"X-GitHub-Delivery",
+	"X-Gitlab-Event-UUID",
+	"X-Shopify-Webhook-Id",
+}
+
+// DedupeStore retient des clés pour une durée limitée. Le moteur en fournit
+// une implémentation partagée entre ses instances ; MemoryDedupeStore suffit
+// à un processus seul.
+type DedupeStore interface {
+	// Add retient key pendant ttl et indique si elle était absente. Elle
+	// doit être atomique : de deux appels simultanés avec la même clé, un
+	// seul renvoie true.
+	Add(key string, ttl time.Duration) (bool, error)
+	// Remove oublie key, pour qu'un événement dont le traitement a échoué
+	// soit accepté à sa prochaine livraison.
+	Remove(key string) error
+}
+
+// Deduplicator écarte les livraisons répétées d'un même événement de
+// déclencheur (webhook renvoyé par la source, message relu d'une file), pour
+// qu'elles ne lancent pas plusieurs exécutions. Il sert au moteur (voir
+// PollTrigger.Dedupe et WebhookHandler) comme aux plugins de déclencheurs,
+// qui partagent ainsi la même clé (DedupeKey).
+type Deduplicator struct {
+	Store DedupeStore
+	// Window est la durée pendant laquelle un événement déjà vu est écarté.
+	// Zéro : DefaultDedupeWindow.
+	Window time.Duration
+}
+
+// NewDeduplicator renvoie un Deduplicator sur store.
+func NewDeduplicator(store DedupeStore, window time.Duration) (*Deduplicator, error) {
+	if store == nil {
+		return nil, errors.New("dedupe store is required")
+	}
+	if window < 0 {
+		return nil, errors.New("negative dedupe window")
+	}
+	return &Deduplicator{Store: store, Window: window}, nil
+}
+
+// First indique si p est la première livraison de son événement dans la
+// fenêtre, et la retient. Un événement sans identité (voir DedupeKey) est
+// toujours nouveau. En cas d'erreur du magasin, l'événement est aussi
+// considéré comme nouveau : mieux vaut une exécution en double qu'un
+// événement perdu.
+func (d *Deduplicator) First(p TriggerPayload) (bool, error) {
+	if d.Store == nil {
+		return true, errors.New("dedupe store is required")
+	}
+	key, err := DedupeKey(p)
+	if err != nil || key == "" {
+		return true, err
+	}
+	window := d.Window
+	if window <= 0 {
+		window = DefaultDedupeWindow
+	}
+	first, err := d.Store.Add(key, window)
+	if err != nil {
+		return true, err
+	}
+	return first, nil
+}
+
+// Forget oublie p, retenu par First, lorsque son traitement a échoué.
+func (d *Deduplicator) Forget(p TriggerPayload) error {
+	key, err := DedupeKey(p)
+	if err != nil || key == "" || d.Store == nil {
+		return err
+	}
+	return d.Store.Remove(key)
+}
+
+// DedupeKey renvoie la clé de déduplication de p : l'empreinte du type et du
+// nom du déclencheur et de Source.EventID, ou à défaut du contenu de
+// l'événement (corps, en-têtes exclus, car ils varient d'une livraison à
+// l'autre). Un rejeu (Source.ReplayOf) a sa propre clé, distincte de celle
+// de l'événement d'origine. Elle est vide lorsque rien n'identifie
+// l'événement (cron, déclenchement manuel) : il n'est alors pas dédupliqué.
+func DedupeKey(p TriggerPayload) (string, error) {
+	if p.Source.EventID == "" && p.Body == nil && len(p.RawBody) == 0 {
+		return "", nil
+	}
+	h := hash.New()
+	h.Write([]byte(p.Source.Kind))
+	h.Write([]byte{0})
+	h.Write([]byte(p.Source.Name))
+	h.Write([]byte{0})
+	if p.Source.ReplayOf != "" {
+		h.Write([]byte("replay:" + p.Source.ReplayOf))
+		h.Write([]byte{0})
+	}
+	switch {
+	case p.Source.EventID != "":
+		h.Write([]byte("id:" + p.Source.EventID))
+	case p.Body != nil:
+		h.Write([]byte("body:"))
+		if err := h.WriteJSON(p.Body); err != nil {
+			return "", err
+		}
+	default:
+		h.Write([]byte("raw:"))
+		h.Write(p.RawBody)
+	}
+	return h.Sum(), nil
+}
+
+// WebhookHandler reçoit les webhooks du déclencheur name (voir
+// NewWebhookPayload) et transmet à next ceux qui ne sont pas des doublons.
+// Un doublon reçoit une réponse 200 portant HeaderDuplicate, pour que la
+// source cesse de le relivrer. Si next échoue, l'événement est oublié et la
+// requête reçoit une réponse 500 : la source le relivrera.
+func (d *Deduplicator) WebhookHandler(name string, maxBody int64, next func(w http.ResponseWriter, r *http.Request, p TriggerPayload) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := NewWebhookPayload(name, r, maxBody)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if first, _ := d.First(p); !first {
+			w.Header().Set(HeaderDuplicate, "true")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if err := next(w, r, p); err != nil {
+			d.Forget(p)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// MemoryDedupeStore est un DedupeStore en mémoire. Son zéro est prêt à
+// l'emploi.
+type MemoryDedupeStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	// adds compte les ajouts depuis la dernière purge.
+	adds int
+}
+
+func (s *MemoryDedupeStore) Add(key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.expires == nil {
+		s.expires = map[string]time.Time{}
+	}
+	if exp, ok := s.expires[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	s.expires[key] = now.Add(ttl)
+	if s.adds++; s.adds >= len(s.expires)/2+64 {
+		s.adds = 0
+		for k, exp := range s.expires {
+			if !now.Before(exp) {
+				delete(s.expires, k)
+			}
+		}
+	}
+	return true, nil
+}
+
+func (s *MemoryDedupeStore) Remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.expires, key)
+	return nil
+}
@@ -0,0 +1,193 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Dans une boucle Do, NodeOutputs change peu d'une itération à l'autre. Avec
+// ClientOptions.OutputsDelta, le client ne transmet que les sorties
+// modifiées depuis l'appel précédent de la même exécution (la session) ; le
+// plugin les applique à la base qu'il a conservée. Un plugin qui n'a plus la
+// base (redémarrage, éviction, appels concurrents) refuse la requête et le
+// client la renvoie en entier. Chaque ouverture de session tire une époque
+// au hasard : une base n'est acceptée que si l'époque et la séquence
+// correspondent, si bien qu'un appel en retard, émis avant une
+// réinitialisation, ne peut pas s'appliquer à une base plus récente.
+
+// MaxDeltaSessions est le nombre de sessions conservées de chaque côté ; la
+// moins récemment utilisée est oubliée au-delà.
+const MaxDeltaSessions = 64
+
+// errDeltaBaseline est renvoyée par le plugin lorsque la base d'un delta lui
+// est inconnue.
+var errDeltaBaseline = errors.New("outputs delta baseline unknown")
+
+// deltaSessions est l'état du client : l'empreinte de chaque sortie
+// transmise, par session.
+type deltaSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*deltaSession
+}
+
+type deltaSession struct {
+	epoch uint64
+	seq   uint64
+	sums  map[string][sha256.Size]byte
+	used  time.Time
+}
+
+// diff renvoie les sorties à transmettre pour session et le delta qui les
+// décrit, puis enregistre outputs comme nouvelle base.
+func (d *deltaSessions) diff(c Codec, session string, outputs map[string]interface{}) (map[string]interface{}, *proto.OutputsDelta, error) {
+	sums := make(map[string][sha256.Size]byte, len(outputs))
+	for id, v := range outputs {
+		data, hints, err := encodeValue(c, v)
+		if err != nil {
+			return nil, nil, err
+		}
+		h := sha256.New()
+		h.Write(data)
+		h.Write([]byte{0})
+		h.Write(hints)
+		var sum [sha256.Size]byte
+		h.Sum(sum[:0])
+		sums[id] = sum
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.sessions == nil {
+		d.sessions = map[string]*deltaSession{}
+	}
+	s := d.sessions[session]
+	if s == nil {
+		s = &deltaSession{epoch: rand.Uint64(), used: time.Now()}
+		d.sessions[session] = s
+		evictOldest(d.sessions, func(s *deltaSession) time.Time { return s.used })
+	}
+	delta := &proto.OutputsDelta{Session: session, Epoch: s.epoch, Baseline: s.seq, Sequence: s.seq + 1}
+	changed := outputs
+	if s.sums != nil {
+		changed = map[string]interface{}{}
+		for id, sum := range sums {
+			if old, ok := s.sums[id]; !ok || old != sum {
+				changed[id] = outputs[id]
+			}
+		}
+		for id := range s.sums {
+			if _, ok := sums[id]; !ok {
+				delta.Removed = append(delta.Removed, id)
+			}
+		}
+	}
+	s.seq, s.sums, s.used = delta.Sequence, sums, time.Now()
+	return changed, delta, nil
+}
+
+// reset oublie la session de delta, si elle n'a pas été rouverte depuis : le
+// prochain appel transmet NodeOutputs en entier sous une nouvelle époque.
+func (d *deltaSessions) reset(delta *proto.OutputsDelta) {
+	if delta == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s := d.sessions[delta.Session]; s != nil && s.epoch == delta.Epoch {
+		delete(d.sessions, delta.Session)
+	}
+}
+
+// deltaMissed indique si err signale une base inconnue du plugin, et oublie
+// alors la session de req.
+func (m *NodeExecutorGRPC) deltaMissed(err error, req *proto.ExecuteRequest) bool {
+	if status.Code(err) != codes.FailedPrecondition || status.Convert(err).Message() != errDeltaBaseline.Error() {
+		return false
+	}
+	m.deltas.reset(req.Context.GetOutputsDelta())
+	return true
+}
+
+// useDelta indique si NodeOutputs peut être transmis en delta pour ctx.
+func (m *NodeExecutorGRPC) useDelta(ctx ExecutionContext) bool {
+	if !m.opts.OutputsDelta || ctx.RunID == "" {
+		return false
+	}
+	return m.loadManifest() != nil && m.outputsDelta.Load()
+}
+
+// deltaBaselines est l'état du plugin : les sorties reçues, par session.
+type deltaBaselines struct {
+	mu       sync.Mutex
+	sessions map[string]*deltaBaseline
+}
+
+type deltaBaseline struct {
+	epoch   uint64
+	seq     uint64
+	outputs map[string]interface{}
+	used    time.Time
+}
+
+// apply reconstitue execCtx.NodeOutputs à partir de la base de la session et
+// des changements reçus. Les sorties ne doivent pas être modifiées par le
+// plugin : leurs valeurs sont partagées avec la base.
+func (d *deltaBaselines) apply(delta *proto.OutputsDelta, execCtx *ExecutionContext) error {
+	if delta == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.sessions == nil {
+		d.sessions = map[string]*deltaBaseline{}
+	}
+	var base map[string]interface{}
+	if delta.Baseline > 0 {
+		b := d.sessions[delta.Session]
+		if b == nil || b.epoch != delta.Epoch || b.seq != delta.Baseline {
+			return status.Error(codes.FailedPrecondition, errDeltaBaseline.Error())
+		}
+		base = b.outputs
+	}
+	outputs := make(map[string]interface{}, len(base)+len(execCtx.NodeOutputs))
+	for id, v := range base {
+		outputs[id] = v
+	}
+	for _, id := range delta.Removed {
+		delete(outputs, id)
+	}
+	for id, v := range execCtx.NodeOutputs {
+		outputs[id] = v
+	}
+	d.sessions[delta.Session] = &deltaBaseline{epoch: delta.Epoch, seq: delta.Sequence, outputs: outputs, used: time.Now()}
+	evictOldest(d.sessions, func(b *deltaBaseline) time.Time { return b.used })
+
+	execCtx.NodeOutputs = make(map[string]interface{}, len(outputs))
+	for id, v := range outputs {
+		execCtx.NodeOutputs[id] = v
+	}
+	return nil
+}
+
+// evictOldest retire de sessions l'entrée la moins récemment utilisée
+// lorsqu'elle dépasse MaxDeltaSessions.
+func evictOldest[S any](sessions map[string]S, used func(S) time.Time) {
+	if len(sessions) <= MaxDeltaSessions {
+		return
+	}
+	var oldest string
+	var at time.Time
+	for id, s := range sessions {
+		if t := used(s); oldest == "" || t.Before(at) {
+			oldest, at = id, t
+		}
+	}
+	delete(sessions, oldest)
+}
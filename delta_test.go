@@ -0,0 +1,137 @@
+package shared
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deltaCall prépare un appel : le delta calculé par le client et le contexte
+// que le plugin recevra.
+func deltaCall(t *testing.T, client *deltaSessions, session string, outputs map[string]interface{}) (*proto.OutputsDelta, ExecutionContext) {
+	t.Helper()
+	changed, delta, err := client.diff(codecOrDefault(nil), session, outputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delta, ExecutionContext{NodeOutputs: changed}
+}
+
+func TestDeltaRejectsStaleBaselineAfterReset(t *testing.T) {
+	var client deltaSessions
+	var server deltaBaselines
+	a := map[string]interface{}{"x": 1.0}
+	b := map[string]interface{}{"x": 1.0, "y": 2.0}
+	c := map[string]interface{}{"x": 3.0, "z": 4.0}
+
+	delta, execCtx := deltaCall(t, &client, "run", a)
+	if err := server.apply(delta, &execCtx); err != nil {
+		t.Fatal(err)
+	}
+	// B et C partent en parallèle ; C arrive le premier.
+	deltaB, ctxB := deltaCall(t, &client, "run", b)
+	deltaC, ctxC := deltaCall(t, &client, "run", c)
+	if err := server.apply(deltaC, &ctxC); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("C with an unknown baseline: got %v, want FailedPrecondition", err)
+	}
+	client.reset(deltaC)
+	deltaC, ctxC = deltaCall(t, &client, "run", c)
+	if deltaC.Baseline != 0 {
+		t.Fatalf("resent C has baseline %d, want a full send", deltaC.Baseline)
+	}
+	if err := server.apply(deltaC, &ctxC); err != nil {
+		t.Fatal(err)
+	}
+	// B, émis avant la réinitialisation, ne doit pas s'appliquer sur C.
+	if err := server.apply(deltaB, &ctxB); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stale B: got %v, want FailedPrecondition", err)
+	}
+	// Le rejet de B n'invalide pas la session rouverte par C.
+	client.reset(deltaB)
+	d := map[string]interface{}{"x": 3.0, "z": 5.0}
+	delta, execCtx = deltaCall(t, &client, "run", d)
+	if delta.Baseline == 0 {
+		t.Fatal("session reopened by C was forgotten")
+	}
+	if err := server.apply(delta, &execCtx); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(execCtx.NodeOutputs, d) {
+		t.Fatalf("got %v, want %v", execCtx.NodeOutputs, d)
+	}
+}
+
+func TestDeltaRejectsUnsentSequence(t *testing.T) {
+	var client deltaSessions
+	var server deltaBaselines
+	delta, execCtx := deltaCall(t, &client, "run", map[string]interface{}{"x": 1.0})
+	if err := server.apply(delta, &execCtx); err != nil {
+		t.Fatal(err)
+	}
+	// Un appel abandonné avant l'envoi (journal, limiteur) oublie la session.
+	unsent, _ := deltaCall(t, &client, "run", map[string]interface{}{"x": 2.0})
+	client.reset(unsent)
+	want := map[string]interface{}{"x": 2.0, "y": 3.0}
+	delta, execCtx = deltaCall(t, &client, "run", want)
+	if delta.Baseline != 0 {
+		t.Fatalf("got baseline %d after an unsent call, want a full send", delta.Baseline)
+	}
+	if err := server.apply(delta, &execCtx); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(execCtx.NodeOutputs, want) {
+		t.Fatalf("got %v, want %v", execCtx.NodeOutputs, want)
+	}
+}
+
+func TestDeltaConcurrentOutOfOrder(t *testing.T) {
+	var client deltaSessions
+	var server deltaBaselines
+	const calls = 200
+	var wg sync.WaitGroup
+	for i := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outputs := map[string]interface{}{"shared": 1.0, "iter": float64(i % 7)}
+			if i%3 == 0 {
+				outputs[fmt.Sprintf("n%d", i%5)] = float64(i)
+			}
+			for attempt := 0; ; attempt++ {
+				if attempt == 100 {
+					t.Errorf("call %d: never accepted", i)
+					return
+				}
+				changed, delta, err := client.diff(codecOrDefault(nil), "run", outputs)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				// Livraison dans le désordre.
+				time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+				execCtx := ExecutionContext{NodeOutputs: changed}
+				err = server.apply(delta, &execCtx)
+				if status.Code(err) == codes.FailedPrecondition {
+					client.reset(delta)
+					continue
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if !reflect.DeepEqual(execCtx.NodeOutputs, outputs) {
+					t.Errorf("call %d: plugin saw %v, want %v", i, execCtx.NodeOutputs, outputs)
+				}
+				return
+			}
+		}()
+	}
+	wg.Wait()
+}
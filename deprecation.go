@@ -0,0 +1,79 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeprecationWarning signale qu'un nœud d'un workflow utilise une capacité
+// dépréciée.
+type DeprecationWarning struct {
+	NodeID      string
+	Uses        string
+	Deprecation Deprecation
+	// Expired est vrai lorsque la date de retrait est dépassée.
+	Expired bool
+}
+
+func (w DeprecationWarning) String() string {
+	msg := fmt.Sprintf("node %q uses deprecated capability %q", w.NodeID, w.Uses)
+	if !w.Deprecation.Sunset.IsZero() {
+		verb := "will be removed after"
+		if w.Expired {
+			verb = "may be removed since"
+		}
+		msg += fmt.Sprintf(" (%s %s)", verb, w.Deprecation.Sunset.Format(time.DateOnly))
+	}
+	if w.Deprecation.Replacement != "" {
+		msg += fmt.Sprintf("; use %q instead", w.Deprecation.Replacement)
+	}
+	if w.Deprecation.Message != "" {
+		msg += ": " + w.Deprecation.Message
+	}
+	return msg
+}
+
+// CheckDeprecations parcourt les nœuds (y compris Do, OnFailure et
+// Compensate) et renvoie un avertissement pour chaque utilisation d'une
+// capacité marquée comme dépréciée dans le manifeste.
+func CheckDeprecations(m Manifest, nodes []*Node, now time.Time) []DeprecationWarning {
+	var warnings []DeprecationWarning
+	walkNodes(nodes, func(n *Node) {
+		spec := m.Capability(n.Uses)
+		if spec == nil || spec.Deprecation == nil {
+			return
+		}
+		d := *spec.Deprecation
+		warnings = append(warnings, DeprecationWarning{
+			NodeID:      n.ID,
+			Uses:        n.Uses,
+			Deprecation: d,
+			Expired:     !d.Sunset.IsZero() && now.After(d.Sunset),
+		})
+	})
+	return warnings
+}
+
+// walkNodes appelle fn sur chaque nœud de l'arbre, en profondeur. Le parcours
+// est itératif pour supporter des arbres arbitrairement profonds.
+func walkNodes(nodes []*Node, fn func(*Node)) {
+	stack := make([]*Node, 0, len(nodes))
+	push := func(children []*Node) {
+		for i := len(children) - 1; i >= 0; i-- {
+			if children[i] != nil {
+				stack = append(stack, children[i])
+			}
+		}
+	}
+	push(nodes)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		fn(n)
+		if n.Compensate != nil {
+			stack = append(stack, n.Compensate)
+		}
+		push(n.OnFailure)
+		push(n.Do)
+	}
+}
@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// templateExpr isole le contenu des expressions {{ ... }}.
+	templateExpr = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	// stepRef reconnaît steps.<id> et steps['<id>'], suivis d'un chemin
+	// facultatif (.champ, [0], ['clé']).
+	stepRef = regexp.MustCompile(`\bsteps(?:\.([\w-]+)|\[['"]([^'"\]]+)['"]\])((?:\.[\w-]+|\[[^\]]*\])*)`)
+)
+
+// StepRef est une référence à la sortie d'un nœud dans une expression.
+type StepRef struct {
+	// Step est l'ID du nœud référencé.
+	Step string
+	// Path est le chemin lu dans sa sortie, vide si la sortie entière est
+	// utilisée (« body.items[0] »).
+	Path string
+}
+
+// StepReferences renvoie les références steps.* contenues dans les
+// expressions {{ ... }} des chaînes de v, parcouru récursivement. Le
+// résultat est trié et sans doublon.
+func StepReferences(v interface{}) []StepRef {
+	seen := map[StepRef]bool{}
+	var refs []StepRef
+	scanExpressions(v, func(expr string) {
+		for _, m := range stepRef.FindAllStringSubmatch(expr, -1) {
+			ref := StepRef{Step: m[1], Path: strings.TrimPrefix(m[3], ".")}
+			if ref.Step == "" {
+				ref.Step = m[2]
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Step != refs[j].Step {
+			return refs[i].Step < refs[j].Step
+		}
+		return refs[i].Path < refs[j].Path
+	})
+	return refs
+}
+
+// ImpliedNeeds renvoie les IDs des nœuds dont n lit la sortie dans With,
+// triés et sans doublon. Le moteur s'en sert pour compléter Needs et pour ne
+// transmettre au plugin que les sorties utiles.
+func (n *Node) ImpliedNeeds() []string {
+	var needs []string
+	for _, ref := range StepReferences(n.With) {
+		if ref.Step == n.ID || (len(needs) > 0 && needs[len(needs)-1] == ref.Step) {
+			continue
+		}
+		needs = append(needs, ref.Step)
+	}
+	return needs
+}
+
+// scanExpressions appelle fn sur le contenu de chaque expression {{ ... }}
+// trouvée dans les chaînes de v, parcouru récursivement.
+func scanExpressions(v interface{}, fn func(expr string)) {
+	stack := []interface{}{v}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch x := v.(type) {
+		case string:
+			for _, m := range templateExpr.FindAllStringSubmatch(x, -1) {
+				fn(m[1])
+			}
+		case map[string]interface{}:
+			for _, child := range x {
+				stack = append(stack, child)
+			}
+		case []interface{}:
+			stack = append(stack, x...)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/vcr"
+)
+
+// ExecutionMode indique si une exécution est réelle, enregistrée pour être
+// rejouée, ou rejouée.
+type ExecutionMode string
+
+const (
+	// ModeLive est l'exécution ordinaire.
+	ModeLive ExecutionMode = ""
+	// ModeRecord exécute le nœud de manière déterministe et enregistre ses
+	// échanges HTTP dans ExecutionContext.Cassette, renvoyée au moteur dans
+	// Result.Cassette.
+	ModeRecord ExecutionMode = "record"
+	// ModeReplay réexécute le nœud à l'identique : les échanges HTTP sont
+	// servis par la cassette, sans solliciter les API externes.
+	ModeReplay ExecutionMode = "replay"
+)
+
+// Deterministic indique si l'exécution doit être reproductible. Les
+// plugins lisent alors l'heure avec Now, tirent leurs nombres aléatoires
+// de Rand et émettent leurs requêtes HTTP via HTTPTransport.
+func (c ExecutionContext) Deterministic() bool {
+	return c.Mode != ModeLive
+}
+
+// Now renvoie l'horloge figée par le moteur en mode déterministe, l'heure
+// courante sinon.
+func (c ExecutionContext) Now() time.Time {
+	if c.Deterministic() && !c.Clock.IsZero() {
+		return c.Clock
+	}
+	return time.Now()
+}
+
+// Rand renvoie un générateur initialisé avec Seed en mode déterministe,
+// avec l'heure courante sinon. Chaque appel repart de la graine.
+func (c ExecutionContext) Rand() *rand.Rand {
+	if c.Deterministic() {
+		return rand.New(rand.NewSource(c.Seed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// HTTPTransport enveloppe base (http.DefaultTransport si nil) selon le mode :
+// les échanges sont enregistrés dans Cassette en ModeRecord et servis par
+// elle en ModeReplay.
+func (c ExecutionContext) HTTPTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cassette := c.Cassette
+	if cassette == nil {
+		cassette = &vcr.Cassette{}
+	}
+	switch c.Mode {
+	case ModeRecord:
+		return vcr.NewTransport(cassette, vcr.ModeRecord, base)
+	case ModeReplay:
+		return vcr.NewTransport(cassette, vcr.ModeReplay, nil)
+	}
+	return base
+}
+
+func encodeCassette(c *vcr.Cassette) ([]byte, error) {
+	if c == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	return b, nil
+}
+
+func decodeCassette(b []byte) (*vcr.Cassette, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	c := &vcr.Cassette{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette: %w", err)
+	}
+	return c, nil
+}
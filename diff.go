@@ -0,0 +1,153 @@
+package shared
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeSet décrit les différences entre deux versions d'un arbre de nœuds.
+// Les nœuds sont appariés par ID, à tous les niveaux de l'arbre.
+type ChangeSet struct {
+	Added    []string
+	Removed  []string
+	Modified []NodeChange
+}
+
+// NodeChange décrit les modifications d'un nœud présent dans les deux
+// versions.
+type NodeChange struct {
+	ID string
+	// Fields liste les champs modifiés hors With (« Uses », « Retries »...).
+	Fields []string
+	// WithKeys liste les clés de premier niveau de With ajoutées, retirées
+	// ou modifiées.
+	WithKeys []string
+}
+
+// Empty indique si les deux versions sont identiques.
+func (c ChangeSet) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// String renvoie un résumé lisible, une ligne par nœud.
+func (c ChangeSet) String() string {
+	var b strings.Builder
+	for _, id := range c.Added {
+		fmt.Fprintf(&b, "+ %s\n", id)
+	}
+	for _, id := range c.Removed {
+		fmt.Fprintf(&b, "- %s\n", id)
+	}
+	for _, m := range c.Modified {
+		changes := append([]string(nil), m.Fields...)
+		for _, k := range m.WithKeys {
+			changes = append(changes, "With."+k)
+		}
+		fmt.Fprintf(&b, "~ %s: %s\n", m.ID, strings.Join(changes, ", "))
+	}
+	return b.String()
+}
+
+// DiffNodes compare deux versions d'un arbre de nœuds. Les valeurs sont
+// comparées avec reflect.DeepEqual : les deux versions doivent avoir été
+// décodées de la même manière (un 1 entier diffère d'un 1.0 flottant).
+func DiffNodes(old, new []*Node) ChangeSet {
+	before, after := indexNodes(old), indexNodes(new)
+	var c ChangeSet
+	for id, n := range after {
+		o, ok := before[id]
+		if !ok {
+			c.Added = append(c.Added, id)
+			continue
+		}
+		if m := diffNode(o, n); len(m.Fields) > 0 || len(m.WithKeys) > 0 {
+			c.Modified = append(c.Modified, m)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			c.Removed = append(c.Removed, id)
+		}
+	}
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+	sort.Slice(c.Modified, func(i, j int) bool { return c.Modified[i].ID < c.Modified[j].ID })
+	return c
+}
+
+func indexNodes(nodes []*Node) map[string]*Node {
+	index := map[string]*Node{}
+	walkNodes(nodes, func(n *Node) { index[n.ID] = n })
+	return index
+}
+
+func diffNode(o, n *Node) NodeChange {
+	c := NodeChange{ID: n.ID}
+	fields := []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"Uses", o.Uses, n.Uses},
+		{"Needs", o.Needs, n.Needs},
+		{"Retries", o.Retries, n.Retries},
+		{"Timeout", o.Timeout, n.Timeout},
+		{"Labels", o.Labels, n.Labels},
+		{"Annotations", o.Annotations, n.Annotations},
+		{"Priority", o.Priority, n.Priority},
+		{"Queue", o.Queue, n.Queue},
+		{"Resources", o.Resources, n.Resources},
+		{"Matrix", o.Matrix, n.Matrix},
+		{"Affinity", o.Affinity, n.Affinity},
+		{"Env", o.Env, n.Env},
+		{"Connection", o.Connection, n.Connection},
+		{"Do", childIDs(o.Do), childIDs(n.Do)},
+		{"OnFailure", childIDs(o.OnFailure), childIDs(n.OnFailure)},
+		{"Compensate", childIDs([]*Node{o.Compensate}), childIDs([]*Node{n.Compensate})},
+	}
+	for _, f := range fields {
+		if !equalValues(f.old, f.new) {
+			c.Fields = append(c.Fields, f.name)
+		}
+	}
+	for k, v := range n.With {
+		if ov, ok := o.With[k]; !ok || !reflect.DeepEqual(ov, v) {
+			c.WithKeys = append(c.WithKeys, k)
+		}
+	}
+	for k := range o.With {
+		if _, ok := n.With[k]; !ok {
+			c.WithKeys = append(c.WithKeys, k)
+		}
+	}
+	sort.Strings(c.WithKeys)
+	return c
+}
+
+// equalValues est reflect.DeepEqual, à ceci près qu'une collection vide et
+// une collection nil sont égales.
+func equalValues(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Map, reflect.Slice:
+			if va.Len() == 0 && vb.Len() == 0 {
+				return true
+			}
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// childIDs renvoie les IDs des enfants directs, pour détecter un changement
+// de structure sans comparer les sous-arbres (comparés nœud par nœud).
+func childIDs(nodes []*Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			ids = append(ids, n.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,100 @@
+package shared
+
+import "errors"
+
+// DiscoveryError est l'échec de GetCapabilities, accompagné de ce que
+// l'utilisateur doit corriger. Un plugin la renvoie pour décrire la cause
+// (identifiants refusés, API injoignable) ; elle traverse gRPC avec son code
+// et sa remédiation, et le client la reconstruit (voir DiscoveryErrorOf) pour
+// que le parcours d'installation l'affiche.
+type DiscoveryError struct {
+	Code ErrorCode
+	// Remediation dit à l'utilisateur comment corriger le problème, par
+	// exemple « Regenerate the API token with the read:repo scope ».
+	Remediation string
+	// Err est la cause, facultative.
+	Err error
+	// message est le message reçu du plugin, pour une erreur reconstruite.
+	message string
+}
+
+// NewDiscoveryError renvoie une *DiscoveryError de code code dont la cause
+// est err.
+func NewDiscoveryError(code ErrorCode, remediation string, err error) error {
+	return &DiscoveryError{Code: code, Remediation: remediation, Err: err}
+}
+
+func (e *DiscoveryError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	msg := "capability discovery failed"
+	if e.Code != "" {
+		msg += " (" + string(e.Code) + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap renvoie l'erreur sentinelle du code, puis la cause.
+func (e *DiscoveryError) Unwrap() []error {
+	return (&CodedError{Code: e.Code, Err: e.Err}).Unwrap()
+}
+
+func (e *DiscoveryError) FailureCode() string { return string(e.Code) }
+
+func (e *DiscoveryError) Retryable() bool { return e.Code.Retryable() }
+
+// Clés des détails d'un maillon DiscoveryError.
+const (
+	discoveryCode        = "discovery.code"
+	discoveryRemediation = "discovery.remediation"
+)
+
+func (e *DiscoveryError) ErrorDetails() map[string]string {
+	return map[string]string{
+		discoveryCode:        string(e.Code),
+		discoveryRemediation: e.Remediation,
+	}
+}
+
+// DiscoveryErrorOf renvoie la *DiscoveryError portée par err, qu'elle ait été
+// créée en processus ou reçue d'un plugin.
+func DiscoveryErrorOf(err error) (*DiscoveryError, bool) {
+	var de *DiscoveryError
+	if errors.As(err, &de) {
+		return de, true
+	}
+	var re *RemoteError
+	if !errors.As(err, &re) {
+		return nil, false
+	}
+	for _, l := range re.Chain {
+		code, ok := l.Details[discoveryCode]
+		if !ok {
+			continue
+		}
+		return &DiscoveryError{Code: ErrorCode(code), Remediation: l.Details[discoveryRemediation], Err: re, message: l.Message}, true
+	}
+	return nil, false
+}
+
+// discoveryError classe l'échec de GetCapabilities côté client : la
+// *DiscoveryError du plugin s'il en a renvoyé une, sinon une erreur dont le
+// code est déduit de err.
+func discoveryError(err error) error {
+	if de, ok := DiscoveryErrorOf(err); ok {
+		return de
+	}
+	code := CodeOf(err)
+	var timeout *TimeoutError
+	if errors.As(err, &timeout) {
+		code = CodeUpstreamUnavailable
+	}
+	if code == "" {
+		code = CodeInternal
+	}
+	return &DiscoveryError{Code: code, Err: err}
+}
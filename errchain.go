@@ -0,0 +1,174 @@
+package shared
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Les erreurs renvoyées par un plugin traversent gRPC sous forme de chaîne :
+// chaque maillon (voir errors.Unwrap) est transmis avec son message et, s'il
+// s'agit d'une erreur enregistrée par RegisterError, son code. Le client
+// reconstruit une *RemoteError qui se déballe vers les erreurs enregistrées
+// correspondantes, si bien que errors.Is fonctionne comme en processus.
+
+// MaxErrorChain borne le nombre de maillons transmis.
+const MaxErrorChain = 32
+
+var errorRegistry = struct {
+	sync.RWMutex
+	byCode map[string]error
+}{byCode: map[string]error{}}
+
+// RegisterError associe code à l'erreur sentinelle err, des deux côtés de
+// la frontière gRPC : un plugin qui renvoie une erreur enveloppant err
+// produit chez le moteur une erreur pour laquelle errors.Is(e, err) est
+// vrai. Les codes de la bibliothèque sont préfixés par « orkestra. ».
+func RegisterError(code string, err error) {
+	errorRegistry.Lock()
+	defer errorRegistry.Unlock()
+	errorRegistry.byCode[code] = err
+}
+
+func init() {
+	RegisterError("orkestra.unsupported", errors.ErrUnsupported)
+	RegisterError("orkestra.token-not-found", ErrTokenNotFound)
+	RegisterError("orkestra.artifact-not-found", ErrArtifactNotFound)
+	RegisterError("orkestra.capture-not-found", ErrCaptureNotFound)
+	RegisterError("orkestra.connection-not-found", ErrConnectionNotFound)
+	RegisterError("orkestra.backpressure", ErrBackpressure)
+	RegisterError("orkestra.idempotency-claim-lost", ErrClaimLost)
+}
+
+// registeredCode renvoie le code sous lequel err est enregistrée.
+func registeredCode(err error) string {
+	if !reflect.TypeOf(err).Comparable() {
+		return ""
+	}
+	errorRegistry.RLock()
+	defer errorRegistry.RUnlock()
+	for code, e := range errorRegistry.byCode {
+		if e == err {
+			return code
+		}
+	}
+	return ""
+}
+
+func registeredError(code string) error {
+	errorRegistry.RLock()
+	defer errorRegistry.RUnlock()
+	return errorRegistry.byCode[code]
+}
+
+// ErrorDetailer peut être implémentée par une erreur pour transmettre des
+// informations structurées avec son maillon.
+type ErrorDetailer interface {
+	ErrorDetails() map[string]string
+}
+
+// ErrorLink est un maillon de la chaîne d'une RemoteError.
+type ErrorLink struct {
+	Code    string
+	Message string
+	Details map[string]string
+}
+
+// RemoteError est une erreur renvoyée par un plugin, reconstruite avec sa
+// chaîne.
+type RemoteError struct {
+	// Message est le message complet de l'erreur, tel que côté plugin.
+	Message string
+	Chain   []ErrorLink
+}
+
+func (e *RemoteError) Error() string { return e.Message }
+
+// Unwrap renvoie les erreurs enregistrées présentes dans la chaîne.
+func (e *RemoteError) Unwrap() []error {
+	var errs []error
+	for _, l := range e.Chain {
+		if l.Code == "" {
+			continue
+		}
+		if err := registeredError(l.Code); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Code renvoie le premier code de la chaîne, vide s'il n'y en a pas.
+func (e *RemoteError) Code() string {
+	for _, l := range e.Chain {
+		if l.Code != "" {
+			return l.Code
+		}
+	}
+	return ""
+}
+
+// toRPCError transmet err avec sa chaîne dans les détails du statut. Les
+// erreurs qui portent déjà un statut gRPC sont renvoyées telles quelles.
+func toRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	chain := &proto.ErrorChain{}
+	stack := []error{err}
+	for len(stack) > 0 && len(chain.Links) < MaxErrorChain {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		link := &proto.ErrorLink{Code: registeredCode(e), Message: e.Error()}
+		if d, ok := e.(ErrorDetailer); ok {
+			link.Details = d.ErrorDetails()
+		}
+		chain.Links = append(chain.Links, link)
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				stack = append(stack, next)
+			}
+		case interface{ Unwrap() []error }:
+			next := u.Unwrap()
+			for i := len(next) - 1; i >= 0; i-- {
+				if next[i] != nil {
+					stack = append(stack, next[i])
+				}
+			}
+		}
+	}
+	st, detailErr := status.New(codes.Unknown, err.Error()).WithDetails(chain)
+	if detailErr != nil {
+		return status.Error(codes.Unknown, err.Error())
+	}
+	return st.Err()
+}
+
+// remoteError reconstruit l'erreur transmise par toRPCError, nil si err n'en
+// provient pas.
+func remoteError(err error) *RemoteError {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		chain, ok := d.(*proto.ErrorChain)
+		if !ok {
+			continue
+		}
+		re := &RemoteError{Message: st.Message()}
+		for _, l := range chain.Links {
+			re.Chain = append(re.Chain, ErrorLink{Code: l.Code, Message: l.Message, Details: l.Details})
+		}
+		return re
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrorCode est un code d'erreur du catalogue commun aux intégrations. Les
+// politiques de reprise et l'aiguillage OnFailure s'écrivent contre ces
+// codes plutôt que contre les messages.
+type ErrorCode string
+
+const (
+	CodeAuthFailed          ErrorCode = "auth-failed"
+	CodePermissionDenied    ErrorCode = "permission-denied"
+	CodeNotFound            ErrorCode = "not-found"
+	CodeConflict            ErrorCode = "conflict"
+	CodeInvalidInput        ErrorCode = "invalid-input"
+	CodeRateLimited         ErrorCode = "rate-limited"
+	CodeUpstreamUnavailable ErrorCode = "upstream-unavailable"
+	CodeInternal            ErrorCode = "internal"
+)
+
+// Erreurs sentinelles du catalogue, à tester avec errors.Is. Elles sont
+// reconnues à travers gRPC (voir RegisterError).
+var (
+	ErrAuthFailed          = errors.New("authentication failed")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrInvalidInput        = errors.New("invalid input")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrInternal            = errors.New("internal error")
+)
+
+// errorCatalog décrit chaque code : son erreur sentinelle et si une
+// nouvelle tentative a des chances d'aboutir.
+var errorCatalog = []struct {
+	code      ErrorCode
+	err       error
+	retryable bool
+}{
+	{CodeAuthFailed, ErrAuthFailed, false},
+	{CodePermissionDenied, ErrPermissionDenied, false},
+	{CodeNotFound, ErrNotFound, false},
+	{CodeConflict, ErrConflict, false},
+	{CodeInvalidInput, ErrInvalidInput, false},
+	{CodeRateLimited, ErrRateLimited, true},
+	{CodeUpstreamUnavailable, ErrUpstreamUnavailable, true},
+	{CodeInternal, ErrInternal, false},
+}
+
+func init() {
+	for _, e := range errorCatalog {
+		RegisterError("orkestra."+string(e.code), e.err)
+	}
+}
+
+// Retryable indique si une erreur de ce code mérite une nouvelle tentative.
+func (c ErrorCode) Retryable() bool {
+	for _, e := range errorCatalog {
+		if e.code == c {
+			return e.retryable
+		}
+	}
+	return false
+}
+
+// CodedError est une erreur du catalogue accompagnée d'un message propre à
+// l'intégration. Elle enveloppe l'erreur sentinelle de son code et
+// implémente FailureCoder.
+type CodedError struct {
+	Code    ErrorCode
+	Message string
+	// Err est la cause, facultative.
+	Err error
+}
+
+// NewError renvoie une *CodedError de code code.
+func NewError(code ErrorCode, format string, args ...interface{}) error {
+	return &CodedError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// WrapError renvoie une *CodedError de code code dont la cause est err.
+func WrapError(code ErrorCode, err error, format string, args ...interface{}) error {
+	return &CodedError{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+func (e *CodedError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap renvoie l'erreur sentinelle du code, puis la cause.
+func (e *CodedError) Unwrap() []error {
+	var errs []error
+	for _, c := range errorCatalog {
+		if c.code == e.Code {
+			errs = append(errs, c.err)
+		}
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+func (e *CodedError) FailureCode() string { return string(e.Code) }
+
+func (e *CodedError) Retryable() bool { return e.Code.Retryable() }
+
+// CodeOf classe err dans le catalogue. Elle renvoie une chaîne vide si err
+// n'y correspond à aucun code.
+func CodeOf(err error) ErrorCode {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	var classified interface{ Code() ErrorCode }
+	if errors.As(err, &classified) {
+		return classified.Code()
+	}
+	for _, e := range errorCatalog {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+	return ""
+}
+
+// IsRetryable indique si err mérite une nouvelle tentative : erreur d'un
+// code rejouable, dépassement d'échéance ou plugin surchargé.
+func IsRetryable(err error) bool {
+	var timeout *TimeoutError
+	if errors.As(err, &timeout) || errors.Is(err, ErrBackpressure) {
+		return true
+	}
+	return CodeOf(err).Retryable()
+}
+
+// CodeForHTTPStatus renvoie le code correspondant à une réponse HTTP en
+// erreur d'une API tierce, une chaîne vide pour un statut de succès.
+func CodeForHTTPStatus(status int) ErrorCode {
+	switch {
+	case status < 400:
+		return ""
+	case status == http.StatusUnauthorized:
+		return CodeAuthFailed
+	case status == http.StatusForbidden:
+		return CodePermissionDenied
+	case status == http.StatusNotFound, status == http.StatusGone:
+		return CodeNotFound
+	case status == http.StatusConflict, status == http.StatusPreconditionFailed:
+		return CodeConflict
+	case status == http.StatusTooManyRequests:
+		return CodeRateLimited
+	case status == http.StatusBadGateway, status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return CodeUpstreamUnavailable
+	case status < 500:
+		return CodeInvalidInput
+	}
+	return CodeInternal
+}
@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventVersion est la version de l'enveloppe Event produite par cette
+// bibliothèque. Elle change lorsque la signification d'un champ change ;
+// l'ajout d'un champ ou d'un type d'événement ne la modifie pas.
+const EventVersion = 1
+
+// EventType identifie un événement du cycle de vie d'une exécution.
+type EventType string
+
+const (
+	EventRunStarted   EventType = "run.started"
+	EventRunSuspended EventType = "run.suspended"
+	EventRunResumed   EventType = "run.resumed"
+	EventRunSucceeded EventType = "run.succeeded"
+	EventRunFailed    EventType = "run.failed"
+	EventRunCancelled EventType = "run.cancelled"
+
+	EventNodeStarted   EventType = "node.started"
+	EventNodeRetried   EventType = "node.retried"
+	EventNodeSucceeded EventType = "node.succeeded"
+	EventNodeFailed    EventType = "node.failed"
+	EventNodeSkipped   EventType = "node.skipped"
+)
+
+// Event est l'enveloppe des événements émis par le moteur (webhooks,
+// interface). Data contient un RunRecord sans ses nœuds pour les événements
+// run.*, un NodeRecord pour les événements node.*.
+type Event struct {
+	Version  int             `json:"version"`
+	ID       string          `json:"id"`
+	Type     EventType       `json:"type"`
+	Time     time.Time       `json:"time"`
+	RunID    string          `json:"runId"`
+	Workflow string          `json:"workflow,omitempty"`
+	NodeID   string          `json:"nodeId,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// NewRunEvent crée un événement run.* décrivant run.
+func NewRunEvent(typ EventType, run RunRecord) (Event, error) {
+	run.Nodes = nil
+	e := newEvent(typ, run.RunID)
+	e.Workflow = run.Workflow
+	return e, e.setData(run)
+}
+
+// NewNodeEvent crée un événement node.* décrivant node dans l'exécution
+// runID.
+func NewNodeEvent(typ EventType, runID string, node NodeRecord) (Event, error) {
+	e := newEvent(typ, runID)
+	e.NodeID = node.NodeID
+	return e, e.setData(node)
+}
+
+func newEvent(typ EventType, runID string) Event {
+	return Event{
+		Version: EventVersion,
+		ID:      newEventID(),
+		Type:    typ,
+		Time:    time.Now().UTC(),
+		RunID:   runID,
+	}
+}
+
+func (e *Event) setData(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", e.Type, err)
+	}
+	e.Data = data
+	return nil
+}
+
+// newEventID renvoie un identifiant aléatoire de 128 bits.
+func newEventID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// ParseEvent décode une enveloppe. Les versions plus récentes que
+// EventVersion sont refusées, les types d'événements inconnus acceptés.
+func ParseEvent(data []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return e, fmt.Errorf("invalid event: %w", err)
+	}
+	if e.Version < 1 || e.Version > EventVersion {
+		return e, fmt.Errorf("unsupported event version %d (max %d)", e.Version, EventVersion)
+	}
+	return e, nil
+}
+
+// Run décode les données d'un événement run.*.
+func (e Event) Run() (RunRecord, error) {
+	var r RunRecord
+	if err := json.Unmarshal(e.Data, &r); err != nil {
+		return r, fmt.Errorf("invalid %s event data: %w", e.Type, err)
+	}
+	return r, nil
+}
+
+// Node décode les données d'un événement node.*.
+func (e Event) Node() (NodeRecord, error) {
+	var n NodeRecord
+	if err := json.Unmarshal(e.Data, &n); err != nil {
+		return n, fmt.Errorf("invalid %s event data: %w", e.Type, err)
+	}
+	return n, nil
+}
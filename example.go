@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Example est un couple entrée/sortie documentant une capacité. Les
+// exemples servent à la documentation du catalogue et de tests de contrat
+// (voir le paquet contracttest).
+type Example struct {
+	Name string                 `json:"name"`
+	With map[string]interface{} `json:"with,omitempty"`
+	// TriggerData et NodeOutputs alimentent le contexte d'exécution.
+	TriggerData map[string]interface{} `json:"triggerData,omitempty"`
+	NodeOutputs map[string]interface{} `json:"nodeOutputs,omitempty"`
+	// Output est une sortie représentative. Seule sa forme est vérifiée
+	// (voir MatchShape) : les valeurs peuvent varier d'un appel à l'autre.
+	Output interface{} `json:"output,omitempty"`
+}
+
+// Node construit le nœud exécutant l'exemple pour la capacité uses.
+func (e Example) Node(uses string) Node {
+	return Node{ID: "example", Uses: uses, With: e.With}
+}
+
+// Context construit le contexte d'exécution de l'exemple.
+func (e Example) Context() ExecutionContext {
+	return ExecutionContext{TriggerData: e.TriggerData, NodeOutputs: e.NodeOutputs}
+}
+
+// ShapeError signale l'écart entre une valeur et la forme attendue.
+type ShapeError struct {
+	// Path est le chemin JSON de l'écart (« $.items[0].id »).
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *ShapeError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// MatchShape vérifie que actual a la forme de expected : même type JSON à
+// chaque niveau, objets contenant au moins les clés de expected, et chaque
+// élément d'un tableau conforme au premier élément du tableau attendu. Un
+// expected nil accepte toute valeur. Les deux valeurs sont comparées après
+// un aller-retour JSON, si bien que les types Go importent peu.
+func MatchShape(expected, actual interface{}) error {
+	exp, err := normalizeJSON(expected)
+	if err != nil {
+		return err
+	}
+	act, err := normalizeJSON(actual)
+	if err != nil {
+		return err
+	}
+	return matchShape("$", exp, act)
+}
+
+func normalizeJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+func matchShape(path string, expected, actual interface{}) error {
+	if expected == nil {
+		return nil
+	}
+	if jsonKind(expected) != jsonKind(actual) {
+		return &ShapeError{Path: path, Expected: jsonKind(expected), Actual: jsonKind(actual)}
+	}
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		act := actual.(map[string]interface{})
+		for k, v := range exp {
+			child := path + "." + k
+			a, ok := act[k]
+			if !ok {
+				return &ShapeError{Path: child, Expected: jsonKind(v), Actual: "missing"}
+			}
+			if err := matchShape(child, v, a); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if len(exp) == 0 {
+			return nil
+		}
+		for i, a := range actual.([]interface{}) {
+			if err := matchShape(path+"["+strconv.Itoa(i)+"]", exp[0], a); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
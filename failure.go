@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Codes d'échec courants de Failure.Code. Les plugins peuvent en définir
+// d'autres via FailureCoder.
+const (
+	FailureCodeError       = "error"
+	FailureCodeTimeout     = "timeout"
+	FailureCodeUnsupported = "unsupported"
+)
+
+// FailureCoder peut être implémentée par une erreur pour fixer le code et
+// le caractère rejouable de l'échec qu'elle provoque.
+type FailureCoder interface {
+	FailureCode() string
+	Retryable() bool
+}
+
+// Failure est la forme canonique de ExecutionContext.FailureData : les
+// branches OnFailure y trouvent les mêmes champs quel que soit le plugin en
+// échec.
+type Failure struct {
+	NodeID    string    `json:"nodeId"`
+	Uses      string    `json:"uses,omitempty"`
+	Code      string    `json:"code"`
+	Message   string    `json:"message"`
+	Attempt   int       `json:"attempt,omitempty"`
+	Time      time.Time `json:"time,omitzero"`
+	Retryable bool      `json:"retryable"`
+	// PartialOutput est ce que le nœud a produit avant d'échouer, s'il
+	// y a lieu.
+	PartialOutput interface{} `json:"partialOutput,omitempty"`
+}
+
+// NewFailure décrit l'échec de node à sa tentative attempt. Le code est lu
+// sur err lorsqu'elle implémente FailureCoder, déduit de son type sinon.
+func NewFailure(node Node, err error, attempt int) Failure {
+	f := Failure{
+		NodeID:  node.ID,
+		Uses:    node.Uses,
+		Code:    FailureCodeError,
+		Message: err.Error(),
+		Attempt: attempt,
+		Time:    time.Now().UTC(),
+	}
+	var coder FailureCoder
+	var timeout *TimeoutError
+	switch {
+	case errors.As(err, &coder):
+		f.Code, f.Retryable = coder.FailureCode(), coder.Retryable()
+	case errors.As(err, &timeout):
+		f.Code, f.Retryable = FailureCodeTimeout, true
+	case errors.Is(err, errors.ErrUnsupported):
+		f.Code = FailureCodeUnsupported
+	}
+	return f
+}
+
+// ToFailureData convertit l'échec dans la forme attendue par
+// ExecutionContext.FailureData.
+func (f Failure) ToFailureData() (map[string]interface{}, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Failure relit FailureData sous sa forme canonique. Elle renvoie false en
+// l'absence d'échec ou si FailureData ne suit pas cette forme.
+func (c ExecutionContext) Failure() (Failure, bool) {
+	if c.FailureData == nil {
+		return Failure{}, false
+	}
+	var f Failure
+	b, err := json.Marshal(c.FailureData)
+	if err != nil {
+		return Failure{}, false
+	}
+	if err := json.Unmarshal(b, &f); err != nil || f.NodeID == "" {
+		return Failure{}, false
+	}
+	return f, true
+}
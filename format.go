@@ -0,0 +1,38 @@
+package shared
+
+import "fmt"
+
+// Versions du format d'encodage des valeurs dans ExecuteRequest et
+// ExecuteResponse. Un émetteur indique la version utilisée dans
+// format_version ; un message sans version provient d'une bibliothèque
+// antérieure et suit FormatV1.
+const (
+	// FormatV1 encode les valeurs en JSON seul.
+	FormatV1 uint32 = 1
+	// FormatV2 ajoute les indications de type (dates, durées, décimaux),
+	// voir encodeValue.
+	FormatV2 uint32 = 2
+	// FormatVersion est la version produite par cette bibliothèque.
+	FormatVersion = FormatV2
+)
+
+// wireFormat renvoie la version d'un message reçu, ou une erreur si elle est
+// plus récente que FormatVersion.
+func wireFormat(v uint32) (uint32, error) {
+	if v == 0 {
+		return FormatV1, nil
+	}
+	if v > FormatVersion {
+		return 0, fmt.Errorf("unsupported wire format version %d (max %d): upgrade orkestra-shared", v, FormatVersion)
+	}
+	return v, nil
+}
+
+// hintsFor renvoie hints si format les transporte, nil sinon. Un pair en
+// FormatV1 lirait les valeurs sans leurs indications.
+func hintsFor(format uint32, hints []byte) []byte {
+	if format < FormatV2 {
+		return nil
+	}
+	return hints
+}
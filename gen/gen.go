@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+)
+
+// Params décrit le plugin à générer.
+type Params struct {
+	Package  string
+	Name     string
+	Version  string
+	Manifest shared.Manifest
+	// Source est le nom du fichier de manifeste, repris dans l'en-tête.
+	Source string
+}
+
+// capability est une capacité du manifeste et ses noms Go.
+type capability struct {
+	spec   shared.CapabilitySpec
+	name   string // nom de la méthode de Handlers
+	config string // nom de la structure de configuration
+}
+
+func capabilities(m shared.Manifest) ([]capability, error) {
+	caps := make([]capability, 0, len(m.Capabilities))
+	seen := map[string]string{}
+	for _, spec := range m.Capabilities {
+		name := goName(spec.Uses)
+		if name == "" {
+			return nil, fmt.Errorf("capability %q has no usable Go name", spec.Uses)
+		}
+		if prev, ok := seen[name]; ok {
+			return nil, fmt.Errorf("capabilities %q and %q both map to %s", prev, spec.Uses, name)
+		}
+		seen[name] = spec.Uses
+		caps = append(caps, capability{spec: spec, name: name, config: name + "Config"})
+	}
+	return caps, nil
+}
+
+// GeneratePlugin renvoie le fichier généré : configurations, interface
+// Handlers et NodeExecutor qui aiguille sur Uses.
+func GeneratePlugin(p Params) ([]byte, error) {
+	caps, err := capabilities(p.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := json.Marshal(p.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by orkestra-shared/gen from %s. DO NOT EDIT.\n\n", p.Source)
+	fmt.Fprintf(&b, "package %s\n\n", p.Package)
+	b.WriteString("import (\n\t\"encoding/json\"\n\t\"sync\"\n\n\tshared \"github.com/orkestra-io/orkestra-shared\"\n)\n\n")
+	fmt.Fprintf(&b, "const (\n\tpluginName = %q\n\tpluginVersion = %q\n)\n\n", p.Name, p.Version)
+	fmt.Fprintf(&b, "const manifestJSON = %s\n\n", strconv.Quote(string(manifest)))
+	b.WriteString(`var manifest = sync.OnceValues(func() (shared.Manifest, error) {
+	var m shared.Manifest
+	err := json.Unmarshal([]byte(manifestJSON), &m)
+	return m, err
+})
+
+`)
+	types := newTypeWriter(caps)
+	for _, c := range caps {
+		types.out.Reset()
+		types.object(c.config, fmt.Sprintf("%s est la configuration (With) de la capacité %s.", c.config, c.spec.Uses), c.spec.InputSchema)
+		b.Write(types.out.Bytes())
+	}
+
+	b.WriteString("// Handlers implémente la logique métier des capacités du plugin.\ntype Handlers interface {\n")
+	for _, c := range caps {
+		if c.spec.Description != "" {
+			fmt.Fprintf(&b, "\t// %s %s\n", c.name, comment(c.spec.Description))
+		}
+		fmt.Fprintf(&b, "\t%s(cfg %s, ctx shared.ExecutionContext) (interface{}, error)\n", c.name, c.config)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString(`// Plugin est le NodeExecutor du plugin : il vérifie With contre le schéma
+// d'entrée, le décode dans la configuration de la capacité, la valide puis
+// appelle Handlers.
+type Plugin struct {
+	Handlers Handlers
+}
+
+func (p *Plugin) Execute(node shared.Node, ctx shared.ExecutionContext) (interface{}, error) {
+	m, err := manifest()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.CheckWith(node, false); err != nil {
+		return nil, shared.WrapError(shared.CodeInvalidInput, err, "invalid configuration for %s", node.Uses)
+	}
+	switch {
+`)
+	for _, c := range caps {
+		fmt.Fprintf(&b, "\tcase shared.SameCapability(node.Uses, %q):\n", c.spec.Uses)
+		fmt.Fprintf(&b, "\t\tvar cfg %s\n", c.config)
+		b.WriteString("\t\tif err := decodeWith(node, &cfg); err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+		fmt.Fprintf(&b, "\t\treturn p.Handlers.%s(cfg, ctx)\n", c.name)
+	}
+	b.WriteString(`	}
+	return nil, shared.NewError(shared.CodeInvalidInput, "unknown capability %q", node.Uses)
+}
+
+func (p *Plugin) GetCapabilities() ([]string, error) {
+	m, err := manifest()
+	if err != nil {
+		return nil, err
+	}
+	uses := make([]string, len(m.Capabilities))
+	for i, c := range m.Capabilities {
+		uses[i] = c.Uses
+	}
+	return uses, nil
+}
+
+func (p *Plugin) GetInfo() (shared.PluginInfo, error) {
+	m, err := manifest()
+	if err != nil {
+		return shared.PluginInfo{}, err
+	}
+	info := shared.NewPluginInfo(pluginName, pluginVersion)
+	info.Manifest = m
+	return info, nil
+}
+
+// validator est implémentée par chaque configuration (voir le fichier des
+// gestionnaires).
+type validator interface {
+	Validate() error
+}
+
+func decodeWith(node shared.Node, cfg validator) error {
+	b, err := json.Marshal(node.With)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return shared.WrapError(shared.CodeInvalidInput, err, "invalid configuration for %s", node.Uses)
+	}
+	if err := cfg.Validate(); err != nil {
+		return shared.WrapError(shared.CodeInvalidInput, err, "invalid configuration for %s", node.Uses)
+	}
+	return nil
+}
+`)
+	return formatSource(b.Bytes())
+}
+
+// GenerateHandlers renvoie le squelette des gestionnaires à compléter :
+// une méthode par capacité et un Validate par configuration.
+func GenerateHandlers(p Params) ([]byte, error) {
+	caps, err := capabilities(p.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\n", p.Package)
+	b.WriteString("import shared \"github.com/orkestra-io/orkestra-shared\"\n\n")
+	b.WriteString("// handlers implémente Handlers.\ntype handlers struct{}\n\n")
+	for _, c := range caps {
+		fmt.Fprintf(&b, "func (handlers) %s(cfg %s, ctx shared.ExecutionContext) (interface{}, error) {\n", c.name, c.config)
+		fmt.Fprintf(&b, "\treturn nil, shared.NewError(shared.CodeInternal, %q)\n}\n\n", c.spec.Uses+" is not implemented")
+	}
+	for _, c := range caps {
+		fmt.Fprintf(&b, "// Validate vérifie les contraintes de %s que le schéma ne peut\n// exprimer.\n", c.config)
+		fmt.Fprintf(&b, "func (c *%s) Validate() error {\n\treturn nil\n}\n\n", c.config)
+	}
+	return formatSource(b.Bytes())
+}
+
+// GenerateTest renvoie le test de contrat du plugin, qui rejoue les exemples
+// du manifeste.
+func GenerateTest(p Params) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\n", p.Package)
+	b.WriteString("import (\n\t\"testing\"\n\n\t\"github.com/orkestra-io/orkestra-shared/contracttest\"\n)\n\n")
+	b.WriteString("func TestContract(t *testing.T) {\n\tcontracttest.Run(t, &Plugin{Handlers: handlers{}})\n}\n")
+	return formatSource(b.Bytes())
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go source: %w", err)
+	}
+	return out, nil
+}
+
+// typeWriter écrit les structures décrites par un schéma d'entrée ; les
+// objets imbriqués deviennent des structures nommées d'après leur parent.
+// Un nom déjà pris par un autre type du fichier reçoit un suffixe numérique.
+type typeWriter struct {
+	out   bytes.Buffer
+	names map[string]bool
+}
+
+// newTypeWriter renvoie un typeWriter qui réserve les noms des types déjà
+// déclarés par le fichier généré.
+func newTypeWriter(caps []capability) *typeWriter {
+	w := &typeWriter{names: map[string]bool{"Plugin": true, "Handlers": true}}
+	for _, c := range caps {
+		w.names[c.config] = true
+	}
+	return w
+}
+
+// unique renvoie name, ou name suivi du plus petit suffixe qui le rend
+// unique dans taken, et l'y ajoute.
+func unique(taken map[string]bool, name string) string {
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+func (w *typeWriter) object(name, doc string, s *shared.Schema) {
+	var fields bytes.Buffer
+	if s != nil {
+		props := make([]string, 0, len(s.Properties))
+		for p := range s.Properties {
+			props = append(props, p)
+		}
+		sort.Strings(props)
+		required := map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		fieldNames := map[string]bool{}
+		for _, p := range props {
+			prop := s.Properties[p]
+			field := goName(p)
+			if field == "" {
+				field = "Field"
+			}
+			// « max-retries » et « max_retries » donnent tous deux MaxRetries.
+			field = unique(fieldNames, field)
+			if prop != nil && prop.Description != "" {
+				fmt.Fprintf(&fields, "\t// %s\n", strings.Join(strings.Fields(prop.Description), " "))
+			}
+			tag := p
+			if !required[p] {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&fields, "\t%s %s `json:%q`\n", field, w.goType(name+field, prop), tag)
+		}
+	}
+	fmt.Fprintf(&w.out, "// %s\ntype %s struct {\n%s}\n\n", doc, name, fields.String())
+}
+
+func (w *typeWriter) goType(name string, s *shared.Schema) string {
+	if s == nil {
+		return "interface{}"
+	}
+	var t string
+	switch s.Type {
+	case "string":
+		t = "string"
+	case "number":
+		t = "float64"
+	case "integer":
+		t = "int64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + w.goType(name+"Item", s.Items)
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		t = unique(w.names, name)
+		w.object(t, t+" est un élément de configuration.", s)
+	default:
+		return "interface{}"
+	}
+	if s.Nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// initialisms sont écrits en capitales dans les noms Go.
+var initialisms = map[string]bool{
+	"api": true, "id": true, "url": true, "uri": true, "http": true, "https": true,
+	"json": true, "xml": true, "sql": true, "ip": true, "uuid": true, "html": true,
+	"db": true,
+}
+
+// goName convertit une valeur de Uses ou un nom de propriété en identifiant
+// Go exporté : « http/get-json » devient HTTPGetJSON.
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name != "" && !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// comment ramène une description sur une ligne de commentaire.
+func comment(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
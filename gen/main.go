@@ -0,0 +1,76 @@
+// Commande gen génère le squelette typé d'un plugin à partir du manifeste de
+// ses capacités : une structure de configuration par capacité, tirée de son
+// schéma d'entrée, l'interface Handlers, et un NodeExecutor qui aiguille sur
+// Uses, décode et valide With. Elle s'utilise avec go generate :
+//
+//	//go:generate go run github.com/orkestra-io/orkestra-shared/gen -manifest manifest.json -name my-plugin -version 0.1.0
+//
+// Le fichier plugin_gen.go est réécrit à chaque exécution. Les squelettes
+// plugin_handlers.go (gestionnaires et validations à compléter) et
+// plugin_test.go (test de contrat rejouant les exemples du manifeste) ne
+// sont écrits que s'ils n'existent pas.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	manifestPath := flag.String("manifest", "manifest.json", "capability manifest (JSON)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated files (default: $GOPACKAGE, or main)")
+	name := flag.String("name", "", "plugin name reported by GetInfo")
+	version := flag.String("version", "0.0.0", "plugin version reported by GetInfo")
+	dir := flag.String("dir", ".", "output directory")
+	flag.Parse()
+	if err := run(*manifestPath, *pkg, *name, *version, *dir); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(manifestPath, pkg, name, version, dir string) error {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+	p := Params{Package: pkg, Name: name, Version: version, Source: filepath.Base(manifestPath)}
+	if err := json.Unmarshal(data, &p.Manifest); err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", manifestPath, err)
+	}
+	if p.Package == "" {
+		p.Package = "main"
+	}
+	files := []struct {
+		name     string
+		generate func(Params) ([]byte, error)
+		skeleton bool
+	}{
+		{"plugin_gen.go", GeneratePlugin, false},
+		{"plugin_handlers.go", GenerateHandlers, true},
+		{"plugin_test.go", GenerateTest, true},
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if f.skeleton {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		src, err := f.generate(p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
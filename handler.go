@@ -0,0 +1,168 @@
+package shared
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// HandlerFunc est la logique métier typée d'une capacité : cfg est With
+// décodé, la sortie est encodée en valeur JSON pour le moteur.
+type HandlerFunc[Config, Output any] func(ctx ExecutionContext, cfg Config) (Output, error)
+
+// Validator peut être implémentée par une configuration de HandlerFunc pour
+// vérifier ses champs une fois With décodé.
+type Validator interface {
+	Validate() error
+}
+
+// Handler exécute un nœud ; c'est la forme non typée d'une HandlerFunc.
+type Handler func(node Node, ctx ExecutionContext) (interface{}, error)
+
+// Middleware enveloppe un Handler pour lui ajouter un comportement
+// transverse : injection d'identifiants, journalisation, cache.
+type Middleware func(next Handler) Handler
+
+// Router est un NodeExecutor qui aiguille chaque nœud vers le gestionnaire
+// de sa capacité (voir Register et HandleFunc), à travers les middlewares
+// communs (Use) puis ceux de la capacité (UseFor).
+type Router struct {
+	mu          sync.RWMutex
+	handlers    map[string]Handler
+	middlewares []Middleware
+	byUses      map[string][]Middleware
+	notFound    Handler
+}
+
+// NewRouter crée un Router sans capacité.
+func NewRouter() *Router {
+	return &Router{handlers: map[string]Handler{}, byUses: map[string][]Middleware{}}
+}
+
+// DefaultRouter est le Router utilisé par Handle.
+var DefaultRouter = NewRouter()
+
+// Handle enregistre fn pour la capacité uses sur DefaultRouter.
+func Handle[Config, Output any](uses string, fn HandlerFunc[Config, Output]) {
+	Register(DefaultRouter, uses, fn)
+}
+
+// Register enregistre fn pour la capacité uses sur r, en remplaçant un
+// éventuel gestionnaire précédent. With est décodé dans Config, validé si
+// Config implémente Validator, et la sortie convertie en valeur JSON ; une
+// sortie de type Result ou *Result est transmise telle quelle. Les erreurs
+// de décodage et de validation portent le code CodeInvalidInput.
+func Register[Config, Output any](r *Router, uses string, fn HandlerFunc[Config, Output]) {
+	r.HandleFunc(uses, func(node Node, ctx ExecutionContext) (interface{}, error) {
+		var cfg Config
+		if err := decodeConfig(node.With, &cfg); err != nil {
+			return nil, WrapError(CodeInvalidInput, err, "invalid configuration for %s", node.Uses)
+		}
+		out, err := fn(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		switch v := interface{}(out).(type) {
+		case Result, *Result:
+			return v, nil
+		}
+		return normalizeJSON(out)
+	})
+}
+
+// HandleFunc enregistre h pour la capacité uses, sans décodage de With.
+func (r *Router) HandleFunc(uses string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[uses] = h
+}
+
+// Use ajoute des middlewares appliqués à toutes les capacités, y compris au
+// gestionnaire NotFound. Le premier ajouté est le plus extérieur.
+func (r *Router) Use(mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, mw...)
+}
+
+// UseFor ajoute des middlewares propres à la capacité uses, appliqués après
+// ceux de Use.
+func (r *Router) UseFor(uses string, mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byUses[uses] = append(r.byUses[uses], mw...)
+}
+
+// NotFound remplace le gestionnaire des capacités non enregistrées, qui
+// renvoie par défaut une erreur CodeNotFound.
+func (r *Router) NotFound(h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notFound = h
+}
+
+// decodeConfig décode with dans cfg puis le valide.
+func decodeConfig(with map[string]interface{}, cfg interface{}) error {
+	b, err := json.Marshal(with)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return err
+	}
+	if v, ok := cfg.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// handler renvoie le gestionnaire de uses enveloppé de ses middlewares.
+func (r *Router) handler(uses string) Handler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key := uses
+	h, ok := r.handlers[uses]
+	if !ok {
+		for registered, rh := range r.handlers {
+			if SameCapability(registered, uses) {
+				key, h, ok = registered, rh, true
+				break
+			}
+		}
+	}
+	var chain []Middleware
+	if ok {
+		chain = append(chain, r.middlewares...)
+		chain = append(chain, r.byUses[key]...)
+	} else {
+		h = r.notFound
+		if h == nil {
+			h = notFound
+		}
+		chain = r.middlewares
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		h = chain[i](h)
+	}
+	return h
+}
+
+func notFound(node Node, ctx ExecutionContext) (interface{}, error) {
+	return nil, NewError(CodeNotFound, "unknown capability %q", node.Uses)
+}
+
+func (r *Router) Execute(node Node, ctx ExecutionContext) (interface{}, error) {
+	return r.handler(node.Uses)(node, ctx)
+}
+
+// GetCapabilities renvoie les capacités enregistrées, triées.
+func (r *Router) GetCapabilities() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	uses := make([]string, 0, len(r.handlers))
+	for u := range r.handlers {
+		uses = append(uses, u)
+	}
+	sort.Strings(uses)
+	return uses, nil
+}
@@ -0,0 +1,155 @@
+// Package hash calcule des empreintes stables des charges utiles échangées
+// avec les plugins, pour les clés de cache, la déduplication et la signature
+// des webhooks.
+//
+// Les empreintes sont des SHA-256 en hexadécimal. Celles de valeurs JSON ne
+// dépendent pas de l'ordre des clés ni de la représentation Go des valeurs
+// (int ou float64, struct ou map). Celles de flux portent sur les octets
+// lus, tels quels : un contenu gzip n'est décompressé qu'à la demande (voir
+// Hasher.ReadGzipFrom), une signature ne devant pas couvrir autre chose que
+// ce qui a été reçu.
+package hash
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	stdhash "hash"
+	"io"
+)
+
+// Canonical renvoie l'encodage JSON canonique de v : clés des objets triées,
+// sans espaces ni échappement HTML, nombres tels que les écrit
+// encoding/json (les grands entiers sont conservés exactement).
+func Canonical(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(normalized); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Hasher calcule une empreinte par morceaux, pour les charges trop grandes
+// pour être assemblées en mémoire. Son zéro n'est pas utilisable : voir New
+// et NewHMAC.
+type Hasher struct {
+	h stdhash.Hash
+}
+
+// New renvoie un Hasher SHA-256.
+func New() *Hasher {
+	return &Hasher{h: sha256.New()}
+}
+
+// NewHMAC renvoie un Hasher HMAC-SHA256 de clé secret.
+func NewHMAC(secret []byte) *Hasher {
+	return &Hasher{h: hmac.New(sha256.New, secret)}
+}
+
+func (h *Hasher) Write(p []byte) (int, error) {
+	return h.h.Write(p)
+}
+
+// WriteJSON ajoute l'encodage canonique de v.
+func (h *Hasher) WriteJSON(v interface{}) error {
+	b, err := Canonical(v)
+	if err != nil {
+		return err
+	}
+	h.h.Write(b)
+	return nil
+}
+
+// ReadFrom ajoute le contenu de r, tel quel.
+func (h *Hasher) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(h.h, r)
+}
+
+// ReadGzipFrom ajoute le contenu décompressé de r, qui doit être au format
+// gzip. Il renvoie le nombre d'octets décompressés.
+func (h *Hasher) ReadGzipFrom(r io.Reader) (int64, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gzip content: %w", err)
+	}
+	defer zr.Close()
+	return io.Copy(h.h, zr)
+}
+
+// Sum renvoie l'empreinte en hexadécimal.
+func (h *Hasher) Sum() string {
+	return hex.EncodeToString(h.h.Sum(nil))
+}
+
+// Bytes renvoie l'empreinte de b.
+func Bytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// JSON renvoie l'empreinte de l'encodage canonique de v : deux valeurs égales
+// une fois décodées ont la même empreinte.
+func JSON(v interface{}) (string, error) {
+	h := New()
+	if err := h.WriteJSON(v); err != nil {
+		return "", err
+	}
+	return h.Sum(), nil
+}
+
+// Reader renvoie l'empreinte du contenu de r.
+func Reader(r io.Reader) (string, error) {
+	h := New()
+	if _, err := h.ReadFrom(r); err != nil {
+		return "", err
+	}
+	return h.Sum(), nil
+}
+
+// HMAC renvoie le HMAC-SHA256 de la concaténation de parts.
+func HMAC(secret []byte, parts ...[]byte) string {
+	h := NewHMAC(secret)
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum()
+}
+
+// HMACJSON renvoie le HMAC-SHA256 de l'encodage canonique de v.
+func HMACJSON(secret []byte, v interface{}) (string, error) {
+	h := NewHMAC(secret)
+	if err := h.WriteJSON(v); err != nil {
+		return "", err
+	}
+	return h.Sum(), nil
+}
+
+// HMACReader renvoie le HMAC-SHA256 du contenu de r.
+func HMACReader(secret []byte, r io.Reader) (string, error) {
+	h := NewHMAC(secret)
+	if _, err := h.ReadFrom(r); err != nil {
+		return "", err
+	}
+	return h.Sum(), nil
+}
+
+// Equal compare deux empreintes en temps constant.
+func Equal(a, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
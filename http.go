@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// HTTPRequest est la forme canonique d'une requête HTTP dans le With d'un
+// nœud ou dans ses sorties. Les en-têtes à valeurs multiples sont joints par
+// ", ". Le corps est porté soit brut (Body), soit décodé (JSON).
+type HTTPRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+	JSON    interface{}       `json:"json,omitempty"`
+}
+
+// HTTPResponse est la forme canonique de la sortie d'un nœud HTTP.
+type HTTPResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+	JSON    interface{}       `json:"json,omitempty"`
+}
+
+// NewRequest construit une *http.Request. JSON est prioritaire sur Body et
+// positionne Content-Type s'il n'est pas déjà fourni.
+func (r HTTPRequest) NewRequest(ctx context.Context) (*http.Request, error) {
+	body := r.Body
+	if r.JSON != nil {
+		var err error
+		if body, err = json.Marshal(r.JSON); err != nil {
+			return nil, fmt.Errorf("failed to encode JSON body: %w", err)
+		}
+	}
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), r.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+	if r.JSON != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// NewHTTPResponse lit entièrement resp.Body et le ferme. Les corps JSON sont
+// décodés dans le champ JSON, les autres conservés dans Body.
+func NewHTTPResponse(resp *http.Response) (HTTPResponse, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	out := HTTPResponse{Status: resp.StatusCode, Headers: flattenHeader(resp.Header)}
+	if isJSONContentType(resp.Header.Get("Content-Type")) && len(body) > 0 {
+		if err := json.Unmarshal(body, &out.JSON); err == nil {
+			return out, nil
+		}
+	}
+	out.Body = body
+	return out, nil
+}
+
+// HTTPResponseFromValue relit la sortie d'un nœud HTTP telle qu'elle apparaît
+// dans ExecutionContext.NodeOutputs.
+func HTTPResponseFromValue(v interface{}) (HTTPResponse, error) {
+	var out HTTPResponse
+	b, err := json.Marshal(v)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return out, fmt.Errorf("value is not an HTTP response: %w", err)
+	}
+	return out, nil
+}
+
+func flattenHeader(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+func isJSONContentType(ct string) bool {
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
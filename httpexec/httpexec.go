@@ -0,0 +1,265 @@
+// Package httpexec permet à un service HTTP (fonction serverless, service
+// écrit dans un autre langage) d'agir comme NodeExecutor sans embarquer
+// go-plugin.
+//
+// Protocole :
+//
+//	POST <url>/execute       {"version": 1, "node": ..., "context": ...}
+//	GET  <url>/capabilities  ["vendor/capability", ...]
+//
+// La forme de "node" et "context" est celle du paquet internal/jsonwire,
+// dont "version" porte le numéro. Les secrets du contexte voyagent dans le
+// corps : Executor refuse les URL en clair, sauf AllowInsecure.
+//
+// La réponse à /execute est {"value": ..., "next_cursor": "...",
+// "warnings": [...], "continuation": {"after_ms": ..., "state": ...}} ou
+// {"error": "..."}. Chaque requête est signée par l'en-tête
+// X-Orkestra-Signature: t=<unix>,v1=<hex>, où v1 est le HMAC-SHA256 de
+// "<t>.<corps>" avec le secret partagé.
+package httpexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+	"github.com/orkestra-io/orkestra-shared/internal/jsonwire"
+	"github.com/orkestra-io/orkestra-shared/webhook"
+)
+
+// SignatureHeader est l'en-tête portant la signature HMAC.
+const SignatureHeader = webhook.SignatureHeader
+
+// DefaultTolerance est l'écart maximal accepté par Verify entre
+// l'horodatage signé et l'horloge locale.
+const DefaultTolerance = webhook.DefaultTolerance
+
+// maxBodyBytes borne la taille des corps lus, requêtes comme réponses.
+const maxBodyBytes = 32 << 20
+
+type warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type response struct {
+	Value        interface{}                  `json:"value,omitempty"`
+	NextCursor   string                       `json:"next_cursor,omitempty"`
+	Warnings     []warning                    `json:"warnings,omitempty"`
+	Continuation *shared.ScheduleContinuation `json:"continuation,omitempty"`
+	Error        string                       `json:"error,omitempty"`
+}
+
+// Executor implémente shared.NodeExecutor en appelant un point de
+// terminaison HTTPS.
+type Executor struct {
+	// URL est la base des points de terminaison, sans barre finale.
+	URL string
+	// Secret signe les requêtes. Vide : requêtes non signées.
+	Secret []byte
+	// Client est le client HTTP utilisé, http.DefaultClient par défaut.
+	Client *http.Client
+	// Timeout borne chaque appel lorsque ni le nœud ni le contexte ne fixent
+	// d'échéance. Zéro : pas de limite.
+	Timeout time.Duration
+	// AllowInsecure autorise une URL http:// ; sans lui, seul https:// est
+	// accepté puisque les requêtes portent les secrets du nœud.
+	AllowInsecure bool
+}
+
+var (
+	_ shared.NodeExecutor   = (*Executor)(nil)
+	_ shared.ResultExecutor = (*Executor)(nil)
+)
+
+func (e *Executor) Execute(node shared.Node, ctx shared.ExecutionContext) (interface{}, error) {
+	res, err := e.ExecuteResult(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// ExecuteResult est comme Execute mais renvoie l'enveloppe complète du
+// résultat.
+func (e *Executor) ExecuteResult(node shared.Node, ctx shared.ExecutionContext) (*shared.Result, error) {
+	body, err := json.Marshal(jsonwire.NewRequest(node, ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	callCtx, cancel := e.callContext(node, ctx)
+	defer cancel()
+	var resp response
+	if err := e.do(callCtx, http.MethodPost, "/execute", body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	if resp.Continuation != nil {
+		if err := resp.Continuation.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid response: %w", err)
+		}
+	}
+	res := &shared.Result{Value: resp.Value, NextCursor: resp.NextCursor, Continuation: resp.Continuation}
+	for _, w := range resp.Warnings {
+		res.Warnings = append(res.Warnings, shared.Warning{Code: w.Code, Message: w.Message})
+	}
+	return res, nil
+}
+
+func (e *Executor) GetCapabilities() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shared.DefaultCallTimeout)
+	defer cancel()
+	var uses []string
+	if err := e.do(ctx, http.MethodGet, "/capabilities", nil, &uses); err != nil {
+		return nil, err
+	}
+	return uses, nil
+}
+
+func (e *Executor) callContext(node shared.Node, ctx shared.ExecutionContext) (context.Context, context.CancelFunc) {
+	timeout := node.Timeout
+	if timeout <= 0 {
+		timeout = e.Timeout
+	}
+	c, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		c, cancel = context.WithTimeout(c, timeout)
+	}
+	if !ctx.Deadline.IsZero() {
+		c2, cancel2 := context.WithDeadline(c, ctx.Deadline)
+		return c2, func() { cancel2(); cancel() }
+	}
+	return c, cancel
+}
+
+func (e *Executor) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	if !e.AllowInsecure && !strings.HasPrefix(strings.ToLower(e.URL), "https://") {
+		return fmt.Errorf("refusing non-TLS endpoint %q: set AllowInsecure to allow it", e.URL)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(e.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(e.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(e.Secret, time.Now(), body))
+	}
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &shared.TimeoutError{Method: path[1:]}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		// Un corps {"error": ...} est préféré au statut seul.
+		var r response
+		if json.Unmarshal(data, &r) == nil && r.Error != "" {
+			return fmt.Errorf("%s %s: %s", method, path, r.Error)
+		}
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s %s: invalid response: %w", method, path, err)
+	}
+	return nil
+}
+
+// Sign calcule la valeur de SignatureHeader pour body à l'instant t.
+func Sign(secret []byte, t time.Time, body []byte) string {
+	return webhook.Sign(t, body, secret)
+}
+
+// Verify vérifie la signature header de body. Les signatures dont
+// l'horodatage s'écarte de plus de tolerance de now sont refusées.
+func Verify(secret []byte, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	return webhook.Verify(header, body, now, tolerance, secret)
+}
+
+// Handler sert exec selon le protocole du paquet, pour exposer un
+// NodeExecutor écrit en Go derrière HTTP. Si secret n'est pas vide, les
+// requêtes non signées ou mal signées sont refusées.
+func Handler(exec shared.NodeExecutor, secret []byte) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /execute", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readVerified(w, r, secret)
+		if !ok {
+			return
+		}
+		var req jsonwire.Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeJSON(w, http.StatusBadRequest, response{Error: "invalid request: " + err.Error()})
+			return
+		}
+		if req.Version != jsonwire.Version {
+			writeJSON(w, http.StatusBadRequest, response{Error: fmt.Sprintf("unsupported wire version %d", req.Version)})
+			return
+		}
+		res, err := shared.ExecuteResult(exec, req.Node.ToNode(), req.Context.ToContext())
+		if err == nil && res.Continuation != nil {
+			err = res.Continuation.Validate()
+		}
+		if err != nil {
+			writeJSON(w, http.StatusOK, response{Error: err.Error()})
+			return
+		}
+		out := response{Value: res.Value, NextCursor: res.NextCursor, Continuation: res.Continuation}
+		for _, wn := range res.Warnings {
+			out.Warnings = append(out.Warnings, warning{Code: wn.Code, Message: wn.Message})
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("GET /capabilities", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := readVerified(w, r, secret); !ok {
+			return
+		}
+		uses, err := exec.GetCapabilities()
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, response{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, uses)
+	})
+	return mux
+}
+
+func readVerified(w http.ResponseWriter, r *http.Request, secret []byte) ([]byte, bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Error: err.Error()})
+		return nil, false
+	}
+	if len(secret) > 0 {
+		if err := Verify(secret, r.Header.Get(SignatureHeader), body, time.Now(), DefaultTolerance); err != nil {
+			writeJSON(w, http.StatusUnauthorized, response{Error: err.Error()})
+			return nil, false
+		}
+	}
+	return body, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
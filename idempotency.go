@@ -0,0 +1,278 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/orkestra-io/orkestra-shared/broker"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// ErrClaimLost est renvoyée par IdempotencyStore.Complete et Release
+// lorsque le jeton présenté n'est plus celui de la réservation en cours :
+// elle a expiré et une autre tentative a réservé la clé depuis.
+var ErrClaimLost = errors.New("idempotency claim lost")
+
+// IdempotencyRecord est l'enregistrement d'un effet de bord sous sa clé
+// d'idempotence.
+type IdempotencyRecord struct {
+	Key string
+	// Token est le jeton de la réservation, renseigné lorsque Claim réussit
+	// : Complete et Release l'exigent, si bien qu'une tentative dont la
+	// réservation a expiré ne peut plus écraser celle qui lui a succédé.
+	Token uint64
+	// Done est faux tant que l'effet est en cours : une autre exécution l'a
+	// réservé et ne l'a ni terminé ni abandonné.
+	Done bool
+	// Result est le résultat de l'effet, sérialisé en JSON.
+	Result    []byte
+	CreatedAt time.Time
+}
+
+// IdempotencyStore conserve les effets de bord déjà produits, pour qu'une
+// tentative rejouée (relance, reprise après arrêt) ne les produise pas une
+// seconde fois. Le moteur en fournit une implémentation partagée
+// (ClientOptions.Idempotency), que les plugins reçoivent via
+// IdempotencyStoreReceiver. Voir le paquet idempotency.
+type IdempotencyStore interface {
+	// Claim réserve key pour la durée lease et renvoie l'enregistrement de
+	// la réservation, dont le jeton, et true. Si key est déjà réservée ou
+	// terminée, elle renvoie l'enregistrement existant et false. Elle doit
+	// être atomique, et chaque réservation d'une clé recevoir un jeton
+	// différent des précédentes.
+	Claim(key string, lease time.Duration) (IdempotencyRecord, bool, error)
+	// Complete enregistre le résultat de key et le conserve pendant ttl. Si
+	// token n'est plus celui de la réservation, elle renvoie ErrClaimLost.
+	Complete(key string, token uint64, result []byte, ttl time.Duration) error
+	// Release abandonne la réservation token de key, pour qu'une tentative
+	// suivante puisse produire l'effet. Si token n'est plus celui de la
+	// réservation, elle renvoie ErrClaimLost.
+	Release(key string, token uint64) error
+}
+
+// IdempotencyStoreReceiver peut être implémentée par un NodeExecutor pour
+// recevoir l'IdempotencyStore du moteur au démarrage.
+// SetIdempotencyStore n'est pas appelée si le moteur n'en fournit pas.
+type IdempotencyStoreReceiver interface {
+	SetIdempotencyStore(store IdempotencyStore)
+}
+
+// idempotencyKey renvoie la clé d'idempotence de ctx pour node : celle
+// fixée par le moteur, ou à défaut l'exécution et le nœud, identiques d'une
+// tentative à l'autre.
+func idempotencyKey(node Node, ctx ExecutionContext) string {
+	if ctx.IdempotencyKey != "" || ctx.RunID == "" {
+		return ctx.IdempotencyKey
+	}
+	return ctx.RunID + "/" + node.ID
+}
+
+// idempotencyNamespace renvoie le préfixe des clés du plugin dans
+// l'IdempotencyStore partagé : ClientOptions.IdempotencyNamespace, ou à
+// défaut le nom annoncé par GetInfo, suivi de l'exécuteur dans un plugin
+// multiplexé.
+func (m *NodeExecutorGRPC) idempotencyNamespace() (string, error) {
+	ns := m.opts.IdempotencyNamespace
+	if ns == "" {
+		info, err := m.GetInfo()
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return "", err
+		}
+		if info.Name == "" {
+			return "", errors.New("idempotency store requires ClientOptions.IdempotencyNamespace or a plugin name")
+		}
+		ns = info.Name
+	}
+	if m.executor != "" {
+		ns += "/" + m.executor
+	}
+	return ns, nil
+}
+
+// attachIdempotencyStore sert store via le broker de go-plugin et transmet
+// son identifiant au plugin. Les clés du plugin y sont préfixées par son
+// espace de noms, pour que deux plugins ne puissent pas lire ni réserver
+// les effets l'un de l'autre. Les plugins qui ne l'utilisent pas sont
+// ignorés.
+func (m *NodeExecutorGRPC) attachIdempotencyStore(b *plugin.GRPCBroker, store IdempotencyStore) error {
+	ns, err := m.idempotencyNamespace()
+	if err != nil {
+		return err
+	}
+	srv := broker.Register[proto.IdempotencyStoreServer](b, proto.RegisterIdempotencyStoreServer, &idempotencyStoreServer{store: store, prefix: ns + "|"})
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	_, err = m.client.SetIdempotencyStore(callCtx, &proto.SetIdempotencyStoreRequest{BrokerId: srv.ID})
+	if err = fromRPCError("SetIdempotencyStore", err); err != nil {
+		srv.Close()
+		if errors.Is(err, errors.ErrUnsupported) {
+			return nil
+		}
+		return withTimeout(err, m.opts.callTimeout())
+	}
+	return nil
+}
+
+func (s *NodeExecutorGRPCServer) SetIdempotencyStore(ctx context.Context, req *proto.SetIdempotencyStoreRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	r, ok := implementation[IdempotencyStoreReceiver](s.Impl)
+	if !ok || s.broker == nil {
+		return s.UnimplementedNodeExecutorServer.SetIdempotencyStore(ctx, req)
+	}
+	c, err := broker.Dial(s.broker, req.BrokerId, proto.NewIdempotencyStoreClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial idempotency store: %w", err)
+	}
+	r.SetIdempotencyStore(&idempotencyStoreClient{client: c.Service})
+	return &proto.Empty{}, nil
+}
+
+// idempotencyStoreServer expose l'IdempotencyStore du moteur au plugin,
+// sous son préfixe.
+type idempotencyStoreServer struct {
+	proto.UnimplementedIdempotencyStoreServer
+	store  IdempotencyStore
+	prefix string
+}
+
+func (s *idempotencyStoreServer) Claim(ctx context.Context, req *proto.ClaimRequest) (*proto.ClaimResponse, error) {
+	rec, claimed, err := s.store.Claim(s.prefix+req.Key, time.Duration(req.LeaseMs)*time.Millisecond)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	resp := &proto.ClaimResponse{Claimed: claimed}
+	if claimed {
+		resp.Token = rec.Token
+	} else {
+		resp.Record = &proto.IdempotencyRecord{
+			Key:           req.Key,
+			Done:          rec.Done,
+			Result:        rec.Result,
+			CreatedUnixMs: unixMillis(rec.CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
+func (s *idempotencyStoreServer) Complete(ctx context.Context, req *proto.CompleteRequest) (*proto.Empty, error) {
+	if err := s.store.Complete(s.prefix+req.Key, req.Token, req.Result, time.Duration(req.TtlMs)*time.Millisecond); err != nil {
+		return nil, toRPCError(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+func (s *idempotencyStoreServer) Release(ctx context.Context, req *proto.IdempotencyKey) (*proto.Empty, error) {
+	if err := s.store.Release(s.prefix+req.Key, req.Token); err != nil {
+		return nil, toRPCError(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// idempotencyStoreClient est l'IdempotencyStore remis au plugin.
+type idempotencyStoreClient struct {
+	client proto.IdempotencyStoreClient
+}
+
+func (c *idempotencyStoreClient) Claim(key string, lease time.Duration) (IdempotencyRecord, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	resp, err := c.client.Claim(ctx, &proto.ClaimRequest{Key: key, LeaseMs: lease.Milliseconds()})
+	if err != nil {
+		return IdempotencyRecord{}, false, fromRPCError("Claim", err)
+	}
+	if resp.Claimed {
+		return IdempotencyRecord{Key: key, Token: resp.Token}, true, nil
+	}
+	if resp.Record == nil {
+		return IdempotencyRecord{}, false, nil
+	}
+	return IdempotencyRecord{
+		Key:       resp.Record.Key,
+		Done:      resp.Record.Done,
+		Result:    resp.Record.Result,
+		CreatedAt: fromUnixMillis(resp.Record.CreatedUnixMs),
+	}, false, nil
+}
+
+func (c *idempotencyStoreClient) Complete(key string, token uint64, result []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	_, err := c.client.Complete(ctx, &proto.CompleteRequest{Key: key, Token: token, Result: result, TtlMs: ttl.Milliseconds()})
+	return fromRPCError("Complete", err)
+}
+
+func (c *idempotencyStoreClient) Release(key string, token uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	_, err := c.client.Release(ctx, &proto.IdempotencyKey{Key: key, Token: token})
+	return fromRPCError("Release", err)
+}
+
+// MemoryIdempotencyStore est un IdempotencyStore en mémoire, pour un
+// moteur à instance unique et les tests de plugins. Son zéro est prêt à
+// l'emploi.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]memoryIdempotencyRecord
+	tokens  uint64
+}
+
+type memoryIdempotencyRecord struct {
+	IdempotencyRecord
+	expires time.Time
+}
+
+func (s *MemoryIdempotencyStore) Claim(key string, lease time.Duration) (IdempotencyRecord, bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = map[string]memoryIdempotencyRecord{}
+	}
+	if r, ok := s.records[key]; ok && now.Before(r.expires) {
+		rec := r.IdempotencyRecord
+		rec.Token = 0
+		return rec, false, nil
+	}
+	s.tokens++
+	rec := IdempotencyRecord{Key: key, Token: s.tokens, CreatedAt: now}
+	s.records[key] = memoryIdempotencyRecord{IdempotencyRecord: rec, expires: now.Add(lease)}
+	return rec, true, nil
+}
+
+// held renvoie la réservation token de key, si elle est toujours en cours.
+func (s *MemoryIdempotencyStore) held(key string, token uint64) (memoryIdempotencyRecord, error) {
+	r, ok := s.records[key]
+	if !ok || r.Done || r.Token != token || !time.Now().Before(r.expires) {
+		return r, fmt.Errorf("idempotency key %s: %w", key, ErrClaimLost)
+	}
+	return r, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(key string, token uint64, result []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, err := s.held(key, token)
+	if err != nil {
+		return err
+	}
+	r.Done, r.Result, r.expires = true, result, time.Now().Add(ttl)
+	s.records[key] = r
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Release(key string, token uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.held(key, token); err != nil {
+		return err
+	}
+	delete(s.records, key)
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package idempotency garantit qu'un effet de bord externe (paiement,
+// envoi de message, création d'enregistrement) n'est produit qu'une fois
+// pour un nœud, même lorsque le moteur le rejoue après un échec ou un arrêt
+// brutal.
+//
+// Un plugin reçoit l'IdempotencyStore du moteur via
+// shared.IdempotencyStoreReceiver et enveloppe chaque écriture dans Do :
+//
+//	func (p *Plugin) SetIdempotencyStore(store shared.IdempotencyStore) {
+//		p.guard = idempotency.New(store)
+//	}
+//
+//	func (p *Plugin) Execute(node shared.Node, ctx shared.ExecutionContext) (interface{}, error) {
+//		return p.guard.Do(idempotency.Key(ctx, "charge"), func() (interface{}, error) {
+//			return p.api.Charge(...)
+//		})
+//	}
+//
+// La première tentative exécute fn et enregistre son résultat ; les
+// suivantes renvoient le résultat enregistré sans rappeler fn.
+package idempotency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+)
+
+const (
+	// DefaultLease est la durée de réservation d'une clé pendant
+	// l'exécution de fn. Passé ce délai, une tentative suivante considère
+	// l'exécution précédente comme perdue et rejoue fn.
+	DefaultLease = 5 * time.Minute
+	// DefaultTTL est la durée de conservation d'un résultat enregistré.
+	DefaultTTL = 7 * 24 * time.Hour
+)
+
+// ErrInProgress est renvoyée par Do lorsqu'une autre exécution a réservé la
+// clé et ne l'a pas encore terminée. Une nouvelle tentative après la fin de
+// la réservation aboutit.
+var ErrInProgress = errors.New("idempotent operation in progress")
+
+func init() {
+	shared.RegisterError("orkestra.idempotency-in-progress", ErrInProgress)
+}
+
+// Guard exécute des effets de bord au plus une fois par clé.
+type Guard struct {
+	Store shared.IdempotencyStore
+	// Lease est la durée de réservation d'une clé. Zéro : DefaultLease.
+	Lease time.Duration
+	// TTL est la durée de conservation des résultats. Zéro : DefaultTTL.
+	TTL time.Duration
+}
+
+// New renvoie un Guard sur store avec les durées par défaut.
+func New(store shared.IdempotencyStore) *Guard {
+	return &Guard{Store: store}
+}
+
+// Key renvoie la clé de l'opération op pour l'exécution ctx. op distingue
+// les effets de bord d'un même nœud ; pour un nœud qui traite une liste, il
+// doit identifier l'élément.
+func Key(ctx shared.ExecutionContext, op string) string {
+	return ctx.IdempotencyKey + "#" + op
+}
+
+// Do exécute fn si key n'a pas encore été traitée et enregistre son
+// résultat, ou renvoie le résultat enregistré sans appeler fn. Le résultat
+// est sérialisé en JSON : il est renvoyé sous sa forme décodée
+// (map[string]interface{}, []interface{}...) dans les deux cas, pour qu'une
+// tentative rejouée voie exactement ce qu'a vu la première.
+//
+// Si fn échoue, la réservation est abandonnée et une tentative suivante
+// rappellera fn. Si l'enregistrement du résultat échoue après le succès de
+// fn, l'erreur est renvoyée et la clé reste réservée jusqu'à la fin de
+// Lease : l'effet ne sera pas reproduit avant. Si fn a duré plus que Lease
+// et qu'une autre tentative a réservé la clé entre-temps, Do renvoie
+// shared.ErrClaimLost sans écraser son enregistrement.
+func (g *Guard) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	if key == "" || key[0] == '#' {
+		return nil, fmt.Errorf("idempotency key is empty: %w", shared.ErrInvalidInput)
+	}
+	rec, claimed, err := g.Store.Claim(key, orDefault(g.Lease, DefaultLease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key %s: %w", key, err)
+	}
+	if !claimed {
+		if !rec.Done {
+			return nil, fmt.Errorf("idempotency key %s claimed at %s: %w", key, rec.CreatedAt.Format(time.RFC3339), ErrInProgress)
+		}
+		return decode(key, rec.Result)
+	}
+	v, err := fn()
+	if err != nil {
+		if relErr := g.Store.Release(key, rec.Token); relErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to release idempotency key %s: %w", key, relErr))
+		}
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result of %s: %w", key, err)
+	}
+	if err := g.Store.Complete(key, rec.Token, b, orDefault(g.TTL, DefaultTTL)); err != nil {
+		return nil, fmt.Errorf("failed to record result of %s: %w", key, err)
+	}
+	return decode(key, b)
+}
+
+func decode(key string, b []byte) (interface{}, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("invalid recorded result for %s: %w", key, err)
+	}
+	return v, nil
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
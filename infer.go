@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"math"
+	"sort"
+)
+
+// InferSchema déduit un schéma des sorties observées samples, pour les
+// capacités qui ne déclarent pas d'OutputSchema : le moteur s'en sert pour
+// proposer les champs d'une sortie dans l'éditeur. Les échantillons sont
+// fusionnés en élargissant les types (voir MergeSchemas) ; un champ n'est
+// requis que s'il figure dans tous les objets observés. Elle renvoie nil sans
+// échantillon.
+func InferSchema(samples ...interface{}) (*Schema, error) {
+	var s *Schema
+	for _, sample := range samples {
+		v, err := normalizeJSON(sample)
+		if err != nil {
+			return nil, err
+		}
+		s = MergeSchemas(s, inferSchema(v))
+	}
+	return s, nil
+}
+
+func inferSchema(v interface{}) *Schema {
+	switch x := v.(type) {
+	case nil:
+		return &Schema{Type: "null"}
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	case map[string]interface{}:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema, len(x))}
+		for name, child := range x {
+			s.Properties[name] = inferSchema(child)
+			s.Required = append(s.Required, name)
+		}
+		sort.Strings(s.Required)
+		return s
+	case []interface{}:
+		s := &Schema{Type: "array"}
+		for _, item := range x {
+			s.Items = MergeSchemas(s.Items, inferSchema(item))
+		}
+		return s
+	}
+	return &Schema{Type: jsonKind(v)}
+}
+
+// MergeSchemas renvoie le schéma le plus étroit acceptant les valeurs de a
+// et de b : integer et number s'élargissent en number, null rend l'autre
+// type Nullable, et deux types incompatibles donnent un schéma sans type.
+// Les objets réunissent leurs propriétés, les tableaux fusionnent leurs
+// éléments. Un schéma nil est neutre. a et b ne sont pas modifiés.
+func MergeSchemas(a, b *Schema) *Schema {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	if a.Type == "null" {
+		a, b = b, a
+	}
+	if b.Type == "null" {
+		out := *a
+		out.Nullable = a.Type != "" && a.Type != "null"
+		return &out
+	}
+	out := &Schema{Type: a.Type, Nullable: a.Nullable || b.Nullable, Description: a.Description}
+	if out.Description == "" {
+		out.Description = b.Description
+	}
+	switch {
+	case a.Type == b.Type:
+	case a.Type == "integer" && b.Type == "number", a.Type == "number" && b.Type == "integer":
+		out.Type = "number"
+	default:
+		return &Schema{Description: out.Description}
+	}
+	switch out.Type {
+	case "object":
+		out.Properties = make(map[string]*Schema, len(a.Properties)+len(b.Properties))
+		for name, p := range a.Properties {
+			out.Properties[name] = MergeSchemas(p, b.Properties[name])
+		}
+		for name, p := range b.Properties {
+			if _, ok := a.Properties[name]; !ok {
+				out.Properties[name] = p
+			}
+		}
+		inB := make(map[string]bool, len(b.Required))
+		for _, name := range b.Required {
+			inB[name] = true
+		}
+		for _, name := range a.Required {
+			if inB[name] {
+				out.Required = append(out.Required, name)
+			}
+		}
+	case "array":
+		out.Items = MergeSchemas(a.Items, b.Items)
+	}
+	return out
+}
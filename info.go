@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"runtime/debug"
+	"slices"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// PluginInfo décrit un plugin déployé : son identité, sa version et les
+// fonctionnalités du protocole qu'il supporte.
+type PluginInfo struct {
+	Name          string
+	Version       string
+	GitCommit     string
+	SharedVersion string
+	Features      []Feature
+	Manifest      Manifest
+}
+
+// InfoProvider peut être implémenté par un NodeExecutor pour exposer son nom
+// et sa version. SharedVersion et Features sont toujours renseignés par la
+// bibliothèque côté serveur.
+type InfoProvider interface {
+	GetInfo() (PluginInfo, error)
+}
+
+// NewPluginInfo construit un PluginInfo pour le plugin courant. Le commit git
+// est lu depuis les informations de build du binaire lorsqu'elles sont
+// disponibles (go build avec -buildvcs).
+func NewPluginInfo(name, version string) PluginInfo {
+	info := PluginInfo{Name: name, Version: version, SharedVersion: Version}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" {
+				info.GitCommit = s.Value
+			}
+		}
+	}
+	return info
+}
+
+// HasFeature indique si le plugin supporte la fonctionnalité f.
+func (i PluginInfo) HasFeature(f Feature) bool {
+	for _, feature := range i.Features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+func toProtoPluginInfo(info PluginInfo) (*proto.PluginInfo, error) {
+	manifest, err := toProtoManifest(info.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	features := make([]string, len(info.Features))
+	for i, f := range info.Features {
+		features[i] = string(f)
+	}
+	return &proto.PluginInfo{
+		Name:          info.Name,
+		Version:       info.Version,
+		GitCommit:     info.GitCommit,
+		SharedVersion: info.SharedVersion,
+		Features:      features,
+		Manifest:      manifest,
+	}, nil
+}
+
+func fromProtoPluginInfo(pInfo *proto.PluginInfo) (PluginInfo, error) {
+	manifest, err := fromProtoManifest(pInfo.Manifest)
+	if err != nil {
+		return PluginInfo{}, err
+	}
+	features := make([]Feature, len(pInfo.Features))
+	for i, f := range pInfo.Features {
+		features[i] = Feature(f)
+	}
+	return PluginInfo{
+		Name:          pInfo.Name,
+		Version:       pInfo.Version,
+		GitCommit:     pInfo.GitCommit,
+		SharedVersion: pInfo.SharedVersion,
+		Features:      features,
+		Manifest:      manifest,
+	}, nil
+}
+
+// Supports indique si le plugin annonce la fonctionnalité f. GetInfo est
+// interrogé une fois ; les plugins qui n'y répondent pas n'annoncent aucune
+// fonctionnalité. Le moteur s'en sert pour adopter une nouvelle RPC tout en
+// se repliant sur l'ancienne avec les plugins qui l'ignorent. Tant que
+// GetInfo échoue pour une autre raison, Supports renvoie false ; voir
+// SupportsOrErr pour distinguer ce cas.
+func (m *NodeExecutorGRPC) Supports(f Feature) bool {
+	ok, _ := m.SupportsOrErr(f)
+	return ok
+}
+
+// SupportsOrErr est comme Supports, mais renvoie l'erreur de GetInfo
+// lorsque les fonctionnalités du plugin ne peuvent pas être lues.
+func (m *NodeExecutorGRPC) SupportsOrErr(f Feature) (bool, error) {
+	if _, err := m.manifestOrErr(); err != nil {
+		return false, err
+	}
+	features := m.features.Load()
+	return features != nil && slices.Contains(*features, f), nil
+}
@@ -2,24 +2,36 @@ package shared
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/rpc"
+	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
-	"github.com/orkestra-io/orkestra-shared/proto"
+	"github.com/orkestra-io/orkestra-shared/broker"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"github.com/orkestra-io/orkestra-shared/vcr"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 // HandshakeConfig est utilisé pour s'assurer que le moteur et le plugin
 // communiquent sur la même version.
 var HandshakeConfig = plugin.HandshakeConfig{
-	ProtocolVersion:  1,
+	ProtocolVersion:  ProtocolVersion,
 	MagicCookieKey:   "ORKESTRA_PLUGIN",
 	MagicCookieValue: "hello",
 }
 
 // NodeExecutor est l'interface que tous les plugins de nœuds doivent implémenter.
+// Execute peut être appelée de manière concurrente, sauf si le plugin déclare
+// une limite via Manifest.MaxConcurrency. Les nouveaux plugins implémentent
+// plutôt v2.NodeExecutor, servi via v2.ToV1.
 type NodeExecutor interface {
 	Execute(node Node, ctx ExecutionContext) (interface{}, error)
 	GetCapabilities() ([]string, error)
@@ -36,6 +48,71 @@ type ExecutionContext struct {
 	Secrets     map[string]string
 	CurrentItem interface{}
 	FailureData map[string]interface{}
+	// Cursor est le curseur renvoyé par l'appel précédent d'une capacité
+	// paginée, vide pour la première page.
+	Cursor string
+	// Deadline est l'échéance fixée par le moteur pour l'exécution. Côté
+	// plugin, elle est renseignée à partir de l'échéance de l'appel gRPC.
+	Deadline time.Time
+	// RunID identifie l'exécution du workflow. Il est transmis dans les
+	// métadonnées de l'appel gRPC.
+	RunID string
+	// RequestID identifie cet appel d'Execute dans les journaux du moteur,
+	// du plugin et des API externes (voir SetRequestHeader). Le client en
+	// génère un s'il est vide ; il est transmis dans les métadonnées de
+	// l'appel gRPC.
+	RequestID string
+	// Attempt est le numéro de la tentative, à partir de 1 ; zéro s'il n'est
+	// pas connu.
+	Attempt int
+	// TenantID identifie le locataire pour le compte duquel le workflow
+	// s'exécute, vide hors contexte multi-locataire.
+	TenantID string
+	// Identity est l'utilisateur qui a déclenché le workflow, zéro s'il
+	// n'est pas connu. Voir ScopeChecker.
+	Identity Identity
+	// Budget est ce qu'il reste au workflow de temps, d'éléments et de
+	// dépense, nil s'il n'est pas borné.
+	Budget *Budget
+	// Connection est la connexion désignée par Node.Connection, résolue par
+	// le moteur ; ses secrets sont dans Secrets. Nil si le nœud n'en
+	// utilise pas.
+	Connection *Connection
+	// Attachments sont les fichiers reçus par le déclencheur (envoi
+	// multipart d'un webhook). Voir ReadAttachment.
+	Attachments []Attachment
+	// IdempotencyKey est identique d'une tentative à l'autre d'un même nœud
+	// dans une exécution, pour que les effets de bord d'une tentative
+	// rejouée ne soient pas produits deux fois (voir le paquet idempotency).
+	// Le client la dérive de RunID et de l'identifiant du nœud si le moteur
+	// ne la fixe pas.
+	IdempotencyKey string
+	// Continuation est la reprise en cours lorsque le nœud a demandé à être
+	// rappelé (voir ScheduleContinuation), nil au premier appel.
+	Continuation *Continuation
+	// OutputRefs désigne les sorties laissées dans le magasin d'artefacts
+	// du moteur, absentes de NodeOutputs. Voir Output et OutputRefReader.
+	OutputRefs map[string]OutputRef
+	// Mode rend l'exécution reproductible pour la rejouer ; voir
+	// Deterministic. Seed et Clock fixent alors les nombres aléatoires et
+	// l'heure, Cassette les échanges HTTP.
+	Mode     ExecutionMode
+	Seed     int64
+	Clock    time.Time
+	Cassette *vcr.Cassette
+
+	// resolve lit une sortie transmise par référence, côté plugin.
+	resolve func(OutputRef) (interface{}, error)
+	// fetch lit un contenu du magasin d'artefacts, côté plugin.
+	fetch func(ref string) ([]byte, error)
+	// done est fermé à l'annulation de l'appel gRPC, côté plugin.
+	done <-chan struct{}
+}
+
+// Done renvoie un canal fermé lorsque le moteur abandonne l'appel (annulation
+// ou échéance dépassée), côté plugin ; nil, qui ne se ferme jamais, ailleurs.
+func (c ExecutionContext) Done() <-chan struct{} {
+	return c.done
 }
 
 type Node struct {
@@ -46,6 +123,38 @@ type Node struct {
 	Do        []*Node
 	Retries   *Retries
 	OnFailure []*Node
+	// Timeout borne la durée d'exécution du nœud, zéro si non borné.
+	Timeout time.Duration
+	// Labels sont des étiquettes courtes exploitables pour le filtrage et le
+	// routage (propriétaire, centre de coût). Annotations porte des
+	// métadonnées d'outillage plus volumineuses (position dans l'éditeur).
+	// Aucune des deux n'a d'effet sur l'exécution.
+	Labels      map[string]string
+	Annotations map[string]string
+	// Priority ordonne les nœuds en attente : les plus élevées sont servies
+	// en premier (de MinPriority à MaxPriority, zéro par défaut).
+	Priority int
+	// Queue dirige le nœud vers un groupe de workers dédié, vide pour la
+	// file par défaut.
+	Queue string
+	// Resources est le budget indicatif du nœud, nil si non renseigné.
+	Resources *Resources
+	// Matrix associe à chaque dimension la liste de ses valeurs. Le nœud est
+	// exécuté une fois par combinaison (voir ExpandMatrix).
+	Matrix map[string][]interface{}
+	// Affinity regroupe les exécutions qui doivent être servies par la même
+	// instance du plugin (voir Pool), vide si n'importe laquelle convient.
+	Affinity string
+	// Env contient les variables d'environnement propres à l'exécution du
+	// nœud.
+	Env map[string]string
+	// Connection nomme la connexion (voir Connection) dont le nœud utilise
+	// les identifiants et réglages, vide si aucune.
+	Connection string
+	// Compensate est l'action inverse du nœud, exécutée par le moteur si un
+	// nœud ultérieur échoue, pour défaire ce que celui-ci a produit (voir
+	// UnwindOrder). Nil si le nœud n'a rien à défaire.
+	Compensate *Node
 }
 
 // --- gRPC Implementation ---
@@ -53,58 +162,453 @@ type Node struct {
 // NodeExecutorGRPC est le client gRPC.
 type NodeExecutorGRPC struct {
 	client proto.NodeExecutorClient
+	opts   ClientOptions
+	limit  *limiter
+	caps   capabilitiesCache
+	debug  proto.PluginDebugClient
+	// manifest est le dernier manifeste lu par GetInfo, et features les
+	// fonctionnalités qu'il annonçait.
+	manifest atomic.Pointer[Manifest]
+	features atomic.Pointer[[]Feature]
+	// manifestErr est le dernier échec de lecture du manifeste, nil après
+	// une lecture réussie.
+	manifestErr atomic.Pointer[manifestFailure]
+	// compression indique que le dernier GetInfo annonçait
+	// FeatureCompression.
+	compression atomic.Bool
+	// zstd indique que le dernier GetInfo annonçait FeatureZstd.
+	zstd atomic.Bool
+	// artifacts est le magasin transmis au plugin, nil s'il n'accepte pas
+	// les références de sorties.
+	artifacts ArtifactStore
+	// outputsDelta indique que le dernier GetInfo annonçait
+	// FeatureOutputsDelta.
+	outputsDelta atomic.Bool
+	deltas       deltaSessions
+	// executor est la clé de l'exécuteur au sein d'un plugin multiplexé,
+	// transmise dans les métadonnées de chaque appel.
+	executor string
+	// rateLimits est le dernier état de quota rapporté par capacité.
+	rateLimits rateLimits
+	// backpressure est la dernière demande de ralentissement du plugin.
+	backpressure backpressure
 }
 
 func (m *NodeExecutorGRPC) Execute(node Node, ctx ExecutionContext) (interface{}, error) {
-	req, err := toProtoExecuteRequest(node, ctx)
+	res, err := m.ExecuteResult(node, ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert request for gRPC: %w", err)
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// ExecuteResult est comme Execute mais renvoie l'enveloppe complète du
+// résultat (curseur de pagination, etc.). L'identifiant de requête, généré
+// si ctx n'en porte pas, est repris dans Result.RequestID et dans les
+// erreurs (voir RequestIDOf).
+func (m *NodeExecutorGRPC) ExecuteResult(node Node, ctx ExecutionContext) (*Result, error) {
+	ctx = withRequestID(ctx)
+	res, err := m.executeResult(node, ctx)
+	if err != nil {
+		return nil, &RequestIDError{RequestID: ctx.RequestID, Err: err}
+	}
+	res.RequestID = ctx.RequestID
+	return res, nil
+}
+
+func (m *NodeExecutorGRPC) executeResult(node Node, ctx ExecutionContext) (*Result, error) {
+	if err := m.checkWith(node); err != nil {
+		return nil, err
+	}
+	if err := m.checkScopes(node, ctx); err != nil {
+		return nil, err
+	}
+	if err := m.checkPolicy(node, ctx); err != nil {
+		return nil, err
+	}
+	req, err := m.executeRequest(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeout := m.executeTimeout(node, ctx)
+	ctx, entry, err := m.journal(node, ctx)
+	if err != nil {
+		m.deltas.reset(req.Context.OutputsDelta)
+		return nil, err
+	}
+	callCtx, cancel := m.executeContext(timeout, ctx)
+	defer cancel()
+	release, err := m.limit.acquire(callCtx)
+	if err != nil {
+		// La requête n'est pas partie : sa séquence ne sera jamais connue du
+		// plugin.
+		m.deltas.reset(req.Context.OutputsDelta)
+		err = &TimeoutError{Method: "Execute", Timeout: timeout}
+		entry.done(nil, err)
+		return nil, err
+	}
+	defer release()
+	watch := m.watchSlowCall("Execute", node)
+	resp, err := m.client.Execute(callCtx, req)
+	if m.deltaMissed(err, req) {
+		entry.retry(err)
+		if req, err = m.executeRequest(node, ctx); err == nil {
+			resp, err = m.client.Execute(callCtx, req)
+		}
+	}
+	if err != nil {
+		err = withTimeout(fromRPCError("Execute", err), timeout)
+	}
+	err = watch.stop(err)
+	m.capture(node, ctx, err != nil)
+	if err != nil {
+		m.observeRateLimit(node, nil, err)
+		m.observeBackpressure(err)
+		entry.done(nil, err)
+		return nil, err
+	}
+	res, err := fromProtoExecuteResponse(m.codec(), resp)
+	if err == nil {
+		err = m.checkContinuation(node, res)
+	}
+	if err != nil {
+		entry.done(nil, err)
+		return nil, err
+	}
+	m.observeRateLimit(node, res, nil)
+	m.reportUsage(node, ctx, res)
+	res, err = m.checkOutputSchema(node, res)
+	entry.done(res, err)
+	return res, err
+}
+
+// nodeRequest prépare le nœud et le contexte des RPC qui, comme Execute,
+// transmettent au plugin les secrets d'un nœud (SampleOutput, Poll, Ack) :
+// mêmes contrôles d'accès, connexion résolue et secrets cloisonnés.
+func (m *NodeExecutorGRPC) nodeRequest(node Node, ctx ExecutionContext) (*proto.Node, *proto.ExecutionContext, error) {
+	if err := m.checkAccess(node, ctx); err != nil {
+		return nil, nil, err
+	}
+	ctx, err := m.resolveConnection(node, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.opts.IsolateTenantSecrets {
+		if ctx, err = isolateTenantSecrets(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	c := m.codec()
+	pNode, err := toProtoNode(c, &node, m.opts.MaxNodeDepth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert node for gRPC: %w", err)
+	}
+	pCtx, err := toProtoExecutionContext(c, &ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert context for gRPC: %w", err)
 	}
-	resp, err := m.client.Execute(context.Background(), req)
+	return pNode, pCtx, nil
+}
+
+// executeRequest prépare la requête d'exécution : les sorties volumineuses
+// sont déposées dans le magasin d'artefacts, les autres réduites à leurs
+// changements, puis le contexte compressé.
+func (m *NodeExecutorGRPC) executeRequest(node Node, ctx ExecutionContext) (*proto.ExecuteRequest, error) {
+	ctx, err := m.resolveConnection(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx.IdempotencyKey = idempotencyKey(node, ctx)
+	if m.opts.IsolateTenantSecrets {
+		if ctx, err = isolateTenantSecrets(ctx); err != nil {
+			return nil, err
+		}
+	}
+	ctx, refs, err := m.offloadOutputs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return fromProtoValue(resp.Result)
+	if ctx, err = m.offloadAttachments(ctx); err != nil {
+		return nil, err
+	}
+	var delta *proto.OutputsDelta
+	if m.useDelta(ctx) {
+		if ctx.NodeOutputs, delta, err = m.deltas.diff(m.codec(), ctx.RunID, ctx.NodeOutputs); err != nil {
+			return nil, fmt.Errorf("failed to compute outputs delta: %w", err)
+		}
+	}
+	req, err := toProtoExecuteRequest(m.codec(), node, ctx, m.opts.MaxNodeDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request for gRPC: %w", err)
+	}
+	req.Context.NodeOutputRefs = refs
+	req.Context.OutputsDelta = delta
+	if err := m.compressContext(req.Context); err != nil {
+		return nil, fmt.Errorf("failed to compress context: %w", err)
+	}
+	return req, nil
 }
 
+// GetCapabilities renvoie les capacités du plugin. Lorsque
+// ClientOptions.CapabilitiesTTL est renseigné, la réponse est gardée en cache
+// pendant cette durée, puis revalidée auprès du plugin par son ETag. Ses
+// échecs sont des *DiscoveryError.
 func (m *NodeExecutorGRPC) GetCapabilities() ([]string, error) {
-	resp, err := m.client.GetCapabilities(context.Background(), &proto.Empty{})
+	ttl := m.opts.CapabilitiesTTL
+	var etag string
+	if ttl > 0 {
+		var uses []string
+		var fresh bool
+		if uses, etag, fresh = m.caps.get(ttl); fresh {
+			return uses, nil
+		}
+	}
+	resp, err := m.getCapabilities(etag)
 	if err != nil {
 		return nil, err
 	}
+	if resp.NotModified {
+		if uses, ok := m.caps.revalidate(resp.Etag); ok {
+			return uses, nil
+		}
+		// Le cache a été invalidé entre-temps.
+		if resp, err = m.getCapabilities(""); err != nil {
+			return nil, err
+		}
+	}
+	if ttl > 0 {
+		m.caps.store(resp.Uses, resp.Etag)
+	}
 	return resp.Uses, nil
 }
 
+func (m *NodeExecutorGRPC) getCapabilities(ifNoneMatch string) (*proto.GetCapabilitiesResponse, error) {
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.GetCapabilities(callCtx, &proto.GetCapabilitiesRequest{IfNoneMatch: ifNoneMatch})
+	if err != nil {
+		return nil, discoveryError(withTimeout(fromRPCError("GetCapabilities", err), m.opts.callTimeout()))
+	}
+	return resp, nil
+}
+
+// InvalidateCapabilities vide le cache de GetCapabilities, par exemple après
+// le redémarrage du plugin.
+func (m *NodeExecutorGRPC) InvalidateCapabilities() {
+	m.caps.invalidate()
+}
+
+// GetInfo interroge le plugin sur sa version. Les plugins compilés avec une
+// version antérieure de orkestra-shared renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) GetInfo() (PluginInfo, error) {
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.GetInfo(callCtx, &proto.Empty{})
+	if err != nil {
+		return PluginInfo{}, withTimeout(fromRPCError("GetInfo", err), m.opts.callTimeout())
+	}
+	info, err := fromProtoPluginInfo(resp)
+	if err != nil {
+		return PluginInfo{}, fmt.Errorf("failed to convert plugin info from proto: %w", err)
+	}
+	if m.limit != nil {
+		m.limit.setLimit(minLimit(m.opts.MaxConcurrency, info.Manifest.MaxConcurrency))
+	}
+	m.compression.Store(info.HasFeature(FeatureCompression))
+	m.zstd.Store(info.HasFeature(FeatureZstd))
+	m.outputsDelta.Store(info.HasFeature(FeatureOutputsDelta))
+	m.features.Store(&info.Features)
+	m.manifest.Store(&info.Manifest)
+	return info, nil
+}
+
+// Unwrapper peut être implémentée par un NodeExecutor qui en adapte un
+// autre (voir v2.ToV1) : les interfaces optionnelles (ItemStreamer,
+// Preflighter, Poller…) que le NodeExecutor n'implémente pas sont cherchées
+// sur la valeur renvoyée par Unwrap.
+type Unwrapper interface {
+	Unwrap() interface{}
+}
+
+// implementation renvoie impl, ou la valeur qu'il adapte (voir Unwrapper),
+// sous l'interface optionnelle T.
+func implementation[T any](impl NodeExecutor) (T, bool) {
+	if t, ok := impl.(T); ok {
+		return t, true
+	}
+	if u, ok := impl.(Unwrapper); ok {
+		t, ok := u.Unwrap().(T)
+		return t, ok
+	}
+	var zero T
+	return zero, false
+}
+
 type NodeExecutorGRPCServer struct {
 	proto.UnimplementedNodeExecutorServer
 	Impl NodeExecutor
+
+	limit    *limiter
+	stats    serverStats
+	recorder *requestRecorder
+	wireLog  atomic.Pointer[wireLogger]
+	options  ServerOptions
+	broker   *plugin.GRPCBroker
+	notices  noticeQueue
+	// artifacts est le magasin d'artefacts du moteur, reçu par
+	// SetArtifactStore.
+	artifacts atomic.Pointer[artifactStoreClient]
+	deltas    deltaBaselines
+	// tokenStore est la connexion au TokenStore du moteur, reçu par
+	// SetTokenStore.
+	tokenStore atomic.Pointer[broker.Client[proto.TokenStoreClient]]
 }
 
-func (s *NodeExecutorGRPCServer) Execute(ctx context.Context, req *proto.ExecuteRequest) (*proto.ExecuteResponse, error) {
-	node, execCtx, err := fromProtoExecuteRequest(req)
+func (s *NodeExecutorGRPCServer) Execute(ctx context.Context, req *proto.ExecuteRequest) (resp *proto.ExecuteResponse, err error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if wl := s.wireLog.Load(); wl != nil {
+		wl.logRequest("Execute", req)
+		defer func() { wl.logResponse("Execute", req, resp, err) }()
+	}
+	node, execCtx, err := fromProtoExecuteRequest(s.options.Codec, req, s.options.MaxNodeDepth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert request from proto: %w", err)
 	}
+	if err := s.deltas.apply(req.Context.GetOutputsDelta(), &execCtx); err != nil {
+		return nil, err
+	}
+	execCtx.Deadline, _ = ctx.Deadline()
+	execCtx.done = ctx.Done()
+	md := CallMetadataFromContext(ctx)
+	execCtx.RunID, execCtx.RequestID = md.RunID, md.RequestID
+	execCtx.resolve = s.outputResolver()
+	execCtx.fetch = s.artifactFetcher()
+	if execCtx.Mode == ModeRecord && execCtx.Cassette == nil {
+		execCtx.Cassette = &vcr.Cassette{}
+	}
 
+	release, err := s.limit.acquire(ctx)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	defer release()
+	start := time.Now()
+	s.stats.begin()
 	result, err := s.Impl.Execute(node, execCtx)
+	s.stats.end(err)
+	s.recorder.record(node, execCtx.Secrets, start, err)
 	if err != nil {
-		return nil, err
+		return nil, toRPCError(err)
 	}
 
-	protoResult, err := toProtoValue(result)
+	// La réponse suit la version de la requête : un client antérieur ne
+	// saurait pas lire une version plus récente.
+	format, _ := wireFormat(req.FormatVersion)
+	res := asResult(result)
+	if execCtx.Mode == ModeRecord {
+		recorded := *res
+		recorded.Cassette = execCtx.Cassette
+		res = &recorded
+	}
+	resp, err = toProtoExecuteResponse(s.codec(ctx), res, format)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert result to proto: %w", err)
 	}
 
-	return &proto.ExecuteResponse{Result: protoResult}, nil
+	return resp, nil
 }
 
-func (s *NodeExecutorGRPCServer) GetCapabilities(ctx context.Context, req *proto.Empty) (*proto.GetCapabilitiesResponse, error) {
+func (s *NodeExecutorGRPCServer) GetCapabilities(ctx context.Context, req *proto.GetCapabilitiesRequest) (*proto.GetCapabilitiesResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
 	uses, err := s.Impl.GetCapabilities()
 	if err != nil {
+		return nil, toRPCError(err)
+	}
+	etag := capabilitiesETag(uses)
+	if req.IfNoneMatch == etag {
+		return &proto.GetCapabilitiesResponse{Etag: etag, NotModified: true}, nil
+	}
+	return &proto.GetCapabilitiesResponse{Uses: uses, Etag: etag}, nil
+}
+
+func (s *NodeExecutorGRPCServer) GetInfo(ctx context.Context, req *proto.Empty) (*proto.PluginInfo, error) {
+	if err := s.authorize(ctx); err != nil {
 		return nil, err
 	}
-	return &proto.GetCapabilitiesResponse{Uses: uses}, nil
+	var info PluginInfo
+	if p, ok := implementation[InfoProvider](s.Impl); ok {
+		var err error
+		if info, err = p.GetInfo(); err != nil {
+			return nil, err
+		}
+	}
+	info.SharedVersion = Version
+	info.Features = s.features()
+	protoInfo, err := toProtoPluginInfo(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert plugin info to proto: %w", err)
+	}
+	return protoInfo, nil
+}
+
+// features liste les fonctionnalités du protocole que ce serveur sait servir
+// pour l'implémentation courante.
+func (s *NodeExecutorGRPCServer) features() []Feature {
+	features := []Feature{FeatureGetInfo, FeatureStats, FeatureConfigure, FeatureControl, FeatureCompression, FeatureZstd, FeatureOutputsDelta, FeatureProtoPayloads}
+	if _, ok := implementation[ItemStreamer](s.Impl); ok {
+		features = append(features, FeatureItemStreaming)
+	}
+	if _, ok := implementation[Preflighter](s.Impl); ok {
+		features = append(features, FeaturePreflight)
+	}
+	if _, ok := implementation[CostEstimator](s.Impl); ok {
+		features = append(features, FeatureCostEstimate)
+	}
+	if _, ok := implementation[SelfTester](s.Impl); ok {
+		features = append(features, FeatureSelfTest)
+	}
+	if _, ok := implementation[OptionsLister](s.Impl); ok {
+		features = append(features, FeatureListOptions)
+	}
+	if _, ok := implementation[SampleProvider](s.Impl); ok {
+		features = append(features, FeatureSampleOutput)
+	}
+	if _, ok := implementation[Poller](s.Impl); ok {
+		features = append(features, FeaturePoll)
+	}
+	if _, ok := implementation[Acknowledger](s.Impl); ok {
+		features = append(features, FeatureAck)
+	}
+	return features
+}
+
+// fromRPCError traduit l'absence d'une RPC optionnelle chez un plugin plus
+// ancien en errors.ErrUnsupported, un dépassement d'échéance en
+// *TimeoutError, et une erreur du plugin en *RemoteError.
+func fromRPCError(method string, err error) error {
+	switch status.Code(err) {
+	case codes.Unimplemented:
+		return fmt.Errorf("plugin does not implement %s: %w", method, errors.ErrUnsupported)
+	case codes.DeadlineExceeded:
+		return &TimeoutError{Method: method}
+	}
+	if re := remoteError(err); re != nil {
+		return re
+	}
+	return err
+}
+
+// withTimeout complète une *TimeoutError avec la durée accordée à l'appel.
+func withTimeout(err error, timeout time.Duration) error {
+	var te *TimeoutError
+	if errors.As(err, &te) {
+		te.Timeout = timeout
+	}
+	return err
 }
 
 // --- Implémentation du wrapper go-plugin ---
@@ -112,6 +616,11 @@ func (s *NodeExecutorGRPCServer) GetCapabilities(ctx context.Context, req *proto
 type NodeExecutorPlugin struct {
 	plugin.GRPCPlugin
 	Impl NodeExecutor
+	// ClientOptions paramètre le client renvoyé par GRPCClient (côté moteur).
+	ClientOptions ClientOptions
+	// ServerOptions paramètre le serveur enregistré par GRPCServer (côté
+	// plugin).
+	ServerOptions ServerOptions
 }
 
 func (p *NodeExecutorPlugin) Server(*plugin.MuxBroker) (interface{}, error) {
@@ -123,97 +632,238 @@ func (p *NodeExecutorPlugin) Client(*plugin.MuxBroker, *rpc.Client) (interface{}
 }
 
 func (p *NodeExecutorPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	proto.RegisterNodeExecutorServer(s, &NodeExecutorGRPCServer{Impl: p.Impl})
+	server, err := newNodeExecutorServer(p.Impl, p.ServerOptions, broker)
+	if err != nil {
+		return err
+	}
+	proto.RegisterNodeExecutorServer(s, server)
+	if p.ServerOptions.Debug {
+		proto.RegisterPluginDebugServer(s, &debugServer{server: server, options: p.ServerOptions})
+	}
+	// go-plugin enregistre déjà la réflexion sur ses propres serveurs.
+	if _, ok := s.GetServiceInfo()["grpc.reflection.v1alpha.ServerReflection"]; p.ServerOptions.Reflection && !ok {
+		reflection.Register(s)
+	}
 	return nil
 }
 
+// newNodeExecutorServer prépare le serveur de impl, sans l'enregistrer.
+func newNodeExecutorServer(impl NodeExecutor, opts ServerOptions, broker *plugin.GRPCBroker) (*NodeExecutorGRPCServer, error) {
+	server := &NodeExecutorGRPCServer{Impl: impl, options: opts, broker: broker}
+	server.stats.started = time.Now()
+	server.notices = make(noticeQueue, DefaultNoticeBuffer)
+	if r, ok := impl.(NotifierReceiver); ok {
+		r.SetNotifier(server.notices)
+	}
+	if ip, ok := impl.(InfoProvider); ok {
+		info, err := ip.GetInfo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin info: %w", err)
+		}
+		if info.Manifest.MaxConcurrency > 0 {
+			server.limit = newLimiter(info.Manifest.MaxConcurrency)
+		}
+	}
+	if opts.WireLog.Enabled {
+		if err := server.setWireLog(opts.WireLog); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Debug {
+		server.recorder = newRequestRecorder(opts.DebugHistory)
+	}
+	return server, nil
+}
+
 func (p *NodeExecutorPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &NodeExecutorGRPC{client: proto.NewNodeExecutorClient(c)}, nil
+	return newNodeExecutorClient(c, broker, p.ClientOptions, "")
+}
+
+// newNodeExecutorClient renvoie le client de l'exécuteur executor, vide
+// hors d'un plugin multiplexé.
+func newNodeExecutorClient(c *grpc.ClientConn, broker *plugin.GRPCBroker, opts ClientOptions, executor string) (*NodeExecutorGRPC, error) {
+	m := &NodeExecutorGRPC{
+		client:   proto.NewNodeExecutorClient(c),
+		opts:     opts,
+		limit:    newLimiter(opts.MaxConcurrency),
+		debug:    proto.NewPluginDebugClient(c),
+		executor: executor,
+	}
+	if opts.TokenStore != nil {
+		if err := m.attachTokenStore(broker, opts.TokenStore); err != nil {
+			return nil, fmt.Errorf("failed to attach token store: %w", err)
+		}
+	}
+	if opts.Artifacts != nil {
+		if err := m.attachArtifactStore(broker, opts.Artifacts); err != nil {
+			return nil, fmt.Errorf("failed to attach artifact store: %w", err)
+		}
+	}
+	if opts.Idempotency != nil {
+		if err := m.attachIdempotencyStore(broker, opts.Idempotency); err != nil {
+			return nil, fmt.Errorf("failed to attach idempotency store: %w", err)
+		}
+	}
+	return m, nil
 }
 
 // --- Fonctions de Conversion (Helpers) ---
 
-func toProtoExecuteRequest(node Node, ctx ExecutionContext) (*proto.ExecuteRequest, error) {
-	protoNode, err := toProtoNode(&node)
+// Les conversions des charges utiles prennent le codec des options ; nil
+// désigne JSONCodec.
+
+func toProtoExecuteRequest(c Codec, node Node, ctx ExecutionContext, maxDepth int) (*proto.ExecuteRequest, error) {
+	c = codecOrDefault(c)
+	protoNode, err := toProtoNode(c, &node, maxDepth)
 	if err != nil {
 		return nil, err
 	}
-	protoCtx, err := toProtoExecutionContext(&ctx)
+	protoCtx, err := toProtoExecutionContext(c, &ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &proto.ExecuteRequest{Node: protoNode, Context: protoCtx}, nil
+	return &proto.ExecuteRequest{Node: protoNode, Context: protoCtx, FormatVersion: FormatVersion}, nil
 }
 
-func fromProtoExecuteRequest(req *proto.ExecuteRequest) (Node, ExecutionContext, error) {
-	node, err := fromProtoNode(req.Node)
+func fromProtoExecuteRequest(c Codec, req *proto.ExecuteRequest, maxDepth int) (Node, ExecutionContext, error) {
+	c = codecOrDefault(c)
+	if _, err := wireFormat(req.FormatVersion); err != nil {
+		return Node{}, ExecutionContext{}, err
+	}
+	node, err := fromProtoNode(c, req.Node, maxDepth)
 	if err != nil {
 		return Node{}, ExecutionContext{}, err
 	}
-	execCtx, err := fromProtoExecutionContext(req.Context)
+	execCtx, err := fromProtoExecutionContext(c, req.Context)
 	if err != nil {
 		return Node{}, ExecutionContext{}, err
 	}
 	return node, execCtx, nil
 }
 
-func toProtoNode(node *Node) (*proto.Node, error) {
+// toProtoNode convertit l'arbre de nœuds itérativement, pour qu'un workflow
+// profondément imbriqué ne puisse pas épuiser la pile. maxDepth est le
+// réglage des options, zéro désignant DefaultMaxNodeDepth.
+func toProtoNode(c Codec, node *Node, maxDepth int) (*proto.Node, error) {
 	if node == nil {
 		return nil, nil
 	}
-	with, err := json.Marshal(node.With)
+	root, err := toProtoNodeFields(c, node)
 	if err != nil {
 		return nil, err
 	}
-
-	var doNodes []*proto.Node
-	for _, doNode := range node.Do {
-		pn, err := toProtoNode(doNode)
-		if err != nil {
+	type frame struct {
+		node  *Node
+		pNode *proto.Node
+		depth int
+	}
+	maxDepth = maxNodeDepth(maxDepth)
+	stack := []frame{{node, root, 1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(f.node.Do)+len(f.node.OnFailure) > 0 || f.node.Compensate != nil {
+			if err := checkDepth(f.node.ID, f.depth, maxDepth); err != nil {
+				return nil, err
+			}
+		}
+		convert := func(children []*Node) ([]*proto.Node, error) {
+			var out []*proto.Node
+			for _, child := range children {
+				pn, err := toProtoNodeFields(c, child)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, pn)
+				if child != nil {
+					stack = append(stack, frame{child, pn, f.depth + 1})
+				}
+			}
+			return out, nil
+		}
+		if f.pNode.Do, err = convert(f.node.Do); err != nil {
+			return nil, err
+		}
+		if f.pNode.OnFailure, err = convert(f.node.OnFailure); err != nil {
 			return nil, err
 		}
-		doNodes = append(doNodes, pn)
+		if f.node.Compensate != nil {
+			compensate, err := convert([]*Node{f.node.Compensate})
+			if err != nil {
+				return nil, err
+			}
+			f.pNode.Compensate = compensate[0]
+		}
 	}
+	return root, nil
+}
 
+// toProtoNodeFields convertit un nœud sans ses enfants.
+func toProtoNodeFields(c Codec, node *Node) (*proto.Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+	with, withHints, err := encodeValue(c, node.With)
+	if err != nil {
+		return nil, err
+	}
 	retries, err := json.Marshal(node.Retries)
 	if err != nil {
 		return nil, err
 	}
-
-	var onFailureNodes []*proto.Node
-	for _, failNode := range node.OnFailure {
-		pn, err := toProtoNode(failNode)
-		if err != nil {
+	var matrix []byte
+	if len(node.Matrix) > 0 {
+		if matrix, err = c.Marshal(node.Matrix); err != nil {
 			return nil, err
 		}
-		onFailureNodes = append(onFailureNodes, pn)
 	}
-
 	return &proto.Node{
 		Id:        node.ID,
 		Uses:      node.Uses,
 		With:      with,
 		Needs:     node.Needs,
-		Do:        doNodes,
 		Retries:   retries,
-		OnFailure: onFailureNodes,
+		WithHints: withHints,
+
+		TimeoutMillis: node.Timeout.Milliseconds(),
+		Labels:        node.Labels,
+		Annotations:   node.Annotations,
+		Priority:      int32(node.Priority),
+		Queue:         node.Queue,
+		Resources:     toProtoResources(node.Resources),
+		Matrix:        matrix,
+		Affinity:      node.Affinity,
+		Env:           node.Env,
+		Connection:    node.Connection,
 	}, nil
 }
 
-func toProtoExecutionContext(ctx *ExecutionContext) (*proto.ExecutionContext, error) {
-	triggerData, err := json.Marshal(ctx.TriggerData)
+func toProtoExecutionContext(c Codec, ctx *ExecutionContext) (*proto.ExecutionContext, error) {
+	triggerData, err := c.Marshal(ctx.TriggerData)
+	if err != nil {
+		return nil, err
+	}
+	nodeOutputs, nodeOutputsHints, err := encodeValue(c, ctx.NodeOutputs)
+	if err != nil {
+		return nil, err
+	}
+	currentItem, err := c.Marshal(ctx.CurrentItem)
+	if err != nil {
+		return nil, err
+	}
+	failureData, err := c.Marshal(ctx.FailureData)
 	if err != nil {
 		return nil, err
 	}
-	nodeOutputs, err := json.Marshal(ctx.NodeOutputs)
+	cassette, err := encodeCassette(ctx.Cassette)
 	if err != nil {
 		return nil, err
 	}
-	currentItem, err := json.Marshal(ctx.CurrentItem)
+	connection, err := toProtoConnection(ctx.Connection)
 	if err != nil {
 		return nil, err
 	}
-	failureData, err := json.Marshal(ctx.FailureData)
+	continuation, err := toProtoContinuation(c, ctx.Continuation)
 	if err != nil {
 		return nil, err
 	}
@@ -224,29 +874,131 @@ func toProtoExecutionContext(ctx *ExecutionContext) (*proto.ExecutionContext, er
 		Secrets:     ctx.Secrets,
 		CurrentItem: currentItem,
 		FailureData: failureData,
+		Cursor:      ctx.Cursor,
+
+		NodeOutputsHints: nodeOutputsHints,
+		Mode:             string(ctx.Mode),
+		Seed:             ctx.Seed,
+		ClockUnixMs:      unixMillis(ctx.Clock),
+		Cassette:         cassette,
+		Attempt:          int32(ctx.Attempt),
+		TenantID:         ctx.TenantID,
+		Identity:         toProtoIdentity(ctx.Identity),
+		Budget:           toProtoBudget(ctx.Budget),
+		Connection:       connection,
+		Attachments:      toProtoAttachments(ctx.Attachments),
+		IdempotencyKey:   ctx.IdempotencyKey,
+		Continuation:     continuation,
 	}, nil
 }
 
-func toProtoValue(v interface{}) ([]byte, error) {
-	return json.Marshal(v)
+func toProtoExecuteResponse(c Codec, res *Result, format uint32) (*proto.ExecuteResponse, error) {
+	value, hints, err := encodeValueFor(codecOrDefault(c), res.Value, format)
+	if err != nil {
+		return nil, err
+	}
+	cassette, err := encodeCassette(res.Cassette)
+	if err != nil {
+		return nil, err
+	}
+	continuation, err := toProtoScheduleContinuation(codecOrDefault(c), res.Continuation)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnits(res.Units); err != nil {
+		return nil, err
+	}
+	var units float64
+	if res.Units != nil {
+		units = *res.Units
+	}
+	var warnings []*proto.Warning
+	for _, w := range res.Warnings {
+		warnings = append(warnings, &proto.Warning{Code: w.Code, Message: w.Message})
+	}
+	return &proto.ExecuteResponse{
+		Result:      value,
+		NextCursor:  res.NextCursor,
+		Warnings:    warnings,
+		ContentType: res.ContentType,
+		OutputKind:  string(res.Kind),
+		ResultHints: hintsFor(format, hints),
+
+		FormatVersion: format,
+		Cassette:      cassette,
+		RateLimit:     toProtoRateLimit(res.RateLimit),
+		Units:         units,
+		UnitsSet:      res.Units != nil,
+		Continuation:  continuation,
+	}, nil
 }
 
-func fromProtoNode(pNode *proto.Node) (Node, error) {
+// fromProtoNode est l'inverse de toProtoNode, itératif lui aussi.
+func fromProtoNode(c Codec, pNode *proto.Node, maxDepth int) (Node, error) {
 	if pNode == nil {
 		return Node{}, nil
 	}
-	var with map[string]interface{}
-	if err := json.Unmarshal(pNode.With, &with); err != nil {
+	root, err := fromProtoNodeFields(c, pNode)
+	if err != nil {
 		return Node{}, err
 	}
-
-	var doNodes []*Node
-	for _, pDoNode := range pNode.Do {
-		dn, err := fromProtoNode(pDoNode)
-		if err != nil {
+	type frame struct {
+		pNode *proto.Node
+		node  *Node
+		depth int
+	}
+	maxDepth = maxNodeDepth(maxDepth)
+	stack := []frame{{pNode, &root, 1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(f.pNode.Do)+len(f.pNode.OnFailure) > 0 || f.pNode.Compensate != nil {
+			if err := checkDepth(f.pNode.Id, f.depth, maxDepth); err != nil {
+				return Node{}, err
+			}
+		}
+		convert := func(children []*proto.Node) ([]*Node, error) {
+			var out []*Node
+			for _, pChild := range children {
+				child, err := fromProtoNodeFields(c, pChild)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, &child)
+				if pChild != nil {
+					stack = append(stack, frame{pChild, &child, f.depth + 1})
+				}
+			}
+			return out, nil
+		}
+		if f.node.Do, err = convert(f.pNode.Do); err != nil {
+			return Node{}, err
+		}
+		if f.node.OnFailure, err = convert(f.pNode.OnFailure); err != nil {
 			return Node{}, err
 		}
-		doNodes = append(doNodes, &dn)
+		if f.pNode.Compensate != nil {
+			compensate, err := convert([]*proto.Node{f.pNode.Compensate})
+			if err != nil {
+				return Node{}, err
+			}
+			f.node.Compensate = compensate[0]
+		}
+	}
+	return root, nil
+}
+
+// fromProtoNodeFields convertit un nœud sans ses enfants.
+func fromProtoNodeFields(c Codec, pNode *proto.Node) (Node, error) {
+	if pNode == nil {
+		return Node{}, nil
+	}
+	var with map[string]interface{}
+	if err := c.Unmarshal(pNode.With, &with); err != nil {
+		return Node{}, err
+	}
+	if _, err := applyHints(with, pNode.WithHints); err != nil {
+		return Node{}, err
 	}
 
 	var retries *Retries
@@ -256,65 +1008,141 @@ func fromProtoNode(pNode *proto.Node) (Node, error) {
 		}
 	}
 
-	var onFailureNodes []*Node
-	for _, pFailNode := range pNode.OnFailure {
-		fn, err := fromProtoNode(pFailNode)
-		if err != nil {
+	var matrix map[string][]interface{}
+	if len(pNode.Matrix) > 0 {
+		if err := c.Unmarshal(pNode.Matrix, &matrix); err != nil {
 			return Node{}, err
 		}
-		onFailureNodes = append(onFailureNodes, &fn)
 	}
 
-	return Node{
-		ID:        pNode.Id,
-		Uses:      pNode.Uses,
-		With:      with,
-		Needs:     pNode.Needs,
-		Do:        doNodes,
-		Retries:   retries,
-		OnFailure: onFailureNodes,
-	}, nil
+	node := Node{
+		ID:          pNode.Id,
+		Uses:        pNode.Uses,
+		With:        with,
+		Needs:       pNode.Needs,
+		Retries:     retries,
+		Timeout:     time.Duration(pNode.TimeoutMillis) * time.Millisecond,
+		Labels:      pNode.Labels,
+		Annotations: pNode.Annotations,
+		Priority:    int(pNode.Priority),
+		Queue:       pNode.Queue,
+		Resources:   fromProtoResources(pNode.Resources),
+		Matrix:      matrix,
+		Affinity:    pNode.Affinity,
+		Env:         pNode.Env,
+		Connection:  pNode.Connection,
+	}
+	node.canonicalizeFields()
+	return node, nil
 }
 
-func fromProtoExecutionContext(pCtx *proto.ExecutionContext) (ExecutionContext, error) {
-	var triggerData, nodeOutputs, currentItem, failureData map[string]interface{}
+func fromProtoExecutionContext(c Codec, pCtx *proto.ExecutionContext) (ExecutionContext, error) {
+	if err := decompressContext(pCtx); err != nil {
+		return ExecutionContext{}, err
+	}
+	var triggerData, nodeOutputs, failureData map[string]interface{}
+	var currentItem interface{}
 	if len(pCtx.TriggerData) > 0 {
-		if err := json.Unmarshal(pCtx.TriggerData, &triggerData); err != nil {
+		if err := c.Unmarshal(pCtx.TriggerData, &triggerData); err != nil {
 			return ExecutionContext{}, err
 		}
 	}
 	if len(pCtx.NodeOutputs) > 0 {
-		if err := json.Unmarshal(pCtx.NodeOutputs, &nodeOutputs); err != nil {
+		if err := c.Unmarshal(pCtx.NodeOutputs, &nodeOutputs); err != nil {
+			return ExecutionContext{}, err
+		}
+		if _, err := applyHints(nodeOutputs, pCtx.NodeOutputsHints); err != nil {
 			return ExecutionContext{}, err
 		}
 	}
 	if len(pCtx.CurrentItem) > 0 {
-		if err := json.Unmarshal(pCtx.CurrentItem, &currentItem); err != nil {
+		if err := c.Unmarshal(pCtx.CurrentItem, &currentItem); err != nil {
 			return ExecutionContext{}, err
 		}
 	}
 	if len(pCtx.FailureData) > 0 {
-		if err := json.Unmarshal(pCtx.FailureData, &failureData); err != nil {
+		if err := c.Unmarshal(pCtx.FailureData, &failureData); err != nil {
 			return ExecutionContext{}, err
 		}
 	}
 
-	return ExecutionContext{
-		TriggerData: triggerData,
-		NodeOutputs: nodeOutputs,
-		Secrets:     pCtx.Secrets,
-		CurrentItem: currentItem,
-		FailureData: failureData,
-	}, nil
+	execCtx := ExecutionContext{
+		TriggerData:    triggerData,
+		NodeOutputs:    nodeOutputs,
+		Secrets:        pCtx.Secrets,
+		CurrentItem:    currentItem,
+		FailureData:    failureData,
+		Cursor:         pCtx.Cursor,
+		OutputRefs:     fromProtoOutputRefs(pCtx.NodeOutputRefs),
+		Mode:           ExecutionMode(pCtx.Mode),
+		Seed:           pCtx.Seed,
+		Clock:          fromUnixMillis(pCtx.ClockUnixMs),
+		Attempt:        int(pCtx.Attempt),
+		TenantID:       pCtx.TenantID,
+		Identity:       fromProtoIdentity(pCtx.Identity),
+		Attachments:    fromProtoAttachments(pCtx.Attachments),
+		IdempotencyKey: pCtx.IdempotencyKey,
+	}
+	var err error
+	if execCtx.Budget, err = fromProtoBudget(pCtx.Budget); err != nil {
+		return ExecutionContext{}, err
+	}
+	if execCtx.Connection, err = fromProtoConnection(pCtx.Connection); err != nil {
+		return ExecutionContext{}, err
+	}
+	if execCtx.Cassette, err = decodeCassette(pCtx.Cassette); err != nil {
+		return ExecutionContext{}, err
+	}
+	if execCtx.Continuation, err = fromProtoContinuation(c, pCtx.Continuation); err != nil {
+		return ExecutionContext{}, err
+	}
+	execCtx.Canonicalize()
+	return execCtx, nil
 }
 
-func fromProtoValue(b []byte) (interface{}, error) {
-	if len(b) == 0 {
-		return nil, nil
+func fromProtoExecuteResponse(c Codec, resp *proto.ExecuteResponse) (*Result, error) {
+	format, err := wireFormat(resp.FormatVersion)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeValue(codecOrDefault(c), resp.Result, hintsFor(format, resp.ResultHints))
+	if err != nil {
+		return nil, err
+	}
+	// Les octets d'un résultat binaire voyagent en base64 dans le JSON.
+	if s, ok := value.(string); ok && OutputKind(resp.OutputKind) == OutputBinary {
+		if value, err = base64.StdEncoding.DecodeString(s); err != nil {
+			return nil, err
+		}
+	}
+	var warnings []Warning
+	for _, w := range resp.Warnings {
+		warnings = append(warnings, Warning{Code: w.Code, Message: w.Message})
 	}
-	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
+	cassette, err := decodeCassette(resp.Cassette)
+	if err != nil {
+		return nil, err
+	}
+	continuation, err := fromProtoScheduleContinuation(codecOrDefault(c), resp.Continuation)
+	if err != nil {
 		return nil, err
 	}
-	return v, nil
+	var units *float64
+	if resp.UnitsSet {
+		units = &resp.Units
+		if err := checkUnits(units); err != nil {
+			return nil, err
+		}
+	}
+	return &Result{
+		Value:        value,
+		NextCursor:   resp.NextCursor,
+		Warnings:     warnings,
+		ContentType:  resp.ContentType,
+		Kind:         OutputKind(resp.OutputKind),
+		Cassette:     cassette,
+		RateLimit:    fromProtoRateLimit(resp.RateLimit),
+		Units:        units,
+		Continuation: continuation,
+	}, nil
 }
@@ -0,0 +1,288 @@
+// Package jsonwire définit la forme JSON des nœuds et contextes d'exécution
+// échangés avec les exécuteurs qui ne parlent pas gRPC (httpexec, wasm).
+// Chaque champ porte un nom JSON explicite : la forme ne dépend pas des noms
+// des champs Go de shared.Node et shared.ExecutionContext, et un champ
+// ajouté à ces derniers n'apparaît sur le fil qu'une fois ajouté ici.
+//
+// Version ne change que lorsque la signification d'un champ change ;
+// l'ajout d'un champ facultatif ne la modifie pas.
+package jsonwire
+
+import (
+	"time"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+	"github.com/orkestra-io/orkestra-shared/vcr"
+)
+
+// Version est la version de la forme JSON produite par ce paquet.
+const Version = 1
+
+// Request est la requête d'exécution.
+type Request struct {
+	Version int     `json:"version"`
+	Node    Node    `json:"node"`
+	Context Context `json:"context"`
+}
+
+// NewRequest renvoie la requête d'exécution de node dans ctx.
+func NewRequest(node shared.Node, ctx shared.ExecutionContext) Request {
+	return Request{Version: Version, Node: FromNode(node), Context: FromContext(ctx)}
+}
+
+// Node est la forme JSON de shared.Node.
+type Node struct {
+	ID          string                   `json:"id"`
+	Uses        string                   `json:"uses"`
+	With        map[string]interface{}   `json:"with,omitempty"`
+	Needs       []string                 `json:"needs,omitempty"`
+	Do          []Node                   `json:"do,omitempty"`
+	Retries     *shared.Retries          `json:"retries,omitempty"`
+	OnFailure   []Node                   `json:"onFailure,omitempty"`
+	TimeoutMs   int64                    `json:"timeoutMs,omitempty"`
+	Labels      map[string]string        `json:"labels,omitempty"`
+	Annotations map[string]string        `json:"annotations,omitempty"`
+	Priority    int                      `json:"priority,omitempty"`
+	Queue       string                   `json:"queue,omitempty"`
+	Resources   *shared.Resources        `json:"resources,omitempty"`
+	Matrix      map[string][]interface{} `json:"matrix,omitempty"`
+	Affinity    string                   `json:"affinity,omitempty"`
+	Env         map[string]string        `json:"env,omitempty"`
+	Connection  string                   `json:"connection,omitempty"`
+	Compensate  *Node                    `json:"compensate,omitempty"`
+}
+
+// FromNode convertit n et ses descendants.
+func FromNode(n shared.Node) Node {
+	w := Node{
+		ID:          n.ID,
+		Uses:        n.Uses,
+		With:        n.With,
+		Needs:       n.Needs,
+		Do:          fromNodes(n.Do),
+		Retries:     n.Retries,
+		OnFailure:   fromNodes(n.OnFailure),
+		TimeoutMs:   n.Timeout.Milliseconds(),
+		Labels:      n.Labels,
+		Annotations: n.Annotations,
+		Priority:    n.Priority,
+		Queue:       n.Queue,
+		Resources:   n.Resources,
+		Matrix:      n.Matrix,
+		Affinity:    n.Affinity,
+		Env:         n.Env,
+		Connection:  n.Connection,
+	}
+	if n.Compensate != nil {
+		c := FromNode(*n.Compensate)
+		w.Compensate = &c
+	}
+	return w
+}
+
+func fromNodes(nodes []*shared.Node) []Node {
+	var out []Node
+	for _, n := range nodes {
+		if n != nil {
+			out = append(out, FromNode(*n))
+		}
+	}
+	return out
+}
+
+// ToNode est l'inverse de FromNode.
+func (w Node) ToNode() shared.Node {
+	n := shared.Node{
+		ID:          w.ID,
+		Uses:        w.Uses,
+		With:        w.With,
+		Needs:       w.Needs,
+		Do:          toNodes(w.Do),
+		Retries:     w.Retries,
+		OnFailure:   toNodes(w.OnFailure),
+		Timeout:     time.Duration(w.TimeoutMs) * time.Millisecond,
+		Labels:      w.Labels,
+		Annotations: w.Annotations,
+		Priority:    w.Priority,
+		Queue:       w.Queue,
+		Resources:   w.Resources,
+		Matrix:      w.Matrix,
+		Affinity:    w.Affinity,
+		Env:         w.Env,
+		Connection:  w.Connection,
+	}
+	if w.Compensate != nil {
+		c := w.Compensate.ToNode()
+		n.Compensate = &c
+	}
+	n.Canonicalize()
+	return n
+}
+
+func toNodes(nodes []Node) []*shared.Node {
+	var out []*shared.Node
+	for _, w := range nodes {
+		n := w.ToNode()
+		out = append(out, &n)
+	}
+	return out
+}
+
+// Context est la forme JSON de shared.ExecutionContext. Les références de
+// sorties (OutputRefs) ne sont pas transmises : ces exécuteurs n'ont pas
+// accès au magasin d'artefacts du moteur.
+type Context struct {
+	TriggerData    map[string]interface{} `json:"triggerData,omitempty"`
+	NodeOutputs    map[string]interface{} `json:"nodeOutputs,omitempty"`
+	Secrets        map[string]string      `json:"secrets,omitempty"`
+	CurrentItem    interface{}            `json:"currentItem,omitempty"`
+	FailureData    map[string]interface{} `json:"failureData,omitempty"`
+	Cursor         string                 `json:"cursor,omitempty"`
+	DeadlineUnixMs int64                  `json:"deadlineUnixMs,omitempty"`
+	RunID          string                 `json:"runId,omitempty"`
+	RequestID      string                 `json:"requestId,omitempty"`
+	Attempt        int                    `json:"attempt,omitempty"`
+	TenantID       string                 `json:"tenantId,omitempty"`
+	Identity       *Identity              `json:"identity,omitempty"`
+	Budget         *Budget                `json:"budget,omitempty"`
+	Connection     *shared.Connection     `json:"connection,omitempty"`
+	Attachments    []Attachment           `json:"attachments,omitempty"`
+	IdempotencyKey string                 `json:"idempotencyKey,omitempty"`
+	Continuation   *Continuation          `json:"continuation,omitempty"`
+	Mode           string                 `json:"mode,omitempty"`
+	Seed           int64                  `json:"seed,omitempty"`
+	ClockUnixMs    int64                  `json:"clockUnixMs,omitempty"`
+	Cassette       *vcr.Cassette          `json:"cassette,omitempty"`
+}
+
+// Identity est la forme JSON de shared.Identity.
+type Identity struct {
+	Subject string   `json:"subject,omitempty"`
+	Scopes  []string `json:"scopes,omitempty"`
+}
+
+// Budget est la forme JSON de shared.Budget.
+type Budget struct {
+	UntilUnixMs int64         `json:"untilUnixMs,omitempty"`
+	Items       *int64        `json:"items,omitempty"`
+	Spend       *shared.Money `json:"spend,omitempty"`
+}
+
+// Attachment est la forme JSON de shared.Attachment ; Data, le contenu
+// transmis en ligne, y est encodé en base64.
+type Attachment struct {
+	Field       string `json:"field,omitempty"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	Ref         string `json:"ref,omitempty"`
+	Data        []byte `json:"data,omitempty"`
+}
+
+// Continuation est la forme JSON de shared.Continuation.
+type Continuation struct {
+	State           interface{} `json:"state,omitempty"`
+	Count           int         `json:"count"`
+	ScheduledUnixMs int64       `json:"scheduledUnixMs,omitempty"`
+}
+
+// FromContext convertit ctx.
+func FromContext(ctx shared.ExecutionContext) Context {
+	w := Context{
+		TriggerData:    ctx.TriggerData,
+		NodeOutputs:    ctx.NodeOutputs,
+		Secrets:        ctx.Secrets,
+		CurrentItem:    ctx.CurrentItem,
+		FailureData:    ctx.FailureData,
+		Cursor:         ctx.Cursor,
+		DeadlineUnixMs: unixMillis(ctx.Deadline),
+		RunID:          ctx.RunID,
+		RequestID:      ctx.RequestID,
+		Attempt:        ctx.Attempt,
+		TenantID:       ctx.TenantID,
+		Connection:     ctx.Connection,
+		IdempotencyKey: ctx.IdempotencyKey,
+		Mode:           string(ctx.Mode),
+		Seed:           ctx.Seed,
+		ClockUnixMs:    unixMillis(ctx.Clock),
+		Cassette:       ctx.Cassette,
+	}
+	if ctx.Identity.Subject != "" || len(ctx.Identity.Scopes) > 0 {
+		w.Identity = &Identity{Subject: ctx.Identity.Subject, Scopes: ctx.Identity.Scopes}
+	}
+	if b := ctx.Budget; b != nil {
+		w.Budget = &Budget{UntilUnixMs: unixMillis(b.Until), Items: b.Items, Spend: b.Spend}
+	}
+	for _, a := range ctx.Attachments {
+		w.Attachments = append(w.Attachments, Attachment{
+			Field:       a.Field,
+			Name:        a.Name,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Ref:         a.Ref,
+			Data:        a.Data,
+		})
+	}
+	if c := ctx.Continuation; c != nil {
+		w.Continuation = &Continuation{State: c.State, Count: c.Count, ScheduledUnixMs: unixMillis(c.ScheduledAt)}
+	}
+	return w
+}
+
+// ToContext est l'inverse de FromContext.
+func (w Context) ToContext() shared.ExecutionContext {
+	ctx := shared.ExecutionContext{
+		TriggerData:    w.TriggerData,
+		NodeOutputs:    w.NodeOutputs,
+		Secrets:        w.Secrets,
+		CurrentItem:    w.CurrentItem,
+		FailureData:    w.FailureData,
+		Cursor:         w.Cursor,
+		Deadline:       fromUnixMillis(w.DeadlineUnixMs),
+		RunID:          w.RunID,
+		RequestID:      w.RequestID,
+		Attempt:        w.Attempt,
+		TenantID:       w.TenantID,
+		Connection:     w.Connection,
+		IdempotencyKey: w.IdempotencyKey,
+		Mode:           shared.ExecutionMode(w.Mode),
+		Seed:           w.Seed,
+		Clock:          fromUnixMillis(w.ClockUnixMs),
+		Cassette:       w.Cassette,
+	}
+	if w.Identity != nil {
+		ctx.Identity = shared.Identity{Subject: w.Identity.Subject, Scopes: w.Identity.Scopes}
+	}
+	if b := w.Budget; b != nil {
+		ctx.Budget = &shared.Budget{Until: fromUnixMillis(b.UntilUnixMs), Items: b.Items, Spend: b.Spend}
+	}
+	for _, a := range w.Attachments {
+		ctx.Attachments = append(ctx.Attachments, shared.Attachment{
+			Field:       a.Field,
+			Name:        a.Name,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Ref:         a.Ref,
+			Data:        a.Data,
+		})
+	}
+	if c := w.Continuation; c != nil {
+		ctx.Continuation = &shared.Continuation{State: c.State, Count: c.Count, ScheduledAt: fromUnixMillis(c.ScheduledUnixMs)}
+	}
+	ctx.Canonicalize()
+	return ctx
+}
+
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromUnixMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
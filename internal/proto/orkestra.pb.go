@@ -0,0 +1,4515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0--rc2
+// source: internal/proto/orkestra.proto
+
+package proto
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Message vide pour les requêtes sans paramètres
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{0}
+}
+
+// Le contrat pour un nœud, optimisé pour la communication gRPC
+type Node struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Uses          string                 `protobuf:"bytes,2,opt,name=Uses,proto3" json:"Uses,omitempty"`
+	With          []byte                 `protobuf:"bytes,3,opt,name=With,proto3" json:"With,omitempty"` // Les paramètres, sérialisés en JSON
+	Needs         []string               `protobuf:"bytes,4,rep,name=Needs,proto3" json:"Needs,omitempty"`
+	Do            []*Node                `protobuf:"bytes,5,rep,name=Do,proto3" json:"Do,omitempty"`           // Pour les boucles, la récursion est gérée
+	Retries       []byte                 `protobuf:"bytes,6,opt,name=Retries,proto3" json:"Retries,omitempty"` // La structure Retries, sérialisée en JSON
+	OnFailure     []*Node                `protobuf:"bytes,7,rep,name=OnFailure,proto3" json:"OnFailure,omitempty"`
+	WithHints     []byte                 `protobuf:"bytes,8,opt,name=WithHints,proto3" json:"WithHints,omitempty"`                                                                                // Indications de type des valeurs de With (encodage v2)
+	TimeoutMillis int64                  `protobuf:"varint,9,opt,name=TimeoutMillis,proto3" json:"TimeoutMillis,omitempty"`                                                                       // Durée maximale d'exécution, 0 si non bornée
+	Labels        map[string]string      `protobuf:"bytes,10,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // Étiquettes libres (propriétaire, centre de coût, routage)
+	Annotations   map[string]string      `protobuf:"bytes,11,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Métadonnées d'outillage (position dans l'éditeur...)
+	Priority      int32                  `protobuf:"varint,12,opt,name=Priority,proto3" json:"Priority,omitempty"`                                                                                // Priorité d'ordonnancement, de -100 à 100
+	Queue         string                 `protobuf:"bytes,13,opt,name=Queue,proto3" json:"Queue,omitempty"`                                                                                       // File de travail dédiée, vide pour la file par défaut
+	Resources     *Resources             `protobuf:"bytes,14,opt,name=Resources,proto3" json:"Resources,omitempty"`                                                                               // Budget indicatif, absent si non renseigné
+	Matrix        []byte                 `protobuf:"bytes,15,opt,name=Matrix,proto3" json:"Matrix,omitempty"`                                                                                     // Les dimensions de la matrice, sérialisées en JSON
+	Affinity      string                 `protobuf:"bytes,16,opt,name=Affinity,proto3" json:"Affinity,omitempty"`                                                                                 // Clé de routage vers une instance fixe du plugin
+	Env           map[string]string      `protobuf:"bytes,17,rep,name=Env,proto3" json:"Env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                 // Variables d'environnement de l'exécution
+	Connection    string                 `protobuf:"bytes,18,opt,name=Connection,proto3" json:"Connection,omitempty"`                                                                             // Nom de la connexion utilisée, vide si aucune
+	Compensate    *Node                  `protobuf:"bytes,19,opt,name=Compensate,proto3" json:"Compensate,omitempty"`                                                                             // Action inverse exécutée au dénouement d'une saga
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Node) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Node) GetUses() string {
+	if x != nil {
+		return x.Uses
+	}
+	return ""
+}
+
+func (x *Node) GetWith() []byte {
+	if x != nil {
+		return x.With
+	}
+	return nil
+}
+
+func (x *Node) GetNeeds() []string {
+	if x != nil {
+		return x.Needs
+	}
+	return nil
+}
+
+func (x *Node) GetDo() []*Node {
+	if x != nil {
+		return x.Do
+	}
+	return nil
+}
+
+func (x *Node) GetRetries() []byte {
+	if x != nil {
+		return x.Retries
+	}
+	return nil
+}
+
+func (x *Node) GetOnFailure() []*Node {
+	if x != nil {
+		return x.OnFailure
+	}
+	return nil
+}
+
+func (x *Node) GetWithHints() []byte {
+	if x != nil {
+		return x.WithHints
+	}
+	return nil
+}
+
+func (x *Node) GetTimeoutMillis() int64 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Node) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Node) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Node) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *Node) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *Node) GetMatrix() []byte {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *Node) GetAffinity() string {
+	if x != nil {
+		return x.Affinity
+	}
+	return ""
+}
+
+func (x *Node) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Node) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *Node) GetCompensate() *Node {
+	if x != nil {
+		return x.Compensate
+	}
+	return nil
+}
+
+// Le budget de ressources indicatif d'un nœud
+type Resources struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cpu           float64                `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"` // En cœurs
+	MemoryMb      int64                  `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	Gpu           int32                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Resources) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *Resources) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *Resources) GetGpu() int32 {
+	if x != nil {
+		return x.Gpu
+	}
+	return 0
+}
+
+// Le contrat pour le contexte d'exécution
+type ExecutionContext struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TriggerData      []byte                 `protobuf:"bytes,1,opt,name=TriggerData,proto3" json:"TriggerData,omitempty"` // Sérialisé en JSON
+	NodeOutputs      []byte                 `protobuf:"bytes,2,opt,name=NodeOutputs,proto3" json:"NodeOutputs,omitempty"` // Sérialisé en JSON
+	Secrets          map[string]string      `protobuf:"bytes,3,rep,name=Secrets,proto3" json:"Secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CurrentItem      []byte                 `protobuf:"bytes,4,opt,name=CurrentItem,proto3" json:"CurrentItem,omitempty"`                                                                                 // Sérialisé en JSON
+	FailureData      []byte                 `protobuf:"bytes,5,opt,name=FailureData,proto3" json:"FailureData,omitempty"`                                                                                 // Sérialisé en JSON
+	Cursor           string                 `protobuf:"bytes,6,opt,name=Cursor,proto3" json:"Cursor,omitempty"`                                                                                           // Curseur de pagination renvoyé par l'appel précédent
+	NodeOutputsHints []byte                 `protobuf:"bytes,7,opt,name=NodeOutputsHints,proto3" json:"NodeOutputsHints,omitempty"`                                                                       // Indications de type des valeurs de NodeOutputs (encodage v2)
+	PayloadEncoding  string                 `protobuf:"bytes,8,opt,name=payload_encoding,json=payloadEncoding,proto3" json:"payload_encoding,omitempty"`                                                  // Compression de TriggerData et NodeOutputs : vide, gzip ou zstd
+	NodeOutputRefs   map[string]*OutputRef  `protobuf:"bytes,9,rep,name=NodeOutputRefs,proto3" json:"NodeOutputRefs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Sorties volumineuses, laissées dans le magasin d'artefacts
+	OutputsDelta     *OutputsDelta          `protobuf:"bytes,10,opt,name=OutputsDelta,proto3" json:"OutputsDelta,omitempty"`                                                                              // Présent si NodeOutputs ne porte que les changements depuis une base
+	Mode             string                 `protobuf:"bytes,11,opt,name=Mode,proto3" json:"Mode,omitempty"`                                                                                              // Mode d'exécution : vide, record ou replay
+	Seed             int64                  `protobuf:"varint,12,opt,name=Seed,proto3" json:"Seed,omitempty"`                                                                                             // Graine des nombres aléatoires en mode déterministe
+	ClockUnixMs      int64                  `protobuf:"varint,13,opt,name=ClockUnixMs,proto3" json:"ClockUnixMs,omitempty"`                                                                               // Horloge figée en mode déterministe, 0 si absente
+	Cassette         []byte                 `protobuf:"bytes,14,opt,name=Cassette,proto3" json:"Cassette,omitempty"`                                                                                      // Échanges HTTP à rejouer, sérialisés en JSON
+	Attempt          int32                  `protobuf:"varint,15,opt,name=Attempt,proto3" json:"Attempt,omitempty"`                                                                                       // Numéro de la tentative, à partir de 1 ; 0 si inconnu
+	TenantID         string                 `protobuf:"bytes,16,opt,name=TenantID,proto3" json:"TenantID,omitempty"`                                                                                      // Locataire pour le compte duquel le workflow s'exécute
+	Identity         *Identity              `protobuf:"bytes,17,opt,name=Identity,proto3" json:"Identity,omitempty"`                                                                                      // Utilisateur qui a déclenché le workflow
+	Budget           *Budget                `protobuf:"bytes,18,opt,name=Budget,proto3" json:"Budget,omitempty"`                                                                                          // Ressources restantes du workflow, absent si non borné
+	Connection       *Connection            `protobuf:"bytes,19,opt,name=Connection,proto3" json:"Connection,omitempty"`                                                                                  // Connexion résolue du nœud, absente si aucune
+	Attachments      []*Attachment          `protobuf:"bytes,20,rep,name=Attachments,proto3" json:"Attachments,omitempty"`                                                                                // Fichiers reçus par le déclencheur
+	IdempotencyKey   string                 `protobuf:"bytes,21,opt,name=IdempotencyKey,proto3" json:"IdempotencyKey,omitempty"`                                                                          // Clé stable d'une tentative à l'autre du même nœud
+	Continuation     *Continuation          `protobuf:"bytes,22,opt,name=Continuation,proto3" json:"Continuation,omitempty"`                                                                              // Reprise planifiée en cours, absente au premier appel
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExecutionContext) Reset() {
+	*x = ExecutionContext{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionContext) ProtoMessage() {}
+
+func (x *ExecutionContext) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionContext.ProtoReflect.Descriptor instead.
+func (*ExecutionContext) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExecutionContext) GetTriggerData() []byte {
+	if x != nil {
+		return x.TriggerData
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetNodeOutputs() []byte {
+	if x != nil {
+		return x.NodeOutputs
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetCurrentItem() []byte {
+	if x != nil {
+		return x.CurrentItem
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetFailureData() []byte {
+	if x != nil {
+		return x.FailureData
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ExecutionContext) GetNodeOutputsHints() []byte {
+	if x != nil {
+		return x.NodeOutputsHints
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetPayloadEncoding() string {
+	if x != nil {
+		return x.PayloadEncoding
+	}
+	return ""
+}
+
+func (x *ExecutionContext) GetNodeOutputRefs() map[string]*OutputRef {
+	if x != nil {
+		return x.NodeOutputRefs
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetOutputsDelta() *OutputsDelta {
+	if x != nil {
+		return x.OutputsDelta
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ExecutionContext) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *ExecutionContext) GetClockUnixMs() int64 {
+	if x != nil {
+		return x.ClockUnixMs
+	}
+	return 0
+}
+
+func (x *ExecutionContext) GetCassette() []byte {
+	if x != nil {
+		return x.Cassette
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ExecutionContext) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
+func (x *ExecutionContext) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetBudget() *Budget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetConnection() *Connection {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *ExecutionContext) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ExecutionContext) GetContinuation() *Continuation {
+	if x != nil {
+		return x.Continuation
+	}
+	return nil
+}
+
+// Un fichier reçu par le déclencheur, transmis en ligne ou par référence
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Ref           string                 `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`     // Référence dans le magasin d'artefacts, vide si en ligne
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`   // Contenu, vide si transmis par référence
+	Field         string                 `protobuf:"bytes,6,opt,name=field,proto3" json:"field,omitempty"` // Champ du formulaire multipart
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *Attachment) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Attachment) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+// Une connexion résolue ; ses secrets voyagent dans ExecutionContext.Secrets
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Integration   string                 `protobuf:"bytes,2,opt,name=integration,proto3" json:"integration,omitempty"`
+	Settings      []byte                 `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"` // Sérialisés en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Connection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Connection) GetIntegration() string {
+	if x != nil {
+		return x.Integration
+	}
+	return ""
+}
+
+func (x *Connection) GetSettings() []byte {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Les ressources restantes d'une exécution de workflow
+type Budget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UntilUnixMs   int64                  `protobuf:"varint,1,opt,name=until_unix_ms,json=untilUnixMs,proto3" json:"until_unix_ms,omitempty"` // Fin du temps alloué, 0 si non borné
+	ItemsLimited  bool                   `protobuf:"varint,2,opt,name=items_limited,json=itemsLimited,proto3" json:"items_limited,omitempty"`
+	Items         int64                  `protobuf:"varint,3,opt,name=items,proto3" json:"items,omitempty"`                                     // Éléments restants, si items_limited
+	SpendAmount   string                 `protobuf:"bytes,4,opt,name=spend_amount,json=spendAmount,proto3" json:"spend_amount,omitempty"`       // Dépense restante, décimale exacte
+	SpendCurrency string                 `protobuf:"bytes,5,opt,name=spend_currency,json=spendCurrency,proto3" json:"spend_currency,omitempty"` // Devise de la dépense, vide si non bornée
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Budget) Reset() {
+	*x = Budget{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Budget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Budget) ProtoMessage() {}
+
+func (x *Budget) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
+func (*Budget) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Budget) GetUntilUnixMs() int64 {
+	if x != nil {
+		return x.UntilUnixMs
+	}
+	return 0
+}
+
+func (x *Budget) GetItemsLimited() bool {
+	if x != nil {
+		return x.ItemsLimited
+	}
+	return false
+}
+
+func (x *Budget) GetItems() int64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *Budget) GetSpendAmount() string {
+	if x != nil {
+		return x.SpendAmount
+	}
+	return ""
+}
+
+func (x *Budget) GetSpendCurrency() string {
+	if x != nil {
+		return x.SpendCurrency
+	}
+	return ""
+}
+
+// L'utilisateur pour le compte duquel un workflow s'exécute
+type Identity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Identity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Identity) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+// Les changements de NodeOutputs par rapport à une base connue du plugin
+type OutputsDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`    // Session de la base, l'identifiant de l'exécution
+	Baseline      uint64                 `protobuf:"varint,2,opt,name=baseline,proto3" json:"baseline,omitempty"` // Séquence de la base, 0 si NodeOutputs est complet
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"` // Séquence de la nouvelle base
+	Removed       []string               `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`    // Sorties retirées depuis la base
+	Epoch         uint64                 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`       // Tirée au hasard à chaque ouverture de la session par le client
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputsDelta) Reset() {
+	*x = OutputsDelta{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputsDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputsDelta) ProtoMessage() {}
+
+func (x *OutputsDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputsDelta.ProtoReflect.Descriptor instead.
+func (*OutputsDelta) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OutputsDelta) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *OutputsDelta) GetBaseline() uint64 {
+	if x != nil {
+		return x.Baseline
+	}
+	return 0
+}
+
+func (x *OutputsDelta) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OutputsDelta) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *OutputsDelta) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Une sortie de nœud stockée dans le magasin d'artefacts du moteur
+type OutputRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`     // Adresse du contenu (sha256:...)
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`  // Taille de la sortie sérialisée
+	Hints         []byte                 `protobuf:"bytes,3,opt,name=hints,proto3" json:"hints,omitempty"` // Indications de type de la sortie (encodage v2)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputRef) Reset() {
+	*x = OutputRef{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputRef) ProtoMessage() {}
+
+func (x *OutputRef) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputRef.ProtoReflect.Descriptor instead.
+func (*OutputRef) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OutputRef) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *OutputRef) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *OutputRef) GetHints() []byte {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+// La requête pour exécuter un nœud
+type ExecuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Context       *ExecutionContext      `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"`
+	FormatVersion uint32                 `protobuf:"varint,3,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"` // Version du format des valeurs, 0 pour la version 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExecuteRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetContext() *ExecutionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetFormatVersion() uint32 {
+	if x != nil {
+		return x.FormatVersion
+	}
+	return 0
+}
+
+// La réponse de l'exécution d'un nœud
+type ExecuteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []byte                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`                           // Le résultat, sérialisé en JSON
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Non vide si d'autres pages sont disponibles
+	Warnings      []*Warning             `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`        // Type MIME du résultat, s'il est connu
+	OutputKind    string                 `protobuf:"bytes,5,opt,name=output_kind,json=outputKind,proto3" json:"output_kind,omitempty"`           // json, text, binary, table ou html
+	ResultHints   []byte                 `protobuf:"bytes,6,opt,name=result_hints,json=resultHints,proto3" json:"result_hints,omitempty"`        // Indications de type des valeurs du résultat (encodage v2)
+	FormatVersion uint32                 `protobuf:"varint,7,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"` // Version du format des valeurs, celle de la requête
+	Cassette      []byte                 `protobuf:"bytes,8,opt,name=cassette,proto3" json:"cassette,omitempty"`                                 // Échanges HTTP enregistrés en mode record, sérialisés en JSON
+	RateLimit     *RateLimit             `protobuf:"bytes,9,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`              // État du quota de l'API tierce, s'il est connu
+	Units         float64                `protobuf:"fixed64,10,opt,name=units,proto3" json:"units,omitempty"`                                    // Unités consommées par l'exécution, si units_set
+	Continuation  *ScheduleContinuation  `protobuf:"bytes,11,opt,name=continuation,proto3" json:"continuation,omitempty"`                        // Reprise demandée par le plugin, absente sinon
+	UnitsSet      bool                   `protobuf:"varint,12,opt,name=units_set,json=unitsSet,proto3" json:"units_set,omitempty"`               // Faux : une unité
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExecuteResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ExecuteResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExecuteResponse) GetOutputKind() string {
+	if x != nil {
+		return x.OutputKind
+	}
+	return ""
+}
+
+func (x *ExecuteResponse) GetResultHints() []byte {
+	if x != nil {
+		return x.ResultHints
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetFormatVersion() uint32 {
+	if x != nil {
+		return x.FormatVersion
+	}
+	return 0
+}
+
+func (x *ExecuteResponse) GetCassette() []byte {
+	if x != nil {
+		return x.Cassette
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetRateLimit() *RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetUnits() float64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *ExecuteResponse) GetContinuation() *ScheduleContinuation {
+	if x != nil {
+		return x.Continuation
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetUnitsSet() bool {
+	if x != nil {
+		return x.UnitsSet
+	}
+	return false
+}
+
+// La reprise d'un nœud demandée par le plugin
+type ScheduleContinuation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterMs       int64                  `protobuf:"varint,1,opt,name=after_ms,json=afterMs,proto3" json:"after_ms,omitempty"` // Délai avant la reprise
+	State         []byte                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                     // État à restituer, sérialisé en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleContinuation) Reset() {
+	*x = ScheduleContinuation{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleContinuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleContinuation) ProtoMessage() {}
+
+func (x *ScheduleContinuation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleContinuation.ProtoReflect.Descriptor instead.
+func (*ScheduleContinuation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScheduleContinuation) GetAfterMs() int64 {
+	if x != nil {
+		return x.AfterMs
+	}
+	return 0
+}
+
+func (x *ScheduleContinuation) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// La reprise en cours, transmise au plugin
+type Continuation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	State           []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`  // Sérialisé en JSON
+	Count           int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // Numéro de la reprise, à partir de 1
+	ScheduledUnixMs int64                  `protobuf:"varint,3,opt,name=scheduled_unix_ms,json=scheduledUnixMs,proto3" json:"scheduled_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Continuation) Reset() {
+	*x = Continuation{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Continuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Continuation) ProtoMessage() {}
+
+func (x *Continuation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Continuation.ProtoReflect.Descriptor instead.
+func (*Continuation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Continuation) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Continuation) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Continuation) GetScheduledUnixMs() int64 {
+	if x != nil {
+		return x.ScheduledUnixMs
+	}
+	return 0
+}
+
+// L'état du quota d'une API tierce rapporté par un plugin
+type RateLimit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int64                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Remaining     int64                  `protobuf:"varint,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	ResetUnixMs   int64                  `protobuf:"varint,3,opt,name=reset_unix_ms,json=resetUnixMs,proto3" json:"reset_unix_ms,omitempty"` // Fin de la fenêtre, 0 si inconnue
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimit) Reset() {
+	*x = RateLimit{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimit) ProtoMessage() {}
+
+func (x *RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimit.ProtoReflect.Descriptor instead.
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RateLimit) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RateLimit) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *RateLimit) GetResetUnixMs() int64 {
+	if x != nil {
+		return x.ResetUnixMs
+	}
+	return 0
+}
+
+// Un maillon de la chaîne d'une erreur renvoyée par un plugin
+type ErrorLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // Code enregistré de l'erreur, vide si inconnu
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorLink) Reset() {
+	*x = ErrorLink{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorLink) ProtoMessage() {}
+
+func (x *ErrorLink) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorLink.ProtoReflect.Descriptor instead.
+func (*ErrorLink) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ErrorLink) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorLink) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorLink) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// La chaîne d'une erreur (%w), transmise dans les détails du statut gRPC
+type ErrorChain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ErrorLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorChain) Reset() {
+	*x = ErrorChain{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorChain) ProtoMessage() {}
+
+func (x *ErrorChain) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorChain.ProtoReflect.Descriptor instead.
+func (*ErrorChain) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ErrorChain) GetLinks() []*ErrorLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Un avertissement non bloquant attaché à un résultat
+type Warning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Warning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Un élément produit par une capacité « source » en streaming
+type StreamItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          []byte                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`                            // L'élément, sérialisé en JSON
+	ItemHints     []byte                 `protobuf:"bytes,2,opt,name=item_hints,json=itemHints,proto3" json:"item_hints,omitempty"` // Indications de type des valeurs de l'élément (encodage v2)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamItem) Reset() {
+	*x = StreamItem{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamItem) ProtoMessage() {}
+
+func (x *StreamItem) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamItem.ProtoReflect.Descriptor instead.
+func (*StreamItem) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamItem) GetItem() []byte {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *StreamItem) GetItemHints() []byte {
+	if x != nil {
+		return x.ItemHints
+	}
+	return nil
+}
+
+// La requête de la fonction GetCapabilities. Compatible sur le fil avec
+// Empty, utilisé par les versions précédentes.
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IfNoneMatch   string                 `protobuf:"bytes,1,opt,name=if_none_match,json=ifNoneMatch,proto3" json:"if_none_match,omitempty"` // ETag connu du client
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetCapabilitiesRequest) GetIfNoneMatch() string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return ""
+}
+
+// La réponse de la fonction GetCapabilities
+type GetCapabilitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uses          []string               `protobuf:"bytes,1,rep,name=uses,proto3" json:"uses,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`                                   // Empreinte de la liste des capacités
+	NotModified   bool                   `protobuf:"varint,3,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"` // Vrai si etag correspond à if_none_match ; uses est alors vide
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesResponse) ProtoMessage() {}
+
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetCapabilitiesResponse) GetUses() []string {
+	if x != nil {
+		return x.Uses
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *GetCapabilitiesResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+// Les informations de build d'un plugin
+type PluginInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Version sémantique du plugin
+	GitCommit     string                 `protobuf:"bytes,3,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	SharedVersion string                 `protobuf:"bytes,4,opt,name=shared_version,json=sharedVersion,proto3" json:"shared_version,omitempty"` // Version de orkestra-shared utilisée à la compilation
+	Features      []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	Manifest      []byte                 `protobuf:"bytes,6,opt,name=manifest,proto3" json:"manifest,omitempty"` // Le Manifest, sérialisé en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginInfo) Reset() {
+	*x = PluginInfo{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfo) ProtoMessage() {}
+
+func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
+func (*PluginInfo) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PluginInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetSharedVersion() string {
+	if x != nil {
+		return x.SharedVersion
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetManifest() []byte {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+// La requête de vérification préalable d'un plugin
+type PreflightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       map[string]string      `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightRequest) Reset() {
+	*x = PreflightRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightRequest) ProtoMessage() {}
+
+func (x *PreflightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightRequest.ProtoReflect.Descriptor instead.
+func (*PreflightRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PreflightRequest) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// Le résultat d'une vérification préalable
+type PreflightCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // ok, warning ou error
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Remediation   string                 `protobuf:"bytes,4,opt,name=remediation,proto3" json:"remediation,omitempty"` // Action suggérée à l'opérateur
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightCheck) Reset() {
+	*x = PreflightCheck{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightCheck) ProtoMessage() {}
+
+func (x *PreflightCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightCheck.ProtoReflect.Descriptor instead.
+func (*PreflightCheck) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PreflightCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PreflightCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PreflightCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PreflightCheck) GetRemediation() string {
+	if x != nil {
+		return x.Remediation
+	}
+	return ""
+}
+
+func (x *PreflightCheck) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// La réponse de la fonction Preflight
+type PreflightResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checks        []*PreflightCheck      `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightResponse) Reset() {
+	*x = PreflightResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightResponse) ProtoMessage() {}
+
+func (x *PreflightResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightResponse.ProtoReflect.Descriptor instead.
+func (*PreflightResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PreflightResponse) GetChecks() []*PreflightCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+// La requête de la fonction SelfTest
+type SelfTestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"` // Vide pour tous les diagnostics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfTestRequest) Reset() {
+	*x = SelfTestRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestRequest) ProtoMessage() {}
+
+func (x *SelfTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestRequest.ProtoReflect.Descriptor instead.
+func (*SelfTestRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SelfTestRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// La requête de la fonction ListOptions
+type ListOptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uses          string                 `protobuf:"bytes,1,opt,name=uses,proto3" json:"uses,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`           // Champ de With dont les valeurs sont demandées
+	Connection    string                 `protobuf:"bytes,3,opt,name=connection,proto3" json:"connection,omitempty"` // Connexion désignée par le nœud en cours d'édition
+	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`           // Recherche saisie par l'utilisateur
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`    // 0 : au choix du plugin
+	Context       *ExecutionContext      `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"` // Locataire, secrets et connexion résolue
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOptionsRequest) Reset() {
+	*x = ListOptionsRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOptionsRequest) ProtoMessage() {}
+
+func (x *ListOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListOptionsRequest) GetUses() string {
+	if x != nil {
+		return x.Uses
+	}
+	return ""
+}
+
+func (x *ListOptionsRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ListOptionsRequest) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *ListOptionsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListOptionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOptionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOptionsRequest) GetContext() *ExecutionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// Une valeur proposée pour un champ
+type Option struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Option) Reset() {
+	*x = Option{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Option) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Option) ProtoMessage() {}
+
+func (x *Option) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Option.ProtoReflect.Descriptor instead.
+func (*Option) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Option) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Option) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Option) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// La réponse de la fonction ListOptions
+type ListOptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       []*Option              `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Vide pour la dernière page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOptionsResponse) Reset() {
+	*x = ListOptionsResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOptionsResponse) ProtoMessage() {}
+
+func (x *ListOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListOptionsResponse) GetOptions() []*Option {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListOptionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// La requête de la fonction SampleOutput
+type SampleOutputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Context       *ExecutionContext      `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"` // Locataire, secrets et connexion résolue
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SampleOutputRequest) Reset() {
+	*x = SampleOutputRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SampleOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SampleOutputRequest) ProtoMessage() {}
+
+func (x *SampleOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SampleOutputRequest.ProtoReflect.Descriptor instead.
+func (*SampleOutputRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SampleOutputRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *SampleOutputRequest) GetContext() *ExecutionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// La réponse de la fonction SampleOutput
+type SampleOutputResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Output        []byte                 `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // Sérialisée en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SampleOutputResponse) Reset() {
+	*x = SampleOutputResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SampleOutputResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SampleOutputResponse) ProtoMessage() {}
+
+func (x *SampleOutputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SampleOutputResponse.ProtoReflect.Descriptor instead.
+func (*SampleOutputResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SampleOutputResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+// La requête de la fonction Poll
+type PollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`       // Le déclencheur et sa configuration
+	Context       *ExecutionContext      `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"` // Locataire, secrets et connexion résolue
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`   // Vide pour le premier relevé
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollRequest) Reset() {
+	*x = PollRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollRequest) ProtoMessage() {}
+
+func (x *PollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollRequest.ProtoReflect.Descriptor instead.
+func (*PollRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PollRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *PollRequest) GetContext() *ExecutionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PollRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Un événement relevé par un déclencheur
+type PollEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // Sérialisé en JSON
+	TimeUnixMs    int64                  `protobuf:"varint,3,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollEvent) Reset() {
+	*x = PollEvent{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollEvent) ProtoMessage() {}
+
+func (x *PollEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollEvent.ProtoReflect.Descriptor instead.
+func (*PollEvent) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PollEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PollEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PollEvent) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+// La réponse de la fonction Poll
+type PollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*PollEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	IntervalMs    int64                  `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"` // Délai avant le prochain relevé, 0 par défaut
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollResponse) Reset() {
+	*x = PollResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollResponse) ProtoMessage() {}
+
+func (x *PollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollResponse.ProtoReflect.Descriptor instead.
+func (*PollResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PollResponse) GetEvents() []*PollEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *PollResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *PollResponse) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+// La requête des fonctions Ack et Nack
+type AckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"` // Le déclencheur dont les événements sont confirmés
+	Context       *ExecutionContext      `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckRequest) Reset() {
+	*x = AckRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckRequest) ProtoMessage() {}
+
+func (x *AckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckRequest.ProtoReflect.Descriptor instead.
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AckRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *AckRequest) GetContext() *ExecutionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *AckRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// La requête de la fonction EstimateCost
+type EstimateCostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateCostRequest) Reset() {
+	*x = EstimateCostRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateCostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateCostRequest) ProtoMessage() {}
+
+func (x *EstimateCostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateCostRequest.ProtoReflect.Descriptor instead.
+func (*EstimateCostRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EstimateCostRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// Le coût attendu de l'exécution d'un nœud
+type CostEstimate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Credits       float64                `protobuf:"fixed64,1,opt,name=credits,proto3" json:"credits,omitempty"` // Crédits d'API consommés
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`     // Montant décimal exact, vide si inconnu
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"` // Devise ISO 4217 du montant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostEstimate) Reset() {
+	*x = CostEstimate{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostEstimate) ProtoMessage() {}
+
+func (x *CostEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostEstimate.ProtoReflect.Descriptor instead.
+func (*CostEstimate) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CostEstimate) GetCredits() float64 {
+	if x != nil {
+		return x.Credits
+	}
+	return 0
+}
+
+func (x *CostEstimate) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CostEstimate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Les statistiques d'utilisation des ressources d'un plugin
+type PluginStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	HeapBytes       uint64                 `protobuf:"varint,1,opt,name=heap_bytes,json=heapBytes,proto3" json:"heap_bytes,omitempty"`
+	SysBytes        uint64                 `protobuf:"varint,2,opt,name=sys_bytes,json=sysBytes,proto3" json:"sys_bytes,omitempty"`
+	Goroutines      int32                  `protobuf:"varint,3,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	OpenConnections int32                  `protobuf:"varint,4,opt,name=open_connections,json=openConnections,proto3" json:"open_connections,omitempty"`
+	Calls           int64                  `protobuf:"varint,5,opt,name=calls,proto3" json:"calls,omitempty"`                       // Exécutions depuis le démarrage
+	Errors          int64                  `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`                     // Exécutions en erreur depuis le démarrage
+	InFlight        int64                  `protobuf:"varint,7,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"` // Exécutions en cours
+	UptimeMs        int64                  `protobuf:"varint,8,opt,name=uptime_ms,json=uptimeMs,proto3" json:"uptime_ms,omitempty"`
+	Custom          map[string]float64     `protobuf:"bytes,9,rep,name=custom,proto3" json:"custom,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Compteurs propres au plugin
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PluginStats) Reset() {
+	*x = PluginStats{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginStats) ProtoMessage() {}
+
+func (x *PluginStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginStats.ProtoReflect.Descriptor instead.
+func (*PluginStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PluginStats) GetHeapBytes() uint64 {
+	if x != nil {
+		return x.HeapBytes
+	}
+	return 0
+}
+
+func (x *PluginStats) GetSysBytes() uint64 {
+	if x != nil {
+		return x.SysBytes
+	}
+	return 0
+}
+
+func (x *PluginStats) GetGoroutines() int32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *PluginStats) GetOpenConnections() int32 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *PluginStats) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *PluginStats) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *PluginStats) GetInFlight() int64 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *PluginStats) GetUptimeMs() int64 {
+	if x != nil {
+		return x.UptimeMs
+	}
+	return 0
+}
+
+func (x *PluginStats) GetCustom() map[string]float64 {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+// Une exécution récente, conservée par le service de débogage
+type DebugRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Uses          string                 `protobuf:"bytes,2,opt,name=uses,proto3" json:"uses,omitempty"`
+	StartedUnixMs int64                  `protobuf:"varint,3,opt,name=started_unix_ms,json=startedUnixMs,proto3" json:"started_unix_ms,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Node          []byte                 `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"` // Le nœud, sérialisé en JSON et tronqué
+	Truncated     bool                   `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugRequest) Reset() {
+	*x = DebugRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugRequest) ProtoMessage() {}
+
+func (x *DebugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugRequest.ProtoReflect.Descriptor instead.
+func (*DebugRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DebugRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DebugRequest) GetUses() string {
+	if x != nil {
+		return x.Uses
+	}
+	return ""
+}
+
+func (x *DebugRequest) GetStartedUnixMs() int64 {
+	if x != nil {
+		return x.StartedUnixMs
+	}
+	return 0
+}
+
+func (x *DebugRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *DebugRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DebugRequest) GetNode() []byte {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *DebugRequest) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type RecentRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 0 pour toutes les exécutions conservées
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentRequestsRequest) Reset() {
+	*x = RecentRequestsRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentRequestsRequest) ProtoMessage() {}
+
+func (x *RecentRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentRequestsRequest.ProtoReflect.Descriptor instead.
+func (*RecentRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RecentRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecentRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*DebugRequest        `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentRequestsResponse) Reset() {
+	*x = RecentRequestsResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentRequestsResponse) ProtoMessage() {}
+
+func (x *RecentRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentRequestsResponse.ProtoReflect.Descriptor instead.
+func (*RecentRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RecentRequestsResponse) GetRequests() []*DebugRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// La configuration courante d'un plugin
+type DebugConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        []byte                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"` // Sérialisée en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugConfig) Reset() {
+	*x = DebugConfig{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugConfig) ProtoMessage() {}
+
+func (x *DebugConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugConfig.ProtoReflect.Descriptor instead.
+func (*DebugConfig) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DebugConfig) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// La configuration de la journalisation des échanges
+type WireLogConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	MaxFileBytes  int64                  `protobuf:"varint,3,opt,name=max_file_bytes,json=maxFileBytes,proto3" json:"max_file_bytes,omitempty"`
+	MaxFiles      int32                  `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxEntryBytes int32                  `protobuf:"varint,5,opt,name=max_entry_bytes,json=maxEntryBytes,proto3" json:"max_entry_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WireLogConfig) Reset() {
+	*x = WireLogConfig{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WireLogConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireLogConfig) ProtoMessage() {}
+
+func (x *WireLogConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireLogConfig.ProtoReflect.Descriptor instead.
+func (*WireLogConfig) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WireLogConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *WireLogConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WireLogConfig) GetMaxFileBytes() int64 {
+	if x != nil {
+		return x.MaxFileBytes
+	}
+	return 0
+}
+
+func (x *WireLogConfig) GetMaxFiles() int32 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *WireLogConfig) GetMaxEntryBytes() int32 {
+	if x != nil {
+		return x.MaxEntryBytes
+	}
+	return 0
+}
+
+// La requête de la fonction Configure. Les champs absents sont inchangés.
+type ConfigureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WireLog       *WireLogConfig         `protobuf:"bytes,1,opt,name=wire_log,json=wireLog,proto3" json:"wire_log,omitempty"`
+	Settings      []byte                 `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"` // Réglages globaux du plugin, sérialisés en JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigureRequest) Reset() {
+	*x = ConfigureRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigureRequest) ProtoMessage() {}
+
+func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigureRequest.ProtoReflect.Descriptor instead.
+func (*ConfigureRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ConfigureRequest) GetWireLog() *WireLogConfig {
+	if x != nil {
+		return x.WireLog
+	}
+	return nil
+}
+
+func (x *ConfigureRequest) GetSettings() []byte {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Les piles de toutes les goroutines d'un plugin
+type GoroutineDump struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dump          []byte                 `protobuf:"bytes,1,opt,name=dump,proto3" json:"dump,omitempty"` // Format de runtime/pprof (debug=2)
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoroutineDump) Reset() {
+	*x = GoroutineDump{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoroutineDump) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoroutineDump) ProtoMessage() {}
+
+func (x *GoroutineDump) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoroutineDump.ProtoReflect.Descriptor instead.
+func (*GoroutineDump) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GoroutineDump) GetDump() []byte {
+	if x != nil {
+		return x.Dump
+	}
+	return nil
+}
+
+func (x *GoroutineDump) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// Un jeton OAuth2 conservé par le moteur
+type OAuth2Token struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	TokenType     string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	ExpiryUnixMs  int64                  `protobuf:"varint,4,opt,name=expiry_unix_ms,json=expiryUnixMs,proto3" json:"expiry_unix_ms,omitempty"` // 0 si le jeton n'expire pas
+	Scopes        []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2Token) Reset() {
+	*x = OAuth2Token{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2Token) ProtoMessage() {}
+
+func (x *OAuth2Token) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2Token.ProtoReflect.Descriptor instead.
+func (*OAuth2Token) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *OAuth2Token) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *OAuth2Token) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *OAuth2Token) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *OAuth2Token) GetExpiryUnixMs() int64 {
+	if x != nil {
+		return x.ExpiryUnixMs
+	}
+	return 0
+}
+
+func (x *OAuth2Token) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type TokenKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenKey) Reset() {
+	*x = TokenKey{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenKey) ProtoMessage() {}
+
+func (x *TokenKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenKey.ProtoReflect.Descriptor instead.
+func (*TokenKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TokenKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type PutTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Token         *OAuth2Token           `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutTokenRequest) Reset() {
+	*x = PutTokenRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutTokenRequest) ProtoMessage() {}
+
+func (x *PutTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutTokenRequest.ProtoReflect.Descriptor instead.
+func (*PutTokenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PutTokenRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PutTokenRequest) GetToken() *OAuth2Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+// La requête de la fonction SetTokenStore
+type SetTokenStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrokerId      uint32                 `protobuf:"varint,1,opt,name=broker_id,json=brokerId,proto3" json:"broker_id,omitempty"` // Identifiant du service TokenStore sur le broker go-plugin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenStoreRequest) Reset() {
+	*x = SetTokenStoreRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenStoreRequest) ProtoMessage() {}
+
+func (x *SetTokenStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenStoreRequest.ProtoReflect.Descriptor instead.
+func (*SetTokenStoreRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetTokenStoreRequest) GetBrokerId() uint32 {
+	if x != nil {
+		return x.BrokerId
+	}
+	return 0
+}
+
+// La requête de la fonction SetArtifactStore
+type SetArtifactStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrokerId      uint32                 `protobuf:"varint,1,opt,name=broker_id,json=brokerId,proto3" json:"broker_id,omitempty"` // Identifiant du service ArtifactStore sur le broker go-plugin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetArtifactStoreRequest) Reset() {
+	*x = SetArtifactStoreRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetArtifactStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetArtifactStoreRequest) ProtoMessage() {}
+
+func (x *SetArtifactStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetArtifactStoreRequest.ProtoReflect.Descriptor instead.
+func (*SetArtifactStoreRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetArtifactStoreRequest) GetBrokerId() uint32 {
+	if x != nil {
+		return x.BrokerId
+	}
+	return 0
+}
+
+// La requête de la fonction SetIdempotencyStore
+type SetIdempotencyStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrokerId      uint32                 `protobuf:"varint,1,opt,name=broker_id,json=brokerId,proto3" json:"broker_id,omitempty"` // Identifiant du service IdempotencyStore sur le broker go-plugin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIdempotencyStoreRequest) Reset() {
+	*x = SetIdempotencyStoreRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIdempotencyStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIdempotencyStoreRequest) ProtoMessage() {}
+
+func (x *SetIdempotencyStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIdempotencyStoreRequest.ProtoReflect.Descriptor instead.
+func (*SetIdempotencyStoreRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetIdempotencyStoreRequest) GetBrokerId() uint32 {
+	if x != nil {
+		return x.BrokerId
+	}
+	return 0
+}
+
+// Un effet de bord enregistré sous sa clé d'idempotence
+type IdempotencyRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`    // Faux tant que l'effet est en cours
+	Result        []byte                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"` // Sérialisé en JSON, une fois l'effet terminé
+	CreatedUnixMs int64                  `protobuf:"varint,4,opt,name=created_unix_ms,json=createdUnixMs,proto3" json:"created_unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdempotencyRecord) Reset() {
+	*x = IdempotencyRecord{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdempotencyRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdempotencyRecord) ProtoMessage() {}
+
+func (x *IdempotencyRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdempotencyRecord.ProtoReflect.Descriptor instead.
+func (*IdempotencyRecord) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *IdempotencyRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IdempotencyRecord) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *IdempotencyRecord) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *IdempotencyRecord) GetCreatedUnixMs() int64 {
+	if x != nil {
+		return x.CreatedUnixMs
+	}
+	return 0
+}
+
+type ClaimRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	LeaseMs       int64                  `protobuf:"varint,2,opt,name=lease_ms,json=leaseMs,proto3" json:"lease_ms,omitempty"` // Durée de la réservation si l'effet ne se termine pas
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimRequest) Reset() {
+	*x = ClaimRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRequest) ProtoMessage() {}
+
+func (x *ClaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRequest.ProtoReflect.Descriptor instead.
+func (*ClaimRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ClaimRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetLeaseMs() int64 {
+	if x != nil {
+		return x.LeaseMs
+	}
+	return 0
+}
+
+type ClaimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Claimed       bool                   `protobuf:"varint,1,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	Record        *IdempotencyRecord     `protobuf:"bytes,2,opt,name=record,proto3" json:"record,omitempty"` // Enregistrement existant si claimed est faux
+	Token         uint64                 `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`  // Jeton de la réservation si claimed est vrai
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimResponse) Reset() {
+	*x = ClaimResponse{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimResponse) ProtoMessage() {}
+
+func (x *ClaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimResponse.ProtoReflect.Descriptor instead.
+func (*ClaimResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ClaimResponse) GetClaimed() bool {
+	if x != nil {
+		return x.Claimed
+	}
+	return false
+}
+
+func (x *ClaimResponse) GetRecord() *IdempotencyRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ClaimResponse) GetToken() uint64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+type CompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Result        []byte                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,3,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	Token         uint64                 `protobuf:"varint,4,opt,name=token,proto3" json:"token,omitempty"` // Jeton renvoyé par Claim
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteRequest) Reset() {
+	*x = CompleteRequest{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteRequest) ProtoMessage() {}
+
+func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteRequest.ProtoReflect.Descriptor instead.
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CompleteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CompleteRequest) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CompleteRequest) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *CompleteRequest) GetToken() uint64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+type IdempotencyKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Token         uint64                 `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"` // Jeton renvoyé par Claim
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdempotencyKey) Reset() {
+	*x = IdempotencyKey{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdempotencyKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdempotencyKey) ProtoMessage() {}
+
+func (x *IdempotencyKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdempotencyKey.ProtoReflect.Descriptor instead.
+func (*IdempotencyKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *IdempotencyKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IdempotencyKey) GetToken() uint64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+type ArtifactRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtifactRef) Reset() {
+	*x = ArtifactRef{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtifactRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactRef) ProtoMessage() {}
+
+func (x *ArtifactRef) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactRef.ProtoReflect.Descriptor instead.
+func (*ArtifactRef) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ArtifactRef) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+// Un fragment d'artefact ; les artefacts circulent par fragments pour
+// rester sous la taille maximale des messages gRPC
+type ArtifactChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtifactChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactChunk) ProtoMessage() {}
+
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Un message poussé par le moteur sur le flux de contrôle
+type ControlMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Configure     *ConfigureRequest      `protobuf:"bytes,1,opt,name=configure,proto3" json:"configure,omitempty"`   // Configuration à appliquer, absente sinon
+	Invalidate    []string               `protobuf:"bytes,2,rep,name=invalidate,proto3" json:"invalidate,omitempty"` // Clés de cache à oublier
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlMessage) Reset() {
+	*x = ControlMessage{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlMessage) ProtoMessage() {}
+
+func (x *ControlMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlMessage.ProtoReflect.Descriptor instead.
+func (*ControlMessage) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ControlMessage) GetConfigure() *ConfigureRequest {
+	if x != nil {
+		return x.Configure
+	}
+	return nil
+}
+
+func (x *ControlMessage) GetInvalidate() []string {
+	if x != nil {
+		return x.Invalidate
+	}
+	return nil
+}
+
+// Un avis envoyé spontanément par le plugin sur le flux de contrôle
+type PluginNotice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`         // quota-warning, credential-expiry, degraded...
+	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"` // info, warning ou error
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TimeUnixMs    int64                  `protobuf:"varint,5,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginNotice) Reset() {
+	*x = PluginNotice{}
+	mi := &file_internal_proto_orkestra_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginNotice) ProtoMessage() {}
+
+func (x *PluginNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_orkestra_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginNotice.ProtoReflect.Descriptor instead.
+func (*PluginNotice) Descriptor() ([]byte, []int) {
+	return file_internal_proto_orkestra_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *PluginNotice) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PluginNotice) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *PluginNotice) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PluginNotice) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *PluginNotice) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+var File_internal_proto_orkestra_proto protoreflect.FileDescriptor
+
+const file_internal_proto_orkestra_proto_rawDesc = "" +
+	"\n" +
+	"\x1dinternal/proto/orkestra.proto\x12\x05proto\"\a\n" +
+	"\x05Empty\"\xa9\x06\n" +
+	"\x04Node\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04Uses\x18\x02 \x01(\tR\x04Uses\x12\x12\n" +
+	"\x04With\x18\x03 \x01(\fR\x04With\x12\x14\n" +
+	"\x05Needs\x18\x04 \x03(\tR\x05Needs\x12\x1b\n" +
+	"\x02Do\x18\x05 \x03(\v2\v.proto.NodeR\x02Do\x12\x18\n" +
+	"\aRetries\x18\x06 \x01(\fR\aRetries\x12)\n" +
+	"\tOnFailure\x18\a \x03(\v2\v.proto.NodeR\tOnFailure\x12\x1c\n" +
+	"\tWithHints\x18\b \x01(\fR\tWithHints\x12$\n" +
+	"\rTimeoutMillis\x18\t \x01(\x03R\rTimeoutMillis\x12/\n" +
+	"\x06Labels\x18\n" +
+	" \x03(\v2\x17.proto.Node.LabelsEntryR\x06Labels\x12>\n" +
+	"\vAnnotations\x18\v \x03(\v2\x1c.proto.Node.AnnotationsEntryR\vAnnotations\x12\x1a\n" +
+	"\bPriority\x18\f \x01(\x05R\bPriority\x12\x14\n" +
+	"\x05Queue\x18\r \x01(\tR\x05Queue\x12.\n" +
+	"\tResources\x18\x0e \x01(\v2\x10.proto.ResourcesR\tResources\x12\x16\n" +
+	"\x06Matrix\x18\x0f \x01(\fR\x06Matrix\x12\x1a\n" +
+	"\bAffinity\x18\x10 \x01(\tR\bAffinity\x12&\n" +
+	"\x03Env\x18\x11 \x03(\v2\x14.proto.Node.EnvEntryR\x03Env\x12\x1e\n" +
+	"\n" +
+	"Connection\x18\x12 \x01(\tR\n" +
+	"Connection\x12+\n" +
+	"\n" +
+	"Compensate\x18\x13 \x01(\v2\v.proto.NodeR\n" +
+	"Compensate\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
+	"\tResources\x12\x10\n" +
+	"\x03cpu\x18\x01 \x01(\x01R\x03cpu\x12\x1b\n" +
+	"\tmemory_mb\x18\x02 \x01(\x03R\bmemoryMb\x12\x10\n" +
+	"\x03gpu\x18\x03 \x01(\x05R\x03gpu\"\xa1\b\n" +
+	"\x10ExecutionContext\x12 \n" +
+	"\vTriggerData\x18\x01 \x01(\fR\vTriggerData\x12 \n" +
+	"\vNodeOutputs\x18\x02 \x01(\fR\vNodeOutputs\x12>\n" +
+	"\aSecrets\x18\x03 \x03(\v2$.proto.ExecutionContext.SecretsEntryR\aSecrets\x12 \n" +
+	"\vCurrentItem\x18\x04 \x01(\fR\vCurrentItem\x12 \n" +
+	"\vFailureData\x18\x05 \x01(\fR\vFailureData\x12\x16\n" +
+	"\x06Cursor\x18\x06 \x01(\tR\x06Cursor\x12*\n" +
+	"\x10NodeOutputsHints\x18\a \x01(\fR\x10NodeOutputsHints\x12)\n" +
+	"\x10payload_encoding\x18\b \x01(\tR\x0fpayloadEncoding\x12S\n" +
+	"\x0eNodeOutputRefs\x18\t \x03(\v2+.proto.ExecutionContext.NodeOutputRefsEntryR\x0eNodeOutputRefs\x127\n" +
+	"\fOutputsDelta\x18\n" +
+	" \x01(\v2\x13.proto.OutputsDeltaR\fOutputsDelta\x12\x12\n" +
+	"\x04Mode\x18\v \x01(\tR\x04Mode\x12\x12\n" +
+	"\x04Seed\x18\f \x01(\x03R\x04Seed\x12 \n" +
+	"\vClockUnixMs\x18\r \x01(\x03R\vClockUnixMs\x12\x1a\n" +
+	"\bCassette\x18\x0e \x01(\fR\bCassette\x12\x18\n" +
+	"\aAttempt\x18\x0f \x01(\x05R\aAttempt\x12\x1a\n" +
+	"\bTenantID\x18\x10 \x01(\tR\bTenantID\x12+\n" +
+	"\bIdentity\x18\x11 \x01(\v2\x0f.proto.IdentityR\bIdentity\x12%\n" +
+	"\x06Budget\x18\x12 \x01(\v2\r.proto.BudgetR\x06Budget\x121\n" +
+	"\n" +
+	"Connection\x18\x13 \x01(\v2\x11.proto.ConnectionR\n" +
+	"Connection\x123\n" +
+	"\vAttachments\x18\x14 \x03(\v2\x11.proto.AttachmentR\vAttachments\x12&\n" +
+	"\x0eIdempotencyKey\x18\x15 \x01(\tR\x0eIdempotencyKey\x127\n" +
+	"\fContinuation\x18\x16 \x01(\v2\x13.proto.ContinuationR\fContinuation\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aS\n" +
+	"\x13NodeOutputRefsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.proto.OutputRefR\x05value:\x028\x01\"\x93\x01\n" +
+	"\n" +
+	"Attachment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x10\n" +
+	"\x03ref\x18\x04 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x14\n" +
+	"\x05field\x18\x06 \x01(\tR\x05field\"^\n" +
+	"\n" +
+	"Connection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vintegration\x18\x02 \x01(\tR\vintegration\x12\x1a\n" +
+	"\bsettings\x18\x03 \x01(\fR\bsettings\"\xb1\x01\n" +
+	"\x06Budget\x12\"\n" +
+	"\runtil_unix_ms\x18\x01 \x01(\x03R\vuntilUnixMs\x12#\n" +
+	"\ritems_limited\x18\x02 \x01(\bR\fitemsLimited\x12\x14\n" +
+	"\x05items\x18\x03 \x01(\x03R\x05items\x12!\n" +
+	"\fspend_amount\x18\x04 \x01(\tR\vspendAmount\x12%\n" +
+	"\x0espend_currency\x18\x05 \x01(\tR\rspendCurrency\"<\n" +
+	"\bIdentity\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\"\x90\x01\n" +
+	"\fOutputsDelta\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x1a\n" +
+	"\bbaseline\x18\x02 \x01(\x04R\bbaseline\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x12\x18\n" +
+	"\aremoved\x18\x04 \x03(\tR\aremoved\x12\x14\n" +
+	"\x05epoch\x18\x05 \x01(\x04R\x05epoch\"G\n" +
+	"\tOutputRef\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05hints\x18\x03 \x01(\fR\x05hints\"\x8b\x01\n" +
+	"\x0eExecuteRequest\x12\x1f\n" +
+	"\x04node\x18\x01 \x01(\v2\v.proto.NodeR\x04node\x121\n" +
+	"\acontext\x18\x02 \x01(\v2\x17.proto.ExecutionContextR\acontext\x12%\n" +
+	"\x0eformat_version\x18\x03 \x01(\rR\rformatVersion\"\xc5\x03\n" +
+	"\x0fExecuteResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\fR\x06result\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12*\n" +
+	"\bwarnings\x18\x03 \x03(\v2\x0e.proto.WarningR\bwarnings\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1f\n" +
+	"\voutput_kind\x18\x05 \x01(\tR\n" +
+	"outputKind\x12!\n" +
+	"\fresult_hints\x18\x06 \x01(\fR\vresultHints\x12%\n" +
+	"\x0eformat_version\x18\a \x01(\rR\rformatVersion\x12\x1a\n" +
+	"\bcassette\x18\b \x01(\fR\bcassette\x12/\n" +
+	"\n" +
+	"rate_limit\x18\t \x01(\v2\x10.proto.RateLimitR\trateLimit\x12\x14\n" +
+	"\x05units\x18\n" +
+	" \x01(\x01R\x05units\x12?\n" +
+	"\fcontinuation\x18\v \x01(\v2\x1b.proto.ScheduleContinuationR\fcontinuation\x12\x1b\n" +
+	"\tunits_set\x18\f \x01(\bR\bunitsSet\"G\n" +
+	"\x14ScheduleContinuation\x12\x19\n" +
+	"\bafter_ms\x18\x01 \x01(\x03R\aafterMs\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\fR\x05state\"f\n" +
+	"\fContinuation\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12*\n" +
+	"\x11scheduled_unix_ms\x18\x03 \x01(\x03R\x0fscheduledUnixMs\"c\n" +
+	"\tRateLimit\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x1c\n" +
+	"\tremaining\x18\x02 \x01(\x03R\tremaining\x12\"\n" +
+	"\rreset_unix_ms\x18\x03 \x01(\x03R\vresetUnixMs\"\xae\x01\n" +
+	"\tErrorLink\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x127\n" +
+	"\adetails\x18\x03 \x03(\v2\x1d.proto.ErrorLink.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
+	"\n" +
+	"ErrorChain\x12&\n" +
+	"\x05links\x18\x01 \x03(\v2\x10.proto.ErrorLinkR\x05links\"7\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"?\n" +
+	"\n" +
+	"StreamItem\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\fR\x04item\x12\x1d\n" +
+	"\n" +
+	"item_hints\x18\x02 \x01(\fR\titemHints\"<\n" +
+	"\x16GetCapabilitiesRequest\x12\"\n" +
+	"\rif_none_match\x18\x01 \x01(\tR\vifNoneMatch\"d\n" +
+	"\x17GetCapabilitiesResponse\x12\x12\n" +
+	"\x04uses\x18\x01 \x03(\tR\x04uses\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12!\n" +
+	"\fnot_modified\x18\x03 \x01(\bR\vnotModified\"\xb8\x01\n" +
+	"\n" +
+	"PluginInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x03 \x01(\tR\tgitCommit\x12%\n" +
+	"\x0eshared_version\x18\x04 \x01(\tR\rsharedVersion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\x12\x1a\n" +
+	"\bmanifest\x18\x06 \x01(\fR\bmanifest\"\x8e\x01\n" +
+	"\x10PreflightRequest\x12>\n" +
+	"\asecrets\x18\x01 \x03(\v2$.proto.PreflightRequest.SecretsEntryR\asecrets\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x99\x01\n" +
+	"\x0ePreflightCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12 \n" +
+	"\vremediation\x18\x04 \x01(\tR\vremediation\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\"B\n" +
+	"\x11PreflightResponse\x12-\n" +
+	"\x06checks\x18\x01 \x03(\v2\x15.proto.PreflightCheckR\x06checks\"'\n" +
+	"\x0fSelfTestRequest\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\"\xdc\x01\n" +
+	"\x12ListOptionsRequest\x12\x12\n" +
+	"\x04uses\x18\x01 \x01(\tR\x04uses\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x1e\n" +
+	"\n" +
+	"connection\x18\x03 \x01(\tR\n" +
+	"connection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x121\n" +
+	"\acontext\x18\a \x01(\v2\x17.proto.ExecutionContextR\acontext\"V\n" +
+	"\x06Option\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"f\n" +
+	"\x13ListOptionsResponse\x12'\n" +
+	"\aoptions\x18\x01 \x03(\v2\r.proto.OptionR\aoptions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"i\n" +
+	"\x13SampleOutputRequest\x12\x1f\n" +
+	"\x04node\x18\x01 \x01(\v2\v.proto.NodeR\x04node\x121\n" +
+	"\acontext\x18\x02 \x01(\v2\x17.proto.ExecutionContextR\acontext\".\n" +
+	"\x14SampleOutputResponse\x12\x16\n" +
+	"\x06output\x18\x01 \x01(\fR\x06output\"y\n" +
+	"\vPollRequest\x12\x1f\n" +
+	"\x04node\x18\x01 \x01(\v2\v.proto.NodeR\x04node\x121\n" +
+	"\acontext\x18\x02 \x01(\v2\x17.proto.ExecutionContextR\acontext\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"Q\n" +
+	"\tPollEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12 \n" +
+	"\ftime_unix_ms\x18\x03 \x01(\x03R\n" +
+	"timeUnixMs\"q\n" +
+	"\fPollResponse\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.proto.PollEventR\x06events\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x1f\n" +
+	"\vinterval_ms\x18\x03 \x01(\x03R\n" +
+	"intervalMs\"r\n" +
+	"\n" +
+	"AckRequest\x12\x1f\n" +
+	"\x04node\x18\x01 \x01(\v2\v.proto.NodeR\x04node\x121\n" +
+	"\acontext\x18\x02 \x01(\v2\x17.proto.ExecutionContextR\acontext\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\"6\n" +
+	"\x13EstimateCostRequest\x12\x1f\n" +
+	"\x04node\x18\x01 \x01(\v2\v.proto.NodeR\x04node\"\\\n" +
+	"\fCostEstimate\x12\x18\n" +
+	"\acredits\x18\x01 \x01(\x01R\acredits\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\xef\x02\n" +
+	"\vPluginStats\x12\x1d\n" +
+	"\n" +
+	"heap_bytes\x18\x01 \x01(\x04R\theapBytes\x12\x1b\n" +
+	"\tsys_bytes\x18\x02 \x01(\x04R\bsysBytes\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x03 \x01(\x05R\n" +
+	"goroutines\x12)\n" +
+	"\x10open_connections\x18\x04 \x01(\x05R\x0fopenConnections\x12\x14\n" +
+	"\x05calls\x18\x05 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06errors\x18\x06 \x01(\x03R\x06errors\x12\x1b\n" +
+	"\tin_flight\x18\a \x01(\x03R\binFlight\x12\x1b\n" +
+	"\tuptime_ms\x18\b \x01(\x03R\buptimeMs\x126\n" +
+	"\x06custom\x18\t \x03(\v2\x1e.proto.PluginStats.CustomEntryR\x06custom\x1a9\n" +
+	"\vCustomEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xcc\x01\n" +
+	"\fDebugRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x12\n" +
+	"\x04uses\x18\x02 \x01(\tR\x04uses\x12&\n" +
+	"\x0fstarted_unix_ms\x18\x03 \x01(\x03R\rstartedUnixMs\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x12\n" +
+	"\x04node\x18\x06 \x01(\fR\x04node\x12\x1c\n" +
+	"\ttruncated\x18\a \x01(\bR\ttruncated\"-\n" +
+	"\x15RecentRequestsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"I\n" +
+	"\x16RecentRequestsResponse\x12/\n" +
+	"\brequests\x18\x01 \x03(\v2\x13.proto.DebugRequestR\brequests\"%\n" +
+	"\vDebugConfig\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\fR\x06config\"\xa8\x01\n" +
+	"\rWireLogConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12$\n" +
+	"\x0emax_file_bytes\x18\x03 \x01(\x03R\fmaxFileBytes\x12\x1b\n" +
+	"\tmax_files\x18\x04 \x01(\x05R\bmaxFiles\x12&\n" +
+	"\x0fmax_entry_bytes\x18\x05 \x01(\x05R\rmaxEntryBytes\"_\n" +
+	"\x10ConfigureRequest\x12/\n" +
+	"\bwire_log\x18\x01 \x01(\v2\x14.proto.WireLogConfigR\awireLog\x12\x1a\n" +
+	"\bsettings\x18\x02 \x01(\fR\bsettings\"A\n" +
+	"\rGoroutineDump\x12\x12\n" +
+	"\x04dump\x18\x01 \x01(\fR\x04dump\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"\xb2\x01\n" +
+	"\vOAuth2Token\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x12$\n" +
+	"\x0eexpiry_unix_ms\x18\x04 \x01(\x03R\fexpiryUnixMs\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\"\x1c\n" +
+	"\bTokenKey\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"M\n" +
+	"\x0fPutTokenRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12(\n" +
+	"\x05token\x18\x02 \x01(\v2\x12.proto.OAuth2TokenR\x05token\"3\n" +
+	"\x14SetTokenStoreRequest\x12\x1b\n" +
+	"\tbroker_id\x18\x01 \x01(\rR\bbrokerId\"6\n" +
+	"\x17SetArtifactStoreRequest\x12\x1b\n" +
+	"\tbroker_id\x18\x01 \x01(\rR\bbrokerId\"9\n" +
+	"\x1aSetIdempotencyStoreRequest\x12\x1b\n" +
+	"\tbroker_id\x18\x01 \x01(\rR\bbrokerId\"y\n" +
+	"\x11IdempotencyRecord\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\fR\x06result\x12&\n" +
+	"\x0fcreated_unix_ms\x18\x04 \x01(\x03R\rcreatedUnixMs\";\n" +
+	"\fClaimRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x19\n" +
+	"\blease_ms\x18\x02 \x01(\x03R\aleaseMs\"q\n" +
+	"\rClaimResponse\x12\x18\n" +
+	"\aclaimed\x18\x01 \x01(\bR\aclaimed\x120\n" +
+	"\x06record\x18\x02 \x01(\v2\x18.proto.IdempotencyRecordR\x06record\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\x04R\x05token\"h\n" +
+	"\x0fCompleteRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\fR\x06result\x12\x15\n" +
+	"\x06ttl_ms\x18\x03 \x01(\x03R\x05ttlMs\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\x04R\x05token\"8\n" +
+	"\x0eIdempotencyKey\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\x04R\x05token\"\x1f\n" +
+	"\vArtifactRef\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\"#\n" +
+	"\rArtifactChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"g\n" +
+	"\x0eControlMessage\x125\n" +
+	"\tconfigure\x18\x01 \x01(\v2\x17.proto.ConfigureRequestR\tconfigure\x12\x1e\n" +
+	"\n" +
+	"invalidate\x18\x02 \x03(\tR\n" +
+	"invalidate\"\xfe\x01\n" +
+	"\fPluginNotice\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bseverity\x18\x02 \x01(\tR\bseverity\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12C\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2#.proto.PluginNotice.AttributesEntryR\n" +
+	"attributes\x12 \n" +
+	"\ftime_unix_ms\x18\x05 \x01(\x03R\n" +
+	"timeUnixMs\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xb6\b\n" +
+	"\fNodeExecutor\x128\n" +
+	"\aExecute\x12\x15.proto.ExecuteRequest\x1a\x16.proto.ExecuteResponse\x12P\n" +
+	"\x0fGetCapabilities\x12\x1d.proto.GetCapabilitiesRequest\x1a\x1e.proto.GetCapabilitiesResponse\x12*\n" +
+	"\aGetInfo\x12\f.proto.Empty\x1a\x11.proto.PluginInfo\x12;\n" +
+	"\rExecuteStream\x12\x15.proto.ExecuteRequest\x1a\x11.proto.StreamItem0\x01\x12>\n" +
+	"\tPreflight\x12\x17.proto.PreflightRequest\x1a\x18.proto.PreflightResponse\x12,\n" +
+	"\bGetStats\x12\f.proto.Empty\x1a\x12.proto.PluginStats\x122\n" +
+	"\tConfigure\x12\x17.proto.ConfigureRequest\x1a\f.proto.Empty\x12:\n" +
+	"\rSetTokenStore\x12\x1b.proto.SetTokenStoreRequest\x1a\f.proto.Empty\x129\n" +
+	"\aControl\x12\x15.proto.ControlMessage\x1a\x13.proto.PluginNotice(\x010\x01\x12@\n" +
+	"\x10SetArtifactStore\x12\x1e.proto.SetArtifactStoreRequest\x1a\f.proto.Empty\x12?\n" +
+	"\fEstimateCost\x12\x1a.proto.EstimateCostRequest\x1a\x13.proto.CostEstimate\x12<\n" +
+	"\bSelfTest\x12\x16.proto.SelfTestRequest\x1a\x18.proto.PreflightResponse\x12D\n" +
+	"\vListOptions\x12\x19.proto.ListOptionsRequest\x1a\x1a.proto.ListOptionsResponse\x12G\n" +
+	"\fSampleOutput\x12\x1a.proto.SampleOutputRequest\x1a\x1b.proto.SampleOutputResponse\x12/\n" +
+	"\x04Poll\x12\x12.proto.PollRequest\x1a\x13.proto.PollResponse\x12&\n" +
+	"\x03Ack\x12\x11.proto.AckRequest\x1a\f.proto.Empty\x12'\n" +
+	"\x04Nack\x12\x11.proto.AckRequest\x1a\f.proto.Empty\x12F\n" +
+	"\x13SetIdempotencyStore\x12!.proto.SetIdempotencyStoreRequest\x1a\f.proto.Empty2\xc1\x01\n" +
+	"\vPluginDebug\x12M\n" +
+	"\x0eRecentRequests\x12\x1c.proto.RecentRequestsRequest\x1a\x1d.proto.RecentRequestsResponse\x12-\n" +
+	"\tGetConfig\x12\f.proto.Empty\x1a\x12.proto.DebugConfig\x124\n" +
+	"\x0eDumpGoroutines\x12\f.proto.Empty\x1a\x14.proto.GoroutineDump2\x9d\x01\n" +
+	"\n" +
+	"TokenStore\x12/\n" +
+	"\bGetToken\x12\x0f.proto.TokenKey\x1a\x12.proto.OAuth2Token\x120\n" +
+	"\bPutToken\x12\x16.proto.PutTokenRequest\x1a\f.proto.Empty\x12,\n" +
+	"\vDeleteToken\x12\x0f.proto.TokenKey\x1a\f.proto.Empty2\xa8\x01\n" +
+	"\x10IdempotencyStore\x122\n" +
+	"\x05Claim\x12\x13.proto.ClaimRequest\x1a\x14.proto.ClaimResponse\x120\n" +
+	"\bComplete\x12\x16.proto.CompleteRequest\x1a\f.proto.Empty\x12.\n" +
+	"\aRelease\x12\x15.proto.IdempotencyKey\x1a\f.proto.Empty2\x85\x01\n" +
+	"\rArtifactStore\x129\n" +
+	"\vPutArtifact\x12\x14.proto.ArtifactChunk\x1a\x12.proto.ArtifactRef(\x01\x129\n" +
+	"\vGetArtifact\x12\x12.proto.ArtifactRef\x1a\x14.proto.ArtifactChunk0\x01B\x12Z\x10./internal/protob\x06proto3"
+
+var (
+	file_internal_proto_orkestra_proto_rawDescOnce sync.Once
+	file_internal_proto_orkestra_proto_rawDescData []byte
+)
+
+func file_internal_proto_orkestra_proto_rawDescGZIP() []byte {
+	file_internal_proto_orkestra_proto_rawDescOnce.Do(func() {
+		file_internal_proto_orkestra_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_proto_orkestra_proto_rawDesc), len(file_internal_proto_orkestra_proto_rawDesc)))
+	})
+	return file_internal_proto_orkestra_proto_rawDescData
+}
+
+var file_internal_proto_orkestra_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
+var file_internal_proto_orkestra_proto_goTypes = []any{
+	(*Empty)(nil),                      // 0: proto.Empty
+	(*Node)(nil),                       // 1: proto.Node
+	(*Resources)(nil),                  // 2: proto.Resources
+	(*ExecutionContext)(nil),           // 3: proto.ExecutionContext
+	(*Attachment)(nil),                 // 4: proto.Attachment
+	(*Connection)(nil),                 // 5: proto.Connection
+	(*Budget)(nil),                     // 6: proto.Budget
+	(*Identity)(nil),                   // 7: proto.Identity
+	(*OutputsDelta)(nil),               // 8: proto.OutputsDelta
+	(*OutputRef)(nil),                  // 9: proto.OutputRef
+	(*ExecuteRequest)(nil),             // 10: proto.ExecuteRequest
+	(*ExecuteResponse)(nil),            // 11: proto.ExecuteResponse
+	(*ScheduleContinuation)(nil),       // 12: proto.ScheduleContinuation
+	(*Continuation)(nil),               // 13: proto.Continuation
+	(*RateLimit)(nil),                  // 14: proto.RateLimit
+	(*ErrorLink)(nil),                  // 15: proto.ErrorLink
+	(*ErrorChain)(nil),                 // 16: proto.ErrorChain
+	(*Warning)(nil),                    // 17: proto.Warning
+	(*StreamItem)(nil),                 // 18: proto.StreamItem
+	(*GetCapabilitiesRequest)(nil),     // 19: proto.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil),    // 20: proto.GetCapabilitiesResponse
+	(*PluginInfo)(nil),                 // 21: proto.PluginInfo
+	(*PreflightRequest)(nil),           // 22: proto.PreflightRequest
+	(*PreflightCheck)(nil),             // 23: proto.PreflightCheck
+	(*PreflightResponse)(nil),          // 24: proto.PreflightResponse
+	(*SelfTestRequest)(nil),            // 25: proto.SelfTestRequest
+	(*ListOptionsRequest)(nil),         // 26: proto.ListOptionsRequest
+	(*Option)(nil),                     // 27: proto.Option
+	(*ListOptionsResponse)(nil),        // 28: proto.ListOptionsResponse
+	(*SampleOutputRequest)(nil),        // 29: proto.SampleOutputRequest
+	(*SampleOutputResponse)(nil),       // 30: proto.SampleOutputResponse
+	(*PollRequest)(nil),                // 31: proto.PollRequest
+	(*PollEvent)(nil),                  // 32: proto.PollEvent
+	(*PollResponse)(nil),               // 33: proto.PollResponse
+	(*AckRequest)(nil),                 // 34: proto.AckRequest
+	(*EstimateCostRequest)(nil),        // 35: proto.EstimateCostRequest
+	(*CostEstimate)(nil),               // 36: proto.CostEstimate
+	(*PluginStats)(nil),                // 37: proto.PluginStats
+	(*DebugRequest)(nil),               // 38: proto.DebugRequest
+	(*RecentRequestsRequest)(nil),      // 39: proto.RecentRequestsRequest
+	(*RecentRequestsResponse)(nil),     // 40: proto.RecentRequestsResponse
+	(*DebugConfig)(nil),                // 41: proto.DebugConfig
+	(*WireLogConfig)(nil),              // 42: proto.WireLogConfig
+	(*ConfigureRequest)(nil),           // 43: proto.ConfigureRequest
+	(*GoroutineDump)(nil),              // 44: proto.GoroutineDump
+	(*OAuth2Token)(nil),                // 45: proto.OAuth2Token
+	(*TokenKey)(nil),                   // 46: proto.TokenKey
+	(*PutTokenRequest)(nil),            // 47: proto.PutTokenRequest
+	(*SetTokenStoreRequest)(nil),       // 48: proto.SetTokenStoreRequest
+	(*SetArtifactStoreRequest)(nil),    // 49: proto.SetArtifactStoreRequest
+	(*SetIdempotencyStoreRequest)(nil), // 50: proto.SetIdempotencyStoreRequest
+	(*IdempotencyRecord)(nil),          // 51: proto.IdempotencyRecord
+	(*ClaimRequest)(nil),               // 52: proto.ClaimRequest
+	(*ClaimResponse)(nil),              // 53: proto.ClaimResponse
+	(*CompleteRequest)(nil),            // 54: proto.CompleteRequest
+	(*IdempotencyKey)(nil),             // 55: proto.IdempotencyKey
+	(*ArtifactRef)(nil),                // 56: proto.ArtifactRef
+	(*ArtifactChunk)(nil),              // 57: proto.ArtifactChunk
+	(*ControlMessage)(nil),             // 58: proto.ControlMessage
+	(*PluginNotice)(nil),               // 59: proto.PluginNotice
+	nil,                                // 60: proto.Node.LabelsEntry
+	nil,                                // 61: proto.Node.AnnotationsEntry
+	nil,                                // 62: proto.Node.EnvEntry
+	nil,                                // 63: proto.ExecutionContext.SecretsEntry
+	nil,                                // 64: proto.ExecutionContext.NodeOutputRefsEntry
+	nil,                                // 65: proto.ErrorLink.DetailsEntry
+	nil,                                // 66: proto.PreflightRequest.SecretsEntry
+	nil,                                // 67: proto.PluginStats.CustomEntry
+	nil,                                // 68: proto.PluginNotice.AttributesEntry
+}
+var file_internal_proto_orkestra_proto_depIdxs = []int32{
+	1,  // 0: proto.Node.Do:type_name -> proto.Node
+	1,  // 1: proto.Node.OnFailure:type_name -> proto.Node
+	60, // 2: proto.Node.Labels:type_name -> proto.Node.LabelsEntry
+	61, // 3: proto.Node.Annotations:type_name -> proto.Node.AnnotationsEntry
+	2,  // 4: proto.Node.Resources:type_name -> proto.Resources
+	62, // 5: proto.Node.Env:type_name -> proto.Node.EnvEntry
+	1,  // 6: proto.Node.Compensate:type_name -> proto.Node
+	63, // 7: proto.ExecutionContext.Secrets:type_name -> proto.ExecutionContext.SecretsEntry
+	64, // 8: proto.ExecutionContext.NodeOutputRefs:type_name -> proto.ExecutionContext.NodeOutputRefsEntry
+	8,  // 9: proto.ExecutionContext.OutputsDelta:type_name -> proto.OutputsDelta
+	7,  // 10: proto.ExecutionContext.Identity:type_name -> proto.Identity
+	6,  // 11: proto.ExecutionContext.Budget:type_name -> proto.Budget
+	5,  // 12: proto.ExecutionContext.Connection:type_name -> proto.Connection
+	4,  // 13: proto.ExecutionContext.Attachments:type_name -> proto.Attachment
+	13, // 14: proto.ExecutionContext.Continuation:type_name -> proto.Continuation
+	1,  // 15: proto.ExecuteRequest.node:type_name -> proto.Node
+	3,  // 16: proto.ExecuteRequest.context:type_name -> proto.ExecutionContext
+	17, // 17: proto.ExecuteResponse.warnings:type_name -> proto.Warning
+	14, // 18: proto.ExecuteResponse.rate_limit:type_name -> proto.RateLimit
+	12, // 19: proto.ExecuteResponse.continuation:type_name -> proto.ScheduleContinuation
+	65, // 20: proto.ErrorLink.details:type_name -> proto.ErrorLink.DetailsEntry
+	15, // 21: proto.ErrorChain.links:type_name -> proto.ErrorLink
+	66, // 22: proto.PreflightRequest.secrets:type_name -> proto.PreflightRequest.SecretsEntry
+	23, // 23: proto.PreflightResponse.checks:type_name -> proto.PreflightCheck
+	3,  // 24: proto.ListOptionsRequest.context:type_name -> proto.ExecutionContext
+	27, // 25: proto.ListOptionsResponse.options:type_name -> proto.Option
+	1,  // 26: proto.SampleOutputRequest.node:type_name -> proto.Node
+	3,  // 27: proto.SampleOutputRequest.context:type_name -> proto.ExecutionContext
+	1,  // 28: proto.PollRequest.node:type_name -> proto.Node
+	3,  // 29: proto.PollRequest.context:type_name -> proto.ExecutionContext
+	32, // 30: proto.PollResponse.events:type_name -> proto.PollEvent
+	1,  // 31: proto.AckRequest.node:type_name -> proto.Node
+	3,  // 32: proto.AckRequest.context:type_name -> proto.ExecutionContext
+	1,  // 33: proto.EstimateCostRequest.node:type_name -> proto.Node
+	67, // 34: proto.PluginStats.custom:type_name -> proto.PluginStats.CustomEntry
+	38, // 35: proto.RecentRequestsResponse.requests:type_name -> proto.DebugRequest
+	42, // 36: proto.ConfigureRequest.wire_log:type_name -> proto.WireLogConfig
+	45, // 37: proto.PutTokenRequest.token:type_name -> proto.OAuth2Token
+	51, // 38: proto.ClaimResponse.record:type_name -> proto.IdempotencyRecord
+	43, // 39: proto.ControlMessage.configure:type_name -> proto.ConfigureRequest
+	68, // 40: proto.PluginNotice.attributes:type_name -> proto.PluginNotice.AttributesEntry
+	9,  // 41: proto.ExecutionContext.NodeOutputRefsEntry.value:type_name -> proto.OutputRef
+	10, // 42: proto.NodeExecutor.Execute:input_type -> proto.ExecuteRequest
+	19, // 43: proto.NodeExecutor.GetCapabilities:input_type -> proto.GetCapabilitiesRequest
+	0,  // 44: proto.NodeExecutor.GetInfo:input_type -> proto.Empty
+	10, // 45: proto.NodeExecutor.ExecuteStream:input_type -> proto.ExecuteRequest
+	22, // 46: proto.NodeExecutor.Preflight:input_type -> proto.PreflightRequest
+	0,  // 47: proto.NodeExecutor.GetStats:input_type -> proto.Empty
+	43, // 48: proto.NodeExecutor.Configure:input_type -> proto.ConfigureRequest
+	48, // 49: proto.NodeExecutor.SetTokenStore:input_type -> proto.SetTokenStoreRequest
+	58, // 50: proto.NodeExecutor.Control:input_type -> proto.ControlMessage
+	49, // 51: proto.NodeExecutor.SetArtifactStore:input_type -> proto.SetArtifactStoreRequest
+	35, // 52: proto.NodeExecutor.EstimateCost:input_type -> proto.EstimateCostRequest
+	25, // 53: proto.NodeExecutor.SelfTest:input_type -> proto.SelfTestRequest
+	26, // 54: proto.NodeExecutor.ListOptions:input_type -> proto.ListOptionsRequest
+	29, // 55: proto.NodeExecutor.SampleOutput:input_type -> proto.SampleOutputRequest
+	31, // 56: proto.NodeExecutor.Poll:input_type -> proto.PollRequest
+	34, // 57: proto.NodeExecutor.Ack:input_type -> proto.AckRequest
+	34, // 58: proto.NodeExecutor.Nack:input_type -> proto.AckRequest
+	50, // 59: proto.NodeExecutor.SetIdempotencyStore:input_type -> proto.SetIdempotencyStoreRequest
+	39, // 60: proto.PluginDebug.RecentRequests:input_type -> proto.RecentRequestsRequest
+	0,  // 61: proto.PluginDebug.GetConfig:input_type -> proto.Empty
+	0,  // 62: proto.PluginDebug.DumpGoroutines:input_type -> proto.Empty
+	46, // 63: proto.TokenStore.GetToken:input_type -> proto.TokenKey
+	47, // 64: proto.TokenStore.PutToken:input_type -> proto.PutTokenRequest
+	46, // 65: proto.TokenStore.DeleteToken:input_type -> proto.TokenKey
+	52, // 66: proto.IdempotencyStore.Claim:input_type -> proto.ClaimRequest
+	54, // 67: proto.IdempotencyStore.Complete:input_type -> proto.CompleteRequest
+	55, // 68: proto.IdempotencyStore.Release:input_type -> proto.IdempotencyKey
+	57, // 69: proto.ArtifactStore.PutArtifact:input_type -> proto.ArtifactChunk
+	56, // 70: proto.ArtifactStore.GetArtifact:input_type -> proto.ArtifactRef
+	11, // 71: proto.NodeExecutor.Execute:output_type -> proto.ExecuteResponse
+	20, // 72: proto.NodeExecutor.GetCapabilities:output_type -> proto.GetCapabilitiesResponse
+	21, // 73: proto.NodeExecutor.GetInfo:output_type -> proto.PluginInfo
+	18, // 74: proto.NodeExecutor.ExecuteStream:output_type -> proto.StreamItem
+	24, // 75: proto.NodeExecutor.Preflight:output_type -> proto.PreflightResponse
+	37, // 76: proto.NodeExecutor.GetStats:output_type -> proto.PluginStats
+	0,  // 77: proto.NodeExecutor.Configure:output_type -> proto.Empty
+	0,  // 78: proto.NodeExecutor.SetTokenStore:output_type -> proto.Empty
+	59, // 79: proto.NodeExecutor.Control:output_type -> proto.PluginNotice
+	0,  // 80: proto.NodeExecutor.SetArtifactStore:output_type -> proto.Empty
+	36, // 81: proto.NodeExecutor.EstimateCost:output_type -> proto.CostEstimate
+	24, // 82: proto.NodeExecutor.SelfTest:output_type -> proto.PreflightResponse
+	28, // 83: proto.NodeExecutor.ListOptions:output_type -> proto.ListOptionsResponse
+	30, // 84: proto.NodeExecutor.SampleOutput:output_type -> proto.SampleOutputResponse
+	33, // 85: proto.NodeExecutor.Poll:output_type -> proto.PollResponse
+	0,  // 86: proto.NodeExecutor.Ack:output_type -> proto.Empty
+	0,  // 87: proto.NodeExecutor.Nack:output_type -> proto.Empty
+	0,  // 88: proto.NodeExecutor.SetIdempotencyStore:output_type -> proto.Empty
+	40, // 89: proto.PluginDebug.RecentRequests:output_type -> proto.RecentRequestsResponse
+	41, // 90: proto.PluginDebug.GetConfig:output_type -> proto.DebugConfig
+	44, // 91: proto.PluginDebug.DumpGoroutines:output_type -> proto.GoroutineDump
+	45, // 92: proto.TokenStore.GetToken:output_type -> proto.OAuth2Token
+	0,  // 93: proto.TokenStore.PutToken:output_type -> proto.Empty
+	0,  // 94: proto.TokenStore.DeleteToken:output_type -> proto.Empty
+	53, // 95: proto.IdempotencyStore.Claim:output_type -> proto.ClaimResponse
+	0,  // 96: proto.IdempotencyStore.Complete:output_type -> proto.Empty
+	0,  // 97: proto.IdempotencyStore.Release:output_type -> proto.Empty
+	56, // 98: proto.ArtifactStore.PutArtifact:output_type -> proto.ArtifactRef
+	57, // 99: proto.ArtifactStore.GetArtifact:output_type -> proto.ArtifactChunk
+	71, // [71:100] is the sub-list for method output_type
+	42, // [42:71] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_orkestra_proto_init() }
+func file_internal_proto_orkestra_proto_init() {
+	if File_internal_proto_orkestra_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_orkestra_proto_rawDesc), len(file_internal_proto_orkestra_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   69,
+			NumExtensions: 0,
+			NumServices:   5,
+		},
+		GoTypes:           file_internal_proto_orkestra_proto_goTypes,
+		DependencyIndexes: file_internal_proto_orkestra_proto_depIdxs,
+		MessageInfos:      file_internal_proto_orkestra_proto_msgTypes,
+	}.Build()
+	File_internal_proto_orkestra_proto = out.File
+	file_internal_proto_orkestra_proto_goTypes = nil
+	file_internal_proto_orkestra_proto_depIdxs = nil
+}
@@ -0,0 +1,1459 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0--rc2
+// source: internal/proto/orkestra.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NodeExecutor_Execute_FullMethodName             = "/proto.NodeExecutor/Execute"
+	NodeExecutor_GetCapabilities_FullMethodName     = "/proto.NodeExecutor/GetCapabilities"
+	NodeExecutor_GetInfo_FullMethodName             = "/proto.NodeExecutor/GetInfo"
+	NodeExecutor_ExecuteStream_FullMethodName       = "/proto.NodeExecutor/ExecuteStream"
+	NodeExecutor_Preflight_FullMethodName           = "/proto.NodeExecutor/Preflight"
+	NodeExecutor_GetStats_FullMethodName            = "/proto.NodeExecutor/GetStats"
+	NodeExecutor_Configure_FullMethodName           = "/proto.NodeExecutor/Configure"
+	NodeExecutor_SetTokenStore_FullMethodName       = "/proto.NodeExecutor/SetTokenStore"
+	NodeExecutor_Control_FullMethodName             = "/proto.NodeExecutor/Control"
+	NodeExecutor_SetArtifactStore_FullMethodName    = "/proto.NodeExecutor/SetArtifactStore"
+	NodeExecutor_EstimateCost_FullMethodName        = "/proto.NodeExecutor/EstimateCost"
+	NodeExecutor_SelfTest_FullMethodName            = "/proto.NodeExecutor/SelfTest"
+	NodeExecutor_ListOptions_FullMethodName         = "/proto.NodeExecutor/ListOptions"
+	NodeExecutor_SampleOutput_FullMethodName        = "/proto.NodeExecutor/SampleOutput"
+	NodeExecutor_Poll_FullMethodName                = "/proto.NodeExecutor/Poll"
+	NodeExecutor_Ack_FullMethodName                 = "/proto.NodeExecutor/Ack"
+	NodeExecutor_Nack_FullMethodName                = "/proto.NodeExecutor/Nack"
+	NodeExecutor_SetIdempotencyStore_FullMethodName = "/proto.NodeExecutor/SetIdempotencyStore"
+)
+
+// NodeExecutorClient is the client API for NodeExecutor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Le service gRPC que chaque plugin doit implémenter
+type NodeExecutorClient interface {
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+	GetInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	ExecuteStream(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamItem], error)
+	Preflight(ctx context.Context, in *PreflightRequest, opts ...grpc.CallOption) (*PreflightResponse, error)
+	GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginStats, error)
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetTokenStore(ctx context.Context, in *SetTokenStoreRequest, opts ...grpc.CallOption) (*Empty, error)
+	Control(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ControlMessage, PluginNotice], error)
+	SetArtifactStore(ctx context.Context, in *SetArtifactStoreRequest, opts ...grpc.CallOption) (*Empty, error)
+	EstimateCost(ctx context.Context, in *EstimateCostRequest, opts ...grpc.CallOption) (*CostEstimate, error)
+	SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*PreflightResponse, error)
+	ListOptions(ctx context.Context, in *ListOptionsRequest, opts ...grpc.CallOption) (*ListOptionsResponse, error)
+	SampleOutput(ctx context.Context, in *SampleOutputRequest, opts ...grpc.CallOption) (*SampleOutputResponse, error)
+	Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*Empty, error)
+	Nack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetIdempotencyStore(ctx context.Context, in *SetIdempotencyStoreRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type nodeExecutorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodeExecutorClient(cc grpc.ClientConnInterface) NodeExecutorClient {
+	return &nodeExecutorClient{cc}
+}
+
+func (c *nodeExecutorClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_Execute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) GetInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginInfo)
+	err := c.cc.Invoke(ctx, NodeExecutor_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) ExecuteStream(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NodeExecutor_ServiceDesc.Streams[0], NodeExecutor_ExecuteStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecuteRequest, StreamItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeExecutor_ExecuteStreamClient = grpc.ServerStreamingClient[StreamItem]
+
+func (c *nodeExecutorClient) Preflight(ctx context.Context, in *PreflightRequest, opts ...grpc.CallOption) (*PreflightResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreflightResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_Preflight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginStats)
+	err := c.cc.Invoke(ctx, NodeExecutor_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_Configure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) SetTokenStore(ctx context.Context, in *SetTokenStoreRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_SetTokenStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) Control(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ControlMessage, PluginNotice], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NodeExecutor_ServiceDesc.Streams[1], NodeExecutor_Control_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ControlMessage, PluginNotice]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeExecutor_ControlClient = grpc.BidiStreamingClient[ControlMessage, PluginNotice]
+
+func (c *nodeExecutorClient) SetArtifactStore(ctx context.Context, in *SetArtifactStoreRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_SetArtifactStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) EstimateCost(ctx context.Context, in *EstimateCostRequest, opts ...grpc.CallOption) (*CostEstimate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CostEstimate)
+	err := c.cc.Invoke(ctx, NodeExecutor_EstimateCost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*PreflightResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreflightResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_SelfTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) ListOptions(ctx context.Context, in *ListOptionsRequest, opts ...grpc.CallOption) (*ListOptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOptionsResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_ListOptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) SampleOutput(ctx context.Context, in *SampleOutputRequest, opts ...grpc.CallOption) (*SampleOutputResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SampleOutputResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_SampleOutput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollResponse)
+	err := c.cc.Invoke(ctx, NodeExecutor_Poll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_Ack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) Nack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_Nack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeExecutorClient) SetIdempotencyStore(ctx context.Context, in *SetIdempotencyStoreRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, NodeExecutor_SetIdempotencyStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeExecutorServer is the server API for NodeExecutor service.
+// All implementations must embed UnimplementedNodeExecutorServer
+// for forward compatibility.
+//
+// Le service gRPC que chaque plugin doit implémenter
+type NodeExecutorServer interface {
+	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	GetInfo(context.Context, *Empty) (*PluginInfo, error)
+	ExecuteStream(*ExecuteRequest, grpc.ServerStreamingServer[StreamItem]) error
+	Preflight(context.Context, *PreflightRequest) (*PreflightResponse, error)
+	GetStats(context.Context, *Empty) (*PluginStats, error)
+	Configure(context.Context, *ConfigureRequest) (*Empty, error)
+	SetTokenStore(context.Context, *SetTokenStoreRequest) (*Empty, error)
+	Control(grpc.BidiStreamingServer[ControlMessage, PluginNotice]) error
+	SetArtifactStore(context.Context, *SetArtifactStoreRequest) (*Empty, error)
+	EstimateCost(context.Context, *EstimateCostRequest) (*CostEstimate, error)
+	SelfTest(context.Context, *SelfTestRequest) (*PreflightResponse, error)
+	ListOptions(context.Context, *ListOptionsRequest) (*ListOptionsResponse, error)
+	SampleOutput(context.Context, *SampleOutputRequest) (*SampleOutputResponse, error)
+	Poll(context.Context, *PollRequest) (*PollResponse, error)
+	Ack(context.Context, *AckRequest) (*Empty, error)
+	Nack(context.Context, *AckRequest) (*Empty, error)
+	SetIdempotencyStore(context.Context, *SetIdempotencyStoreRequest) (*Empty, error)
+	mustEmbedUnimplementedNodeExecutorServer()
+}
+
+// UnimplementedNodeExecutorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNodeExecutorServer struct{}
+
+func (UnimplementedNodeExecutorServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedNodeExecutorServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedNodeExecutorServer) GetInfo(context.Context, *Empty) (*PluginInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedNodeExecutorServer) ExecuteStream(*ExecuteRequest, grpc.ServerStreamingServer[StreamItem]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteStream not implemented")
+}
+func (UnimplementedNodeExecutorServer) Preflight(context.Context, *PreflightRequest) (*PreflightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Preflight not implemented")
+}
+func (UnimplementedNodeExecutorServer) GetStats(context.Context, *Empty) (*PluginStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedNodeExecutorServer) Configure(context.Context, *ConfigureRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedNodeExecutorServer) SetTokenStore(context.Context, *SetTokenStoreRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTokenStore not implemented")
+}
+func (UnimplementedNodeExecutorServer) Control(grpc.BidiStreamingServer[ControlMessage, PluginNotice]) error {
+	return status.Errorf(codes.Unimplemented, "method Control not implemented")
+}
+func (UnimplementedNodeExecutorServer) SetArtifactStore(context.Context, *SetArtifactStoreRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetArtifactStore not implemented")
+}
+func (UnimplementedNodeExecutorServer) EstimateCost(context.Context, *EstimateCostRequest) (*CostEstimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateCost not implemented")
+}
+func (UnimplementedNodeExecutorServer) SelfTest(context.Context, *SelfTestRequest) (*PreflightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (UnimplementedNodeExecutorServer) ListOptions(context.Context, *ListOptionsRequest) (*ListOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOptions not implemented")
+}
+func (UnimplementedNodeExecutorServer) SampleOutput(context.Context, *SampleOutputRequest) (*SampleOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SampleOutput not implemented")
+}
+func (UnimplementedNodeExecutorServer) Poll(context.Context, *PollRequest) (*PollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Poll not implemented")
+}
+func (UnimplementedNodeExecutorServer) Ack(context.Context, *AckRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ack not implemented")
+}
+func (UnimplementedNodeExecutorServer) Nack(context.Context, *AckRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nack not implemented")
+}
+func (UnimplementedNodeExecutorServer) SetIdempotencyStore(context.Context, *SetIdempotencyStoreRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIdempotencyStore not implemented")
+}
+func (UnimplementedNodeExecutorServer) mustEmbedUnimplementedNodeExecutorServer() {}
+func (UnimplementedNodeExecutorServer) testEmbeddedByValue()                      {}
+
+// UnsafeNodeExecutorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodeExecutorServer will
+// result in compilation errors.
+type UnsafeNodeExecutorServer interface {
+	mustEmbedUnimplementedNodeExecutorServer()
+}
+
+func RegisterNodeExecutorServer(s grpc.ServiceRegistrar, srv NodeExecutorServer) {
+	// If the following call pancis, it indicates UnimplementedNodeExecutorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NodeExecutor_ServiceDesc, srv)
+}
+
+func _NodeExecutor_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Execute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Execute(ctx, req.(*ExecuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).GetInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_ExecuteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecuteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeExecutorServer).ExecuteStream(m, &grpc.GenericServerStream[ExecuteRequest, StreamItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeExecutor_ExecuteStreamServer = grpc.ServerStreamingServer[StreamItem]
+
+func _NodeExecutor_Preflight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreflightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Preflight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Preflight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Preflight(ctx, req.(*PreflightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Configure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Configure(ctx, req.(*ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_SetTokenStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).SetTokenStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_SetTokenStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).SetTokenStore(ctx, req.(*SetTokenStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_Control_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeExecutorServer).Control(&grpc.GenericServerStream[ControlMessage, PluginNotice]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeExecutor_ControlServer = grpc.BidiStreamingServer[ControlMessage, PluginNotice]
+
+func _NodeExecutor_SetArtifactStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetArtifactStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).SetArtifactStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_SetArtifactStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).SetArtifactStore(ctx, req.(*SetArtifactStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_EstimateCost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateCostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).EstimateCost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_EstimateCost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).EstimateCost(ctx, req.(*EstimateCostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).SelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_SelfTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).SelfTest(ctx, req.(*SelfTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_ListOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).ListOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_ListOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).ListOptions(ctx, req.(*ListOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_SampleOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SampleOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).SampleOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_SampleOutput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).SampleOutput(ctx, req.(*SampleOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_Poll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Poll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Poll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Poll(ctx, req.(*PollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Ack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Ack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Ack(ctx, req.(*AckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_Nack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).Nack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_Nack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).Nack(ctx, req.(*AckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeExecutor_SetIdempotencyStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIdempotencyStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeExecutorServer).SetIdempotencyStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeExecutor_SetIdempotencyStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeExecutorServer).SetIdempotencyStore(ctx, req.(*SetIdempotencyStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NodeExecutor_ServiceDesc is the grpc.ServiceDesc for NodeExecutor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NodeExecutor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.NodeExecutor",
+	HandlerType: (*NodeExecutorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Execute",
+			Handler:    _NodeExecutor_Execute_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _NodeExecutor_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _NodeExecutor_GetInfo_Handler,
+		},
+		{
+			MethodName: "Preflight",
+			Handler:    _NodeExecutor_Preflight_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _NodeExecutor_GetStats_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _NodeExecutor_Configure_Handler,
+		},
+		{
+			MethodName: "SetTokenStore",
+			Handler:    _NodeExecutor_SetTokenStore_Handler,
+		},
+		{
+			MethodName: "SetArtifactStore",
+			Handler:    _NodeExecutor_SetArtifactStore_Handler,
+		},
+		{
+			MethodName: "EstimateCost",
+			Handler:    _NodeExecutor_EstimateCost_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _NodeExecutor_SelfTest_Handler,
+		},
+		{
+			MethodName: "ListOptions",
+			Handler:    _NodeExecutor_ListOptions_Handler,
+		},
+		{
+			MethodName: "SampleOutput",
+			Handler:    _NodeExecutor_SampleOutput_Handler,
+		},
+		{
+			MethodName: "Poll",
+			Handler:    _NodeExecutor_Poll_Handler,
+		},
+		{
+			MethodName: "Ack",
+			Handler:    _NodeExecutor_Ack_Handler,
+		},
+		{
+			MethodName: "Nack",
+			Handler:    _NodeExecutor_Nack_Handler,
+		},
+		{
+			MethodName: "SetIdempotencyStore",
+			Handler:    _NodeExecutor_SetIdempotencyStore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteStream",
+			Handler:       _NodeExecutor_ExecuteStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Control",
+			Handler:       _NodeExecutor_Control_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "internal/proto/orkestra.proto",
+}
+
+const (
+	PluginDebug_RecentRequests_FullMethodName = "/proto.PluginDebug/RecentRequests"
+	PluginDebug_GetConfig_FullMethodName      = "/proto.PluginDebug/GetConfig"
+	PluginDebug_DumpGoroutines_FullMethodName = "/proto.PluginDebug/DumpGoroutines"
+)
+
+// PluginDebugClient is the client API for PluginDebug service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service de débogage optionnel, destiné à grpcurl
+type PluginDebugClient interface {
+	RecentRequests(ctx context.Context, in *RecentRequestsRequest, opts ...grpc.CallOption) (*RecentRequestsResponse, error)
+	GetConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DebugConfig, error)
+	DumpGoroutines(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GoroutineDump, error)
+}
+
+type pluginDebugClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPluginDebugClient(cc grpc.ClientConnInterface) PluginDebugClient {
+	return &pluginDebugClient{cc}
+}
+
+func (c *pluginDebugClient) RecentRequests(ctx context.Context, in *RecentRequestsRequest, opts ...grpc.CallOption) (*RecentRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecentRequestsResponse)
+	err := c.cc.Invoke(ctx, PluginDebug_RecentRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginDebugClient) GetConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DebugConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebugConfig)
+	err := c.cc.Invoke(ctx, PluginDebug_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginDebugClient) DumpGoroutines(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GoroutineDump, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoroutineDump)
+	err := c.cc.Invoke(ctx, PluginDebug_DumpGoroutines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginDebugServer is the server API for PluginDebug service.
+// All implementations must embed UnimplementedPluginDebugServer
+// for forward compatibility.
+//
+// Service de débogage optionnel, destiné à grpcurl
+type PluginDebugServer interface {
+	RecentRequests(context.Context, *RecentRequestsRequest) (*RecentRequestsResponse, error)
+	GetConfig(context.Context, *Empty) (*DebugConfig, error)
+	DumpGoroutines(context.Context, *Empty) (*GoroutineDump, error)
+	mustEmbedUnimplementedPluginDebugServer()
+}
+
+// UnimplementedPluginDebugServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPluginDebugServer struct{}
+
+func (UnimplementedPluginDebugServer) RecentRequests(context.Context, *RecentRequestsRequest) (*RecentRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecentRequests not implemented")
+}
+func (UnimplementedPluginDebugServer) GetConfig(context.Context, *Empty) (*DebugConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedPluginDebugServer) DumpGoroutines(context.Context, *Empty) (*GoroutineDump, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpGoroutines not implemented")
+}
+func (UnimplementedPluginDebugServer) mustEmbedUnimplementedPluginDebugServer() {}
+func (UnimplementedPluginDebugServer) testEmbeddedByValue()                     {}
+
+// UnsafePluginDebugServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PluginDebugServer will
+// result in compilation errors.
+type UnsafePluginDebugServer interface {
+	mustEmbedUnimplementedPluginDebugServer()
+}
+
+func RegisterPluginDebugServer(s grpc.ServiceRegistrar, srv PluginDebugServer) {
+	// If the following call pancis, it indicates UnimplementedPluginDebugServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PluginDebug_ServiceDesc, srv)
+}
+
+func _PluginDebug_RecentRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecentRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServer).RecentRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebug_RecentRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServer).RecentRequests(ctx, req.(*RecentRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginDebug_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebug_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServer).GetConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginDebug_DumpGoroutines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginDebugServer).DumpGoroutines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginDebug_DumpGoroutines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginDebugServer).DumpGoroutines(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PluginDebug_ServiceDesc is the grpc.ServiceDesc for PluginDebug service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PluginDebug_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PluginDebug",
+	HandlerType: (*PluginDebugServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecentRequests",
+			Handler:    _PluginDebug_RecentRequests_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _PluginDebug_GetConfig_Handler,
+		},
+		{
+			MethodName: "DumpGoroutines",
+			Handler:    _PluginDebug_DumpGoroutines_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/orkestra.proto",
+}
+
+const (
+	TokenStore_GetToken_FullMethodName    = "/proto.TokenStore/GetToken"
+	TokenStore_PutToken_FullMethodName    = "/proto.TokenStore/PutToken"
+	TokenStore_DeleteToken_FullMethodName = "/proto.TokenStore/DeleteToken"
+)
+
+// TokenStoreClient is the client API for TokenStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service exposé par le moteur aux plugins via le broker go-plugin
+type TokenStoreClient interface {
+	GetToken(ctx context.Context, in *TokenKey, opts ...grpc.CallOption) (*OAuth2Token, error)
+	PutToken(ctx context.Context, in *PutTokenRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteToken(ctx context.Context, in *TokenKey, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type tokenStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTokenStoreClient(cc grpc.ClientConnInterface) TokenStoreClient {
+	return &tokenStoreClient{cc}
+}
+
+func (c *tokenStoreClient) GetToken(ctx context.Context, in *TokenKey, opts ...grpc.CallOption) (*OAuth2Token, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OAuth2Token)
+	err := c.cc.Invoke(ctx, TokenStore_GetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenStoreClient) PutToken(ctx context.Context, in *PutTokenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, TokenStore_PutToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenStoreClient) DeleteToken(ctx context.Context, in *TokenKey, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, TokenStore_DeleteToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenStoreServer is the server API for TokenStore service.
+// All implementations must embed UnimplementedTokenStoreServer
+// for forward compatibility.
+//
+// Service exposé par le moteur aux plugins via le broker go-plugin
+type TokenStoreServer interface {
+	GetToken(context.Context, *TokenKey) (*OAuth2Token, error)
+	PutToken(context.Context, *PutTokenRequest) (*Empty, error)
+	DeleteToken(context.Context, *TokenKey) (*Empty, error)
+	mustEmbedUnimplementedTokenStoreServer()
+}
+
+// UnimplementedTokenStoreServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTokenStoreServer struct{}
+
+func (UnimplementedTokenStoreServer) GetToken(context.Context, *TokenKey) (*OAuth2Token, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+}
+func (UnimplementedTokenStoreServer) PutToken(context.Context, *PutTokenRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutToken not implemented")
+}
+func (UnimplementedTokenStoreServer) DeleteToken(context.Context, *TokenKey) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteToken not implemented")
+}
+func (UnimplementedTokenStoreServer) mustEmbedUnimplementedTokenStoreServer() {}
+func (UnimplementedTokenStoreServer) testEmbeddedByValue()                    {}
+
+// UnsafeTokenStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TokenStoreServer will
+// result in compilation errors.
+type UnsafeTokenStoreServer interface {
+	mustEmbedUnimplementedTokenStoreServer()
+}
+
+func RegisterTokenStoreServer(s grpc.ServiceRegistrar, srv TokenStoreServer) {
+	// If the following call pancis, it indicates UnimplementedTokenStoreServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TokenStore_ServiceDesc, srv)
+}
+
+func _TokenStore_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenStoreServer).GetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokenStore_GetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenStoreServer).GetToken(ctx, req.(*TokenKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TokenStore_PutToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenStoreServer).PutToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokenStore_PutToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenStoreServer).PutToken(ctx, req.(*PutTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TokenStore_DeleteToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenStoreServer).DeleteToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokenStore_DeleteToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenStoreServer).DeleteToken(ctx, req.(*TokenKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TokenStore_ServiceDesc is the grpc.ServiceDesc for TokenStore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TokenStore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.TokenStore",
+	HandlerType: (*TokenStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetToken",
+			Handler:    _TokenStore_GetToken_Handler,
+		},
+		{
+			MethodName: "PutToken",
+			Handler:    _TokenStore_PutToken_Handler,
+		},
+		{
+			MethodName: "DeleteToken",
+			Handler:    _TokenStore_DeleteToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/orkestra.proto",
+}
+
+const (
+	IdempotencyStore_Claim_FullMethodName    = "/proto.IdempotencyStore/Claim"
+	IdempotencyStore_Complete_FullMethodName = "/proto.IdempotencyStore/Complete"
+	IdempotencyStore_Release_FullMethodName  = "/proto.IdempotencyStore/Release"
+)
+
+// IdempotencyStoreClient is the client API for IdempotencyStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Enregistrements d'idempotence des effets de bord, exposés par le moteur
+// aux plugins via le broker go-plugin
+type IdempotencyStoreClient interface {
+	Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*ClaimResponse, error)
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	Release(ctx context.Context, in *IdempotencyKey, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type idempotencyStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIdempotencyStoreClient(cc grpc.ClientConnInterface) IdempotencyStoreClient {
+	return &idempotencyStoreClient{cc}
+}
+
+func (c *idempotencyStoreClient) Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*ClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimResponse)
+	err := c.cc.Invoke(ctx, IdempotencyStore_Claim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idempotencyStoreClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, IdempotencyStore_Complete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idempotencyStoreClient) Release(ctx context.Context, in *IdempotencyKey, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, IdempotencyStore_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IdempotencyStoreServer is the server API for IdempotencyStore service.
+// All implementations must embed UnimplementedIdempotencyStoreServer
+// for forward compatibility.
+//
+// Enregistrements d'idempotence des effets de bord, exposés par le moteur
+// aux plugins via le broker go-plugin
+type IdempotencyStoreServer interface {
+	Claim(context.Context, *ClaimRequest) (*ClaimResponse, error)
+	Complete(context.Context, *CompleteRequest) (*Empty, error)
+	Release(context.Context, *IdempotencyKey) (*Empty, error)
+	mustEmbedUnimplementedIdempotencyStoreServer()
+}
+
+// UnimplementedIdempotencyStoreServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIdempotencyStoreServer struct{}
+
+func (UnimplementedIdempotencyStoreServer) Claim(context.Context, *ClaimRequest) (*ClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Claim not implemented")
+}
+func (UnimplementedIdempotencyStoreServer) Complete(context.Context, *CompleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedIdempotencyStoreServer) Release(context.Context, *IdempotencyKey) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedIdempotencyStoreServer) mustEmbedUnimplementedIdempotencyStoreServer() {}
+func (UnimplementedIdempotencyStoreServer) testEmbeddedByValue()                          {}
+
+// UnsafeIdempotencyStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IdempotencyStoreServer will
+// result in compilation errors.
+type UnsafeIdempotencyStoreServer interface {
+	mustEmbedUnimplementedIdempotencyStoreServer()
+}
+
+func RegisterIdempotencyStoreServer(s grpc.ServiceRegistrar, srv IdempotencyStoreServer) {
+	// If the following call pancis, it indicates UnimplementedIdempotencyStoreServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IdempotencyStore_ServiceDesc, srv)
+}
+
+func _IdempotencyStore_Claim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdempotencyStoreServer).Claim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdempotencyStore_Claim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdempotencyStoreServer).Claim(ctx, req.(*ClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdempotencyStore_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdempotencyStoreServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdempotencyStore_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdempotencyStoreServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdempotencyStore_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdempotencyKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdempotencyStoreServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdempotencyStore_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdempotencyStoreServer).Release(ctx, req.(*IdempotencyKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IdempotencyStore_ServiceDesc is the grpc.ServiceDesc for IdempotencyStore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IdempotencyStore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.IdempotencyStore",
+	HandlerType: (*IdempotencyStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Claim",
+			Handler:    _IdempotencyStore_Claim_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _IdempotencyStore_Complete_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _IdempotencyStore_Release_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/orkestra.proto",
+}
+
+const (
+	ArtifactStore_PutArtifact_FullMethodName = "/proto.ArtifactStore/PutArtifact"
+	ArtifactStore_GetArtifact_FullMethodName = "/proto.ArtifactStore/GetArtifact"
+)
+
+// ArtifactStoreClient is the client API for ArtifactStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Magasin d'artefacts adressés par leur contenu, exposé par le moteur aux
+// plugins via le broker go-plugin
+type ArtifactStoreClient interface {
+	PutArtifact(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ArtifactChunk, ArtifactRef], error)
+	GetArtifact(ctx context.Context, in *ArtifactRef, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error)
+}
+
+type artifactStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArtifactStoreClient(cc grpc.ClientConnInterface) ArtifactStoreClient {
+	return &artifactStoreClient{cc}
+}
+
+func (c *artifactStoreClient) PutArtifact(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ArtifactChunk, ArtifactRef], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArtifactStore_ServiceDesc.Streams[0], ArtifactStore_PutArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ArtifactChunk, ArtifactRef]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArtifactStore_PutArtifactClient = grpc.ClientStreamingClient[ArtifactChunk, ArtifactRef]
+
+func (c *artifactStoreClient) GetArtifact(ctx context.Context, in *ArtifactRef, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArtifactStore_ServiceDesc.Streams[1], ArtifactStore_GetArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ArtifactRef, ArtifactChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArtifactStore_GetArtifactClient = grpc.ServerStreamingClient[ArtifactChunk]
+
+// ArtifactStoreServer is the server API for ArtifactStore service.
+// All implementations must embed UnimplementedArtifactStoreServer
+// for forward compatibility.
+//
+// Magasin d'artefacts adressés par leur contenu, exposé par le moteur aux
+// plugins via le broker go-plugin
+type ArtifactStoreServer interface {
+	PutArtifact(grpc.ClientStreamingServer[ArtifactChunk, ArtifactRef]) error
+	GetArtifact(*ArtifactRef, grpc.ServerStreamingServer[ArtifactChunk]) error
+	mustEmbedUnimplementedArtifactStoreServer()
+}
+
+// UnimplementedArtifactStoreServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArtifactStoreServer struct{}
+
+func (UnimplementedArtifactStoreServer) PutArtifact(grpc.ClientStreamingServer[ArtifactChunk, ArtifactRef]) error {
+	return status.Errorf(codes.Unimplemented, "method PutArtifact not implemented")
+}
+func (UnimplementedArtifactStoreServer) GetArtifact(*ArtifactRef, grpc.ServerStreamingServer[ArtifactChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
+}
+func (UnimplementedArtifactStoreServer) mustEmbedUnimplementedArtifactStoreServer() {}
+func (UnimplementedArtifactStoreServer) testEmbeddedByValue()                       {}
+
+// UnsafeArtifactStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArtifactStoreServer will
+// result in compilation errors.
+type UnsafeArtifactStoreServer interface {
+	mustEmbedUnimplementedArtifactStoreServer()
+}
+
+func RegisterArtifactStoreServer(s grpc.ServiceRegistrar, srv ArtifactStoreServer) {
+	// If the following call pancis, it indicates UnimplementedArtifactStoreServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArtifactStore_ServiceDesc, srv)
+}
+
+func _ArtifactStore_PutArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ArtifactStoreServer).PutArtifact(&grpc.GenericServerStream[ArtifactChunk, ArtifactRef]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArtifactStore_PutArtifactServer = grpc.ClientStreamingServer[ArtifactChunk, ArtifactRef]
+
+func _ArtifactStore_GetArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ArtifactRef)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArtifactStoreServer).GetArtifact(m, &grpc.GenericServerStream[ArtifactRef, ArtifactChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArtifactStore_GetArtifactServer = grpc.ServerStreamingServer[ArtifactChunk]
+
+// ArtifactStore_ServiceDesc is the grpc.ServiceDesc for ArtifactStore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArtifactStore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ArtifactStore",
+	HandlerType: (*ArtifactStoreServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutArtifact",
+			Handler:       _ArtifactStore_PutArtifact_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetArtifact",
+			Handler:       _ArtifactStore_GetArtifact_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/proto/orkestra.proto",
+}
@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+)
+
+// JournalEntry identifie une exécution de nœud dans le journal.
+type JournalEntry struct {
+	RunID     string
+	NodeID    string
+	Uses      string
+	Attempt   int
+	TenantID  string
+	RequestID string
+	// Start est l'heure d'envoi de la requête au plugin.
+	Start time.Time
+}
+
+// Journal reçoit les étapes de chaque exécution passée par le client, pour
+// qu'un moteur tienne un journal durable (write-ahead) et sache, après un
+// arrêt brutal, quels nœuds étaient en cours. Les appels sont synchrones.
+//
+// Les tentatives successives d'un nœud relancé par le moteur produisent
+// chacune leur paire BeforeExecute/AfterExecute, distinguées par Attempt ;
+// OnRetry signale les renvois de la requête par le client lui-même au sein
+// d'une même tentative.
+type Journal interface {
+	// BeforeExecute est appelée avant l'envoi de la requête. Une erreur
+	// annule l'exécution : le plugin n'est pas appelé.
+	BeforeExecute(entry JournalEntry, node Node) error
+	// AfterExecute est appelée avec l'issue de l'exécution. res est nil en
+	// cas d'erreur et pour ExecuteStream. Une écriture qui échoue doit être
+	// traitée par l'implémentation : l'issue renvoyée à l'appelant n'en est
+	// pas affectée.
+	AfterExecute(entry JournalEntry, res *Result, err error)
+	// OnRetry est appelée lorsque le client renvoie la requête après l'échec
+	// cause (contexte de sorties désynchronisé, par exemple).
+	OnRetry(entry JournalEntry, cause error)
+}
+
+// journal ouvre l'entrée de node, sous l'identifiant de requête de ctx
+// (voir withRequestID), celui de l'appel gRPC. Sans Journal, il renvoie une
+// entrée nil.
+func (m *NodeExecutorGRPC) journal(node Node, ctx ExecutionContext) (ExecutionContext, *journalEntry, error) {
+	j := m.opts.Journal
+	if j == nil {
+		return ctx, nil, nil
+	}
+	ctx = withRequestID(ctx)
+	e := &journalEntry{journal: j, JournalEntry: JournalEntry{
+		RunID:     ctx.RunID,
+		NodeID:    node.ID,
+		Uses:      node.Uses,
+		Attempt:   ctx.Attempt,
+		TenantID:  ctx.TenantID,
+		RequestID: ctx.RequestID,
+		Start:     time.Now(),
+	}}
+	if err := j.BeforeExecute(e.JournalEntry, node); err != nil {
+		return ctx, nil, fmt.Errorf("failed to journal execution of node %s: %w", node.ID, err)
+	}
+	return ctx, e, nil
+}
+
+// journalEntry est une entrée ouverte. Ses méthodes acceptent un récepteur
+// nil, lorsqu'aucun Journal n'est configuré.
+type journalEntry struct {
+	journal Journal
+	JournalEntry
+}
+
+func (e *journalEntry) retry(cause error) {
+	if e != nil {
+		e.journal.OnRetry(e.JournalEntry, cause)
+	}
+}
+
+func (e *journalEntry) done(res *Result, err error) {
+	if e != nil {
+		e.journal.AfterExecute(e.JournalEntry, res, err)
+	}
+}
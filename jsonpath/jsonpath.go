@@ -0,0 +1,668 @@
+// Package jsonpath évalue des expressions JSONPath sur des valeurs Go issues
+// du décodage JSON (map[string]interface{}, []interface{}, scalaires).
+//
+// La syntaxe supportée couvre l'usage courant dans les workflows :
+//
+//	$.a.b            enfant
+//	$['a b']         enfant entre crochets
+//	$.items[0]       index (négatif depuis la fin)
+//	$.items[1:3]     tranche
+//	$.items[*]       joker (également .*)
+//	$..id            descente récursive
+//	$.items[?(@.status == 'open')]  filtre simple (==, !=, <, <=, >, >=)
+//
+// Le "$" initial est facultatif.
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound est renvoyée lorsqu'un chemin ne désigne aucune valeur.
+var ErrNotFound = errors.New("jsonpath: no value found")
+
+// SyntaxError décrit une expression invalide.
+type SyntaxError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("jsonpath: invalid expression %q at offset %d: %s", e.Expr, e.Pos, e.Msg)
+}
+
+// NotFoundError précise où l'évaluation d'un chemin a échoué. Elle satisfait
+// errors.Is(err, ErrNotFound).
+type NotFoundError struct {
+	Expr string
+	// At est le préfixe du chemin qui a pu être résolu.
+	At     string
+	Reason string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("jsonpath %s: %s at %s", e.Expr, e.Reason, e.At)
+}
+
+func (e *NotFoundError) Unwrap() error { return ErrNotFound }
+
+type stepKind int
+
+const (
+	stepChild stepKind = iota
+	stepIndex
+	stepWildcard
+	stepSlice
+	stepRecursive
+	stepFilter
+)
+
+type step struct {
+	kind       stepKind
+	names      []string
+	index      int
+	start, end *int
+	filter     *filter
+	text       string
+}
+
+type filter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+// Path est une expression compilée, réutilisable et sûre en concurrence.
+type Path struct {
+	expr  string
+	steps []step
+}
+
+// Compile analyse une expression JSONPath.
+func Compile(expr string) (*Path, error) {
+	p := &parser{expr: expr, s: strings.TrimSpace(expr)}
+	if strings.HasPrefix(p.s, "$") {
+		p.pos = 1
+	}
+	for p.pos < len(p.s) {
+		if err := p.step(); err != nil {
+			return nil, err
+		}
+	}
+	return &Path{expr: expr, steps: p.steps}, nil
+}
+
+// MustCompile est comme Compile mais panique en cas d'erreur.
+func MustCompile(expr string) *Path {
+	p, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String renvoie l'expression d'origine.
+func (p *Path) String() string { return p.expr }
+
+// Get compile expr et renvoie l'unique valeur qu'il désigne.
+func Get(v interface{}, expr string) (interface{}, error) {
+	p, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return p.First(v)
+}
+
+// Singular indique si le chemin désigne au plus une valeur (ni joker, ni
+// tranche, ni descente récursive, ni filtre).
+func (p *Path) Singular() bool {
+	for _, s := range p.steps {
+		if s.kind != stepChild && s.kind != stepIndex || len(s.names) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// First renvoie la première valeur désignée. Pour un chemin singulier,
+// l'erreur est une *NotFoundError indiquant l'étape fautive.
+func (p *Path) First(v interface{}) (interface{}, error) {
+	if p.Singular() {
+		return p.resolve(v)
+	}
+	all := p.All(v)
+	if len(all) == 0 {
+		return nil, &NotFoundError{Expr: p.expr, At: "$", Reason: "no match"}
+	}
+	return all[0], nil
+}
+
+// All renvoie toutes les valeurs désignées, dans l'ordre du document. Les
+// clés d'objets sont parcourues par ordre alphabétique pour rester
+// déterministe.
+func (p *Path) All(v interface{}) []interface{} {
+	current := []interface{}{v}
+	for _, s := range p.steps {
+		var next []interface{}
+		for _, c := range current {
+			next = append(next, apply(s, c)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func (p *Path) resolve(v interface{}) (interface{}, error) {
+	at := "$"
+	for _, s := range p.steps {
+		switch s.kind {
+		case stepChild:
+			m, ok := asMap(v)
+			if !ok {
+				return nil, &NotFoundError{Expr: p.expr, At: at, Reason: fmt.Sprintf("cannot read key %q from %s", s.names[0], kindOf(v))}
+			}
+			child, ok := m[s.names[0]]
+			if !ok {
+				return nil, &NotFoundError{Expr: p.expr, At: at, Reason: fmt.Sprintf("key %q not found", s.names[0])}
+			}
+			v = child
+		case stepIndex:
+			l, ok := asList(v)
+			if !ok {
+				return nil, &NotFoundError{Expr: p.expr, At: at, Reason: fmt.Sprintf("cannot index %s", kindOf(v))}
+			}
+			i := s.index
+			if i < 0 {
+				i += len(l)
+			}
+			if i < 0 || i >= len(l) {
+				return nil, &NotFoundError{Expr: p.expr, At: at, Reason: fmt.Sprintf("index %d out of range (length %d)", s.index, len(l))}
+			}
+			v = l[i]
+		}
+		at += s.text
+	}
+	return v, nil
+}
+
+func apply(s step, v interface{}) []interface{} {
+	switch s.kind {
+	case stepChild:
+		m, ok := asMap(v)
+		if !ok {
+			return nil
+		}
+		var out []interface{}
+		for _, name := range s.names {
+			if child, ok := m[name]; ok {
+				out = append(out, child)
+			}
+		}
+		return out
+	case stepIndex:
+		l, ok := asList(v)
+		if !ok {
+			return nil
+		}
+		i := s.index
+		if i < 0 {
+			i += len(l)
+		}
+		if i < 0 || i >= len(l) {
+			return nil
+		}
+		return []interface{}{l[i]}
+	case stepWildcard:
+		return children(v)
+	case stepSlice:
+		l, ok := asList(v)
+		if !ok {
+			return nil
+		}
+		start, end := 0, len(l)
+		if s.start != nil {
+			start = clamp(*s.start, len(l))
+		}
+		if s.end != nil {
+			end = clamp(*s.end, len(l))
+		}
+		if start >= end {
+			return nil
+		}
+		return append([]interface{}(nil), l[start:end]...)
+	case stepRecursive:
+		var out []interface{}
+		var walk func(interface{})
+		walk = func(x interface{}) {
+			if m, ok := asMap(x); ok {
+				for _, name := range s.names {
+					if child, ok := m[name]; ok {
+						out = append(out, child)
+					}
+				}
+			}
+			for _, c := range children(x) {
+				walk(c)
+			}
+		}
+		walk(v)
+		return out
+	case stepFilter:
+		var out []interface{}
+		for _, c := range children(v) {
+			if s.filter.match(c) {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func clamp(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+func children(v interface{}) []interface{} {
+	if l, ok := asList(v); ok {
+		return l
+	}
+	m, ok := asMap(v)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]interface{}, len(keys))
+	for i, k := range keys {
+		out[i] = m[k]
+	}
+	return out
+}
+
+func (f *filter) match(v interface{}) bool {
+	for _, name := range f.path {
+		m, ok := asMap(v)
+		if !ok {
+			return false
+		}
+		if v, ok = m[name]; !ok {
+			return false
+		}
+	}
+	if f.op == "" {
+		return true
+	}
+	if a, ok := toFloat(v); ok {
+		if b, ok := toFloat(f.value); ok {
+			return compareOrdered(a, b, f.op)
+		}
+	}
+	if a, ok := v.(string); ok {
+		if b, ok := f.value.(string); ok {
+			return compareOrdered(a, b, f.op)
+		}
+	}
+	switch f.op {
+	case "==":
+		return reflect.DeepEqual(v, f.value)
+	case "!=":
+		return !reflect.DeepEqual(v, f.value)
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](a, b T, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+func asList(v interface{}) ([]interface{}, bool) {
+	if l, ok := v.([]interface{}); ok {
+		return l, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	l := make([]interface{}, rv.Len())
+	for i := range l {
+		l[i] = rv.Index(i).Interface()
+	}
+	return l, true
+}
+
+func kindOf(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	if _, ok := asMap(v); ok {
+		return "object"
+	}
+	if _, ok := asList(v); ok {
+		return "array"
+	}
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := toFloat(v); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// --- Analyse syntaxique ---
+
+type parser struct {
+	expr  string
+	s     string
+	pos   int
+	steps []step
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Expr: p.expr, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) step() error {
+	start := p.pos
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], ".."):
+		p.pos += 2
+		name := p.ident()
+		if name == "" {
+			return p.errorf("expected a name after '..'")
+		}
+		p.push(step{kind: stepRecursive, names: []string{name}}, start)
+	case p.s[p.pos] == '.':
+		p.pos++
+		if p.pos < len(p.s) && p.s[p.pos] == '*' {
+			p.pos++
+			p.push(step{kind: stepWildcard}, start)
+			return nil
+		}
+		name := p.ident()
+		if name == "" {
+			return p.errorf("expected a name after '.'")
+		}
+		p.push(step{kind: stepChild, names: []string{name}}, start)
+	case p.s[p.pos] == '[':
+		end := p.closing()
+		if end < 0 {
+			return p.errorf("unterminated '['")
+		}
+		inner := strings.TrimSpace(p.s[p.pos+1 : end])
+		p.pos = end + 1
+		s, err := p.bracket(inner)
+		if err != nil {
+			return err
+		}
+		p.push(s, start)
+	default:
+		if len(p.steps) == 0 && start == 0 {
+			name := p.ident()
+			if name != "" {
+				p.push(step{kind: stepChild, names: []string{name}}, start)
+				return nil
+			}
+		}
+		return p.errorf("unexpected character %q", p.s[p.pos])
+	}
+	return nil
+}
+
+func (p *parser) push(s step, start int) {
+	s.text = p.s[start:p.pos]
+	if !strings.HasPrefix(s.text, ".") && !strings.HasPrefix(s.text, "[") {
+		s.text = "." + s.text
+	}
+	p.steps = append(p.steps, s)
+}
+
+func (p *parser) ident() string {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(".[", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// closing renvoie la position du ']' correspondant en ignorant les chaînes.
+func (p *parser) closing() int {
+	var quote byte
+	depth := 0
+	for i := p.pos; i < len(p.s); i++ {
+		c := p.s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (p *parser) bracket(inner string) (step, error) {
+	switch {
+	case inner == "*":
+		return step{kind: stepWildcard}, nil
+	case strings.HasPrefix(inner, "?"):
+		f, err := p.filter(inner[1:])
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: stepFilter, filter: f}, nil
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		var names []string
+		for _, part := range splitOutsideQuotes(inner, ',') {
+			name, ok := unquote(strings.TrimSpace(part))
+			if !ok {
+				return step{}, p.errorf("invalid quoted name %s", part)
+			}
+			names = append(names, name)
+		}
+		return step{kind: stepChild, names: names}, nil
+	case strings.Contains(inner, ":"):
+		parts := strings.SplitN(inner, ":", 2)
+		var s step
+		s.kind = stepSlice
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return step{}, p.errorf("invalid slice bound %q", part)
+			}
+			if i == 0 {
+				s.start = &n
+			} else {
+				s.end = &n
+			}
+		}
+		return s, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return step{}, p.errorf("invalid index %q", inner)
+		}
+		return step{kind: stepIndex, index: n}, nil
+	}
+}
+
+func (p *parser) filter(expr string) (*filter, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return nil, p.errorf("filter must be of the form ?(...)")
+	}
+	expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	if !strings.HasPrefix(expr, "@") {
+		return nil, p.errorf("filter must start with '@'")
+	}
+	f := &filter{}
+	lhs := expr
+	if i, op := findOperator(expr); i >= 0 {
+		lhs = strings.TrimSpace(expr[:i])
+		f.op = op
+		lit := strings.TrimSpace(expr[i+len(op):])
+		v, err := parseLiteral(lit)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		f.value = v
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(lhs, "@"), ".") {
+		if part != "" {
+			f.path = append(f.path, part)
+		}
+	}
+	return f, nil
+}
+
+// findOperator renvoie la position et l'opérateur de comparaison le plus à
+// gauche de expr, hors des chaînes entre guillemets ; -1 s'il n'y en a pas.
+func findOperator(expr string) (int, string) {
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		default:
+			for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+				if strings.HasPrefix(expr[i:], op) {
+					return i, op
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+func parseLiteral(s string) (interface{}, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if v, ok := unquote(s); ok {
+		return v, nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid literal %q", s)
+	}
+	return n, nil
+}
+
+func unquote(s string) (string, bool) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", false
+	}
+	return s[1 : len(s)-1], true
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
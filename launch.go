@@ -0,0 +1,258 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-plugin/runner"
+)
+
+// DefaultPluginKey est la clé sous laquelle NodeExecutorPlugin est servi et
+// distribué lorsque LaunchOptions.PluginKey n'est pas renseigné.
+const DefaultPluginKey = "executor"
+
+// ResourceLimits borne les ressources d'un processus plugin. Elles sont
+// appliquées via les cgroups v2 sous Linux et les job objects sous Windows ;
+// sur les autres systèmes, Launch échoue si une limite est demandée.
+type ResourceLimits struct {
+	// MemoryBytes est la mémoire maximale. Zéro : pas de limite.
+	MemoryBytes int64
+	// CPUs est le nombre de cœurs utilisables (1.5 = un cœur et demi). Zéro :
+	// pas de limite.
+	CPUs float64
+	// MaxProcesses borne le nombre de processus (ou de threads sous Linux).
+	// Zéro : pas de limite.
+	MaxProcesses int
+	// CgroupParent est le cgroup v2 sous lequel créer celui du plugin ; il
+	// doit être délégué à l'utilisateur du moteur. Linux uniquement, par
+	// défaut /sys/fs/cgroup/orkestra.
+	CgroupParent string
+}
+
+func (l ResourceLimits) isZero() bool {
+	return l.MemoryBytes == 0 && l.CPUs == 0 && l.MaxProcesses == 0
+}
+
+// LaunchOptions paramètre le lancement d'un processus plugin.
+type LaunchOptions struct {
+	Args []string
+	// Env est l'environnement du plugin. L'environnement du moteur n'est pas
+	// transmis, à l'exception des variables nommées dans InheritEnv.
+	Env        []string
+	InheritEnv []string
+	// Dir est le répertoire de travail. S'il est vide et que IsolateDir est
+	// vrai, un répertoire temporaire dédié est créé puis supprimé à l'arrêt.
+	Dir        string
+	IsolateDir bool
+	Limits     ResourceLimits
+	// PluginKey est la clé du plugin dans la table go-plugin, DefaultPluginKey
+	// par défaut.
+	PluginKey string
+	// Executors, s'il est renseigné, désigne les exécuteurs d'un plugin
+	// multiplexé (MultiplexPlugin) : un seul processus et une seule connexion
+	// les servent tous, distribués dans LaunchedPlugin.Executors.
+	Executors     []string
+	ClientOptions ClientOptions
+}
+
+// LaunchedPlugin est un processus plugin démarré par Launch.
+type LaunchedPlugin struct {
+	Client *plugin.Client
+	// Executor est l'exécuteur du plugin. Pour un plugin multiplexé, c'est
+	// celui de DefaultPluginKey s'il fait partie de Executors.
+	Executor  *NodeExecutorGRPC
+	Executors map[string]*NodeExecutorGRPC
+
+	sandbox *sandbox
+	tempDir string
+}
+
+// Launch démarre le plugin path avec les restrictions de opts et renvoie son
+// exécuteur. Kill doit être appelée pour arrêter le processus et libérer les
+// ressources associées.
+func Launch(path string, opts LaunchOptions) (*LaunchedPlugin, error) {
+	key := opts.PluginKey
+	if key == "" {
+		key = DefaultPluginKey
+	}
+	p := &LaunchedPlugin{}
+
+	cmd := exec.Command(path, opts.Args...)
+	cmd.Env = append(cmd.Env, opts.Env...)
+	for _, name := range opts.InheritEnv {
+		if v, ok := os.LookupEnv(name); ok {
+			cmd.Env = append(cmd.Env, name+"="+v)
+		}
+	}
+	cmd.Dir = opts.Dir
+	if cmd.Dir == "" && opts.IsolateDir {
+		dir, err := os.MkdirTemp("", "orkestra-plugin-"+filepath.Base(path)+"-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create plugin working directory: %w", err)
+		}
+		p.tempDir, cmd.Dir = dir, dir
+	}
+
+	if !opts.Limits.isZero() {
+		sb, err := newSandbox(filepath.Base(path), opts.Limits, cmd)
+		if err != nil {
+			p.cleanup()
+			return nil, fmt.Errorf("failed to apply resource limits: %w", err)
+		}
+		p.sandbox = sb
+	}
+
+	config := &plugin.ClientConfig{
+		HandshakeConfig:  HandshakeConfig,
+		Plugins:          pluginSet(key, opts.Executors, opts.ClientOptions),
+		Cmd:              cmd,
+		SkipHostEnv:      true,
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+	}
+	if p.sandbox != nil {
+		// Le processus doit être rattaché au sandbox avant d'exécuter quoi
+		// que ce soit, donc avant le handshake : go-plugin ne le permet
+		// qu'avec son propre Runner.
+		config.Cmd = nil
+		config.RunnerFunc = func(l hclog.Logger, spec *exec.Cmd, tmpDir string) (runner.Runner, error) {
+			cmd.Env = append(cmd.Env, spec.Env...)
+			cmd.Stdin = spec.Stdin
+			return &sandboxRunner{cmd: cmd, sandbox: p.sandbox}, nil
+		}
+	}
+	if err := p.start(path, key, opts.Executors, config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// pluginSet renvoie la table go-plugin du moteur : un MultiplexPlugin si
+// executors est renseigné, un NodeExecutorPlugin sinon.
+func pluginSet(key string, executors []string, opts ClientOptions) plugin.PluginSet {
+	if len(executors) > 0 {
+		return plugin.PluginSet{key: &MultiplexPlugin{ClientOptions: opts}}
+	}
+	return plugin.PluginSet{key: &NodeExecutorPlugin{ClientOptions: opts}}
+}
+
+// start démarre le client go-plugin, appelle started une fois le processus
+// lancé puis distribue l'exécuteur, ou chacun de executors. En cas d'erreur,
+// le plugin est arrêté.
+func (p *LaunchedPlugin) start(name, key string, executors []string, config *plugin.ClientConfig) error {
+	p.Client = plugin.NewClient(config)
+	rpcClient, err := p.Client.Client()
+	if err != nil {
+		p.Kill()
+		return fmt.Errorf("failed to start plugin %s: %w", name, err)
+	}
+	raw, err := rpcClient.Dispense(key)
+	if err != nil {
+		p.Kill()
+		return fmt.Errorf("failed to dispense plugin %s: %w", name, err)
+	}
+	switch c := raw.(type) {
+	case *NodeExecutorGRPC:
+		p.Executor = c
+	case *MultiplexClient:
+		p.Executors = make(map[string]*NodeExecutorGRPC, len(executors))
+		for _, k := range executors {
+			executor, err := c.Executor(k)
+			if err != nil {
+				p.Kill()
+				return fmt.Errorf("failed to dispense plugin %s: %w", name, err)
+			}
+			p.Executors[k] = executor
+		}
+		p.Executor = p.Executors[DefaultPluginKey]
+	default:
+		p.Kill()
+		return fmt.Errorf("plugin %s dispensed unexpected type %T", name, raw)
+	}
+	return nil
+}
+
+// Kill arrête le processus plugin et libère son sandbox et son répertoire de
+// travail temporaire.
+func (p *LaunchedPlugin) Kill() {
+	if p.Client != nil {
+		p.Client.Kill()
+	}
+	p.cleanup()
+}
+
+func (p *LaunchedPlugin) cleanup() {
+	if p.sandbox != nil {
+		p.sandbox.close()
+		p.sandbox = nil
+	}
+	if p.tempDir != "" {
+		os.RemoveAll(p.tempDir)
+		p.tempDir = ""
+	}
+}
+
+// sandboxRunner démarre cmd et le rattache au sandbox avant de le laisser
+// s'exécuter (voir sandbox.attach).
+type sandboxRunner struct {
+	cmd     *exec.Cmd
+	sandbox *sandbox
+	stdout  io.ReadCloser
+	stderr  io.ReadCloser
+}
+
+func (r *sandboxRunner) Start(ctx context.Context) error {
+	var err error
+	if r.stdout, err = r.cmd.StdoutPipe(); err != nil {
+		return err
+	}
+	if r.stderr, err = r.cmd.StderrPipe(); err != nil {
+		return err
+	}
+	if err := r.cmd.Start(); err != nil {
+		return err
+	}
+	if err := r.sandbox.attach(r.cmd.Process); err != nil {
+		r.cmd.Process.Kill()
+		r.cmd.Wait()
+		return fmt.Errorf("failed to apply resource limits: %w", err)
+	}
+	return nil
+}
+
+func (r *sandboxRunner) Diagnose(ctx context.Context) string { return "" }
+func (r *sandboxRunner) Stdout() io.ReadCloser               { return r.stdout }
+func (r *sandboxRunner) Stderr() io.ReadCloser               { return r.stderr }
+func (r *sandboxRunner) Name() string                        { return r.cmd.Path }
+
+func (r *sandboxRunner) ID() string {
+	if r.cmd.Process == nil {
+		return ""
+	}
+	return strconv.Itoa(r.cmd.Process.Pid)
+}
+
+func (r *sandboxRunner) Wait(ctx context.Context) error {
+	return r.cmd.Wait()
+}
+
+func (r *sandboxRunner) Kill(ctx context.Context) error {
+	if r.cmd.Process != nil {
+		return r.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (r *sandboxRunner) PluginToHost(network, addr string) (string, string, error) {
+	return network, addr, nil
+}
+
+func (r *sandboxRunner) HostToPlugin(network, addr string) (string, string, error) {
+	return network, addr, nil
+}
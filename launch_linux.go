@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+const defaultCgroupParent = "/sys/fs/cgroup/orkestra"
+
+// cpuPeriod est la période de cpu.max, en microsecondes.
+const cpuPeriod = 100000
+
+// sandbox est un cgroup v2 dédié au processus plugin. Le processus y est
+// placé dès sa création grâce à CgroupFD.
+type sandbox struct {
+	dir string
+	fd  *os.File
+}
+
+func newSandbox(name string, l ResourceLimits, cmd *exec.Cmd) (*sandbox, error) {
+	parent := l.CgroupParent
+	if parent == "" {
+		parent = defaultCgroupParent
+	}
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return nil, err
+	}
+	// Sans effet si les contrôleurs sont déjà délégués.
+	_ = os.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte("+cpu +memory +pids"), 0)
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(parent, name+"-"+hex.EncodeToString(suffix))
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return nil, err
+	}
+	sb := &sandbox{dir: dir}
+
+	var settings [][2]string
+	if l.MemoryBytes > 0 {
+		settings = append(settings, [2]string{"memory.max", fmt.Sprint(l.MemoryBytes)})
+	}
+	if l.CPUs > 0 {
+		settings = append(settings, [2]string{"cpu.max", fmt.Sprintf("%d %d", int64(l.CPUs*cpuPeriod), cpuPeriod)})
+	}
+	if l.MaxProcesses > 0 {
+		settings = append(settings, [2]string{"pids.max", fmt.Sprint(l.MaxProcesses)})
+	}
+	for _, s := range settings {
+		if err := os.WriteFile(filepath.Join(dir, s[0]), []byte(s[1]), 0); err != nil {
+			sb.close()
+			return nil, fmt.Errorf("cgroup %s: %w", s[0], err)
+		}
+	}
+
+	fd, err := os.Open(dir)
+	if err != nil {
+		sb.close()
+		return nil, err
+	}
+	sb.fd = fd
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(fd.Fd())
+	return sb, nil
+}
+
+func (s *sandbox) attach(*os.Process) error {
+	return nil
+}
+
+func (s *sandbox) close() error {
+	if s.fd != nil {
+		s.fd.Close()
+	}
+	// Échoue tant que le cgroup contient des processus.
+	return os.Remove(s.dir)
+}
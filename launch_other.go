@@ -0,0 +1,23 @@
+//go:build !linux && !windows
+
+package shared
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+type sandbox struct{}
+
+func newSandbox(name string, l ResourceLimits, cmd *exec.Cmd) (*sandbox, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (s *sandbox) attach(*os.Process) error {
+	return nil
+}
+
+func (s *sandbox) close() error {
+	return nil
+}
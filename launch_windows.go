@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// jobCPURateControl correspond à JOBOBJECT_CPU_RATE_CONTROL_INFORMATION.
+type jobCPURateControl struct {
+	ControlFlags uint32
+	CPURate      uint32
+}
+
+const (
+	jobCPURateControlEnable  = 0x1
+	jobCPURateControlHardCap = 0x4
+)
+
+// sandbox est un job object auquel le processus plugin est rattaché. Le
+// processus est créé suspendu et ne reprend qu'une fois rattaché, pour qu'il
+// ne s'exécute jamais hors des limites. Fermer le job tue le processus.
+type sandbox struct {
+	job windows.Handle
+}
+
+func newSandbox(name string, l ResourceLimits, cmd *exec.Cmd) (*sandbox, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	sb := &sandbox{job: job}
+
+	var info windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if l.MemoryBytes > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = uintptr(l.MemoryBytes)
+	}
+	if l.MaxProcesses > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_ACTIVE_PROCESS
+		info.BasicLimitInformation.ActiveProcessLimit = uint32(l.MaxProcesses)
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		sb.close()
+		return nil, err
+	}
+
+	if l.CPUs > 0 {
+		// Le taux est exprimé en centièmes de pourcent de l'ensemble des cœurs.
+		rate := uint32(l.CPUs / float64(runtime.NumCPU()) * 10000)
+		if rate < 1 {
+			rate = 1
+		}
+		if rate > 10000 {
+			rate = 10000
+		}
+		cpu := jobCPURateControl{ControlFlags: jobCPURateControlEnable | jobCPURateControlHardCap, CPURate: rate}
+		if _, err := windows.SetInformationJobObject(job, windows.JobObjectCpuRateControlInformation,
+			uintptr(unsafe.Pointer(&cpu)), uint32(unsafe.Sizeof(cpu))); err != nil {
+			sb.close()
+			return nil, err
+		}
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_SUSPENDED
+	return sb, nil
+}
+
+// attach rattache le processus, créé suspendu, au job object puis le
+// reprend.
+func (s *sandbox) attach(p *os.Process) error {
+	h, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(p.Pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(h)
+	if err := windows.AssignProcessToJobObject(s.job, h); err != nil {
+		return fmt.Errorf("assign process to job object: %w", err)
+	}
+	return resumeProcess(uint32(p.Pid))
+}
+
+// resumeProcess reprend les threads du processus pid, créé suspendu.
+func resumeProcess(pid uint32) error {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return fmt.Errorf("snapshot threads: %w", err)
+	}
+	defer windows.CloseHandle(snap)
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	resumed := false
+	for err = windows.Thread32First(snap, &entry); err == nil; err = windows.Thread32Next(snap, &entry) {
+		if entry.OwnerProcessID != pid {
+			continue
+		}
+		t, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return fmt.Errorf("open thread %d: %w", entry.ThreadID, err)
+		}
+		_, err = windows.ResumeThread(t)
+		windows.CloseHandle(t)
+		if err != nil {
+			return fmt.Errorf("resume thread %d: %w", entry.ThreadID, err)
+		}
+		resumed = true
+	}
+	if !resumed {
+		return fmt.Errorf("no thread to resume in process %d", pid)
+	}
+	return nil
+}
+
+func (s *sandbox) close() error {
+	return windows.CloseHandle(s.job)
+}
@@ -0,0 +1,211 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPluginClosed est renvoyée par un LazyPlugin après Close.
+var ErrPluginClosed = errors.New("plugin closed")
+
+// LazyOptions paramètre un LazyPlugin.
+type LazyOptions struct {
+	// IdleTimeout arrête le processus lorsqu'aucun appel n'a eu lieu depuis
+	// cette durée ; il est relancé au suivant. Zéro : jamais.
+	IdleTimeout time.Duration
+}
+
+// LazyPlugin ne démarre son processus plugin qu'au premier appel, ou à
+// Prewarm, et peut l'arrêter lorsqu'il reste inutilisé : un moteur qui
+// compte des centaines de plugins installés ne garde résidents que ceux
+// qui servent. Il implémente NodeExecutor.
+type LazyPlugin struct {
+	launch func() (*LaunchedPlugin, error)
+	opts   LazyOptions
+
+	mu       sync.Mutex
+	plugin   *LaunchedPlugin
+	inFlight int
+	lastUsed time.Time
+	idle     *time.Timer
+	closed   bool
+	// caps est la dernière liste de capacités lue, pour répondre à
+	// GetCapabilities sans relancer le processus.
+	caps []string
+}
+
+// NewLazyPlugin renvoie un LazyPlugin qui démarre son processus avec launch,
+// dont LaunchedPlugin.Executor doit être renseigné, par exemple :
+//
+//	NewLazyPlugin(func() (*LaunchedPlugin, error) { return Launch(path, opts) }, LazyOptions{})
+func NewLazyPlugin(launch func() (*LaunchedPlugin, error), opts LazyOptions) *LazyPlugin {
+	return &LazyPlugin{launch: launch, opts: opts}
+}
+
+// Prewarm démarre le processus s'il ne tourne pas, en prévision d'un appel
+// prochain. Le délai d'inactivité court à partir de Prewarm.
+func (l *LazyPlugin) Prewarm() error {
+	_, release, err := l.acquire()
+	if err != nil {
+		return err
+	}
+	release()
+	return nil
+}
+
+// Running indique si le processus plugin tourne.
+func (l *LazyPlugin) Running() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.running()
+}
+
+func (l *LazyPlugin) Execute(node Node, ctx ExecutionContext) (interface{}, error) {
+	executor, release, err := l.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return executor.Execute(node, ctx)
+}
+
+// ExecuteResult est comme NodeExecutorGRPC.ExecuteResult.
+func (l *LazyPlugin) ExecuteResult(node Node, ctx ExecutionContext) (*Result, error) {
+	executor, release, err := l.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return executor.ExecuteResult(node, ctx)
+}
+
+// ExecuteStream est comme NodeExecutorGRPC.ExecuteStream.
+func (l *LazyPlugin) ExecuteStream(node Node, ctx ExecutionContext, emit func(item interface{}) error) error {
+	executor, release, err := l.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return executor.ExecuteStream(node, ctx, emit)
+}
+
+// GetCapabilities renvoie les capacités du plugin. Une fois lues, elles sont
+// conservées et ne provoquent plus de lancement.
+func (l *LazyPlugin) GetCapabilities() ([]string, error) {
+	l.mu.Lock()
+	caps := l.caps
+	l.mu.Unlock()
+	if caps != nil {
+		return caps, nil
+	}
+	executor, release, err := l.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	caps, err = executor.GetCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	l.caps = caps
+	l.mu.Unlock()
+	return caps, nil
+}
+
+// Close arrête le processus plugin. Les appels suivants échouent avec
+// ErrPluginClosed.
+func (l *LazyPlugin) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	l.stop()
+}
+
+// acquire renvoie l'exécuteur, en démarrant le processus si besoin. release
+// doit être appelée à la fin de l'appel.
+func (l *LazyPlugin) acquire() (*NodeExecutorGRPC, func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrPluginClosed
+	}
+	if !l.running() {
+		l.stop()
+		p, err := l.launch()
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.Executor == nil {
+			// Plugin multiplexé sans DefaultPluginKey parmi ses exécuteurs.
+			p.Kill()
+			return nil, nil, fmt.Errorf("lazy plugin: launched plugin has no %q executor", DefaultPluginKey)
+		}
+		l.plugin = p
+	}
+	if l.idle != nil {
+		l.idle.Stop()
+		l.idle = nil
+	}
+	l.inFlight++
+	executor := l.plugin.Executor
+	return executor, func() { l.release() }, nil
+}
+
+func (l *LazyPlugin) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.lastUsed = time.Now()
+	if l.inFlight == 0 && l.opts.IdleTimeout > 0 && l.plugin != nil {
+		l.idle = time.AfterFunc(l.opts.IdleTimeout, l.reapIdle)
+	}
+}
+
+// idleSince renvoie la date du dernier appel si le processus tourne sans
+// appel en cours.
+func (l *LazyPlugin) idleSince() (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastUsed, l.running() && l.inFlight == 0
+}
+
+// stopIfIdleSince arrête le processus s'il n'a pas servi depuis since, date
+// lue par idleSince.
+func (l *LazyPlugin) stopIfIdleSince(since time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.running() || l.inFlight > 0 || !l.lastUsed.Equal(since) {
+		return false
+	}
+	l.stop()
+	return true
+}
+
+// reapIdle arrête le processus s'il est resté inactif depuis IdleTimeout.
+func (l *LazyPlugin) reapIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inFlight == 0 && time.Since(l.lastUsed) >= l.opts.IdleTimeout {
+		l.stop()
+	}
+}
+
+// running indique si le processus tourne ; un processus sorti de lui-même
+// sera relancé au prochain appel.
+func (l *LazyPlugin) running() bool {
+	return l.plugin != nil && !l.plugin.Client.Exited()
+}
+
+func (l *LazyPlugin) stop() {
+	if l.idle != nil {
+		l.idle.Stop()
+		l.idle = nil
+	}
+	if l.plugin != nil {
+		l.plugin.Kill()
+		l.plugin = nil
+	}
+}
@@ -0,0 +1,38 @@
+package shared
+
+import "fmt"
+
+// DefaultMaxNodeDepth est la profondeur d'imbrication maximale (via Do,
+// OnFailure et Compensate) acceptée par les conversions gRPC lorsque
+// ClientOptions.MaxNodeDepth ou ServerOptions.MaxNodeDepth n'est pas
+// renseigné. Un workflow plus profond est rejeté avec une *DepthError.
+const DefaultMaxNodeDepth = 64
+
+// DepthError est renvoyée lorsqu'un arbre de nœuds dépasse la profondeur
+// maximale.
+type DepthError struct {
+	// NodeID est le nœud situé à la profondeur maximale.
+	NodeID string
+	Max    int
+}
+
+func (e *DepthError) Error() string {
+	return fmt.Sprintf("node %q: children exceed the maximum nesting depth of %d", e.NodeID, e.Max)
+}
+
+// maxNodeDepth renvoie la profondeur effective pour le réglage n.
+func maxNodeDepth(n int) int {
+	if n > 0 {
+		return n
+	}
+	return DefaultMaxNodeDepth
+}
+
+// checkDepth vérifie que les enfants d'un nœud situé à depth (la racine étant
+// à 1) restent dans la limite max.
+func checkDepth(nodeID string, depth, max int) error {
+	if depth >= max {
+		return &DepthError{NodeID: nodeID, Max: max}
+	}
+	return nil
+}
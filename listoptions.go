@@ -0,0 +1,134 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// OptionsQuery demande les valeurs possibles d'un champ de With, pour
+// alimenter une liste déroulante de l'éditeur (canaux Slack, tables d'une
+// base, bases Airtable).
+type OptionsQuery struct {
+	Uses  string
+	Field string
+	// Connection est la connexion choisie pour le nœud en cours d'édition,
+	// vide si aucune. Le client la résout comme pour Execute.
+	Connection string
+	// Query est la recherche saisie par l'utilisateur, vide pour tout lister.
+	Query     string
+	PageToken string
+	// Limit est le nombre de valeurs souhaité par page, zéro pour laisser
+	// le plugin choisir.
+	Limit int
+}
+
+// Option est une valeur proposée pour un champ.
+type Option struct {
+	// Value est la valeur écrite dans With.
+	Value string
+	// Label est le libellé affiché, Value s'il est vide.
+	Label       string
+	Description string
+}
+
+// OptionsPage est une page de valeurs proposées.
+type OptionsPage struct {
+	Options []Option
+	// NextPageToken est à repasser dans OptionsQuery.PageToken pour obtenir
+	// la page suivante, vide pour la dernière.
+	NextPageToken string
+}
+
+// OptionsLister peut être implémentée par un NodeExecutor pour proposer les
+// valeurs d'un champ depuis l'API de l'intégration. ctx porte le locataire,
+// les secrets et la connexion résolue, comme pour Execute. Un champ sans
+// valeurs dynamiques renvoie une erreur CodeNotFound.
+type OptionsLister interface {
+	ListOptions(query OptionsQuery, ctx ExecutionContext) (OptionsPage, error)
+}
+
+// ListOptions demande au plugin les valeurs possibles d'un champ, après les
+// mêmes contrôles de permissions et de politique qu'Execute. Les plugins qui
+// ne le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) ListOptions(query OptionsQuery, ctx ExecutionContext) (OptionsPage, error) {
+	node := Node{Uses: query.Uses, Connection: query.Connection}
+	if err := m.checkAccess(node, ctx); err != nil {
+		return OptionsPage{}, err
+	}
+	ctx, err := m.resolveConnection(node, ctx)
+	if err != nil {
+		return OptionsPage{}, err
+	}
+	if m.opts.IsolateTenantSecrets {
+		if ctx, err = isolateTenantSecrets(ctx); err != nil {
+			return OptionsPage{}, err
+		}
+	}
+	pCtx, err := toProtoExecutionContext(m.codec(), &ctx)
+	if err != nil {
+		return OptionsPage{}, fmt.Errorf("failed to convert context for gRPC: %w", err)
+	}
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.ListOptions(callCtx, &proto.ListOptionsRequest{
+		Uses:       query.Uses,
+		Field:      query.Field,
+		Connection: query.Connection,
+		Query:      query.Query,
+		PageToken:  query.PageToken,
+		Limit:      int32(query.Limit),
+		Context:    pCtx,
+	})
+	if err != nil {
+		return OptionsPage{}, withTimeout(fromRPCError("ListOptions", err), m.opts.callTimeout())
+	}
+	return fromProtoOptionsPage(resp), nil
+}
+
+func (s *NodeExecutorGRPCServer) ListOptions(ctx context.Context, req *proto.ListOptionsRequest) (*proto.ListOptionsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	l, ok := implementation[OptionsLister](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.ListOptions(ctx, req)
+	}
+	var execCtx ExecutionContext
+	if req.Context != nil {
+		var err error
+		if execCtx, err = fromProtoExecutionContext(codecOrDefault(s.options.Codec), req.Context); err != nil {
+			return nil, err
+		}
+	}
+	query := OptionsQuery{
+		Uses:       req.Uses,
+		Field:      req.Field,
+		Connection: req.Connection,
+		Query:      req.Query,
+		PageToken:  req.PageToken,
+		Limit:      int(req.Limit),
+	}
+	page, err := l.ListOptions(query, execCtx)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	return toProtoOptionsPage(page), nil
+}
+
+func toProtoOptionsPage(p OptionsPage) *proto.ListOptionsResponse {
+	options := make([]*proto.Option, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = &proto.Option{Value: o.Value, Label: o.Label, Description: o.Description}
+	}
+	return &proto.ListOptionsResponse{Options: options, NextPageToken: p.NextPageToken}
+}
+
+func fromProtoOptionsPage(p *proto.ListOptionsResponse) OptionsPage {
+	options := make([]Option, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = Option{Value: o.Value, Label: o.Label, Description: o.Description}
+	}
+	return OptionsPage{Options: options, NextPageToken: p.NextPageToken}
+}
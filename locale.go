@@ -0,0 +1,102 @@
+package shared
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LocalizedText est le nom et la description d'une capacité dans une
+// langue.
+type LocalizedText struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Text renvoie le nom et la description de la capacité dans la première
+// langue de langs pour laquelle une traduction existe. Chaque étiquette
+// BCP-47 est essayée de la plus précise à la plus générale (« fr-CA » puis
+// « fr ») ; les champs non traduits reprennent DisplayName et Description.
+// langs suit typiquement l'ordre de l'en-tête Accept-Language.
+func (c *CapabilitySpec) Text(langs ...string) LocalizedText {
+	t := LocalizedText{DisplayName: c.DisplayName, Description: c.Description}
+	for _, lang := range langs {
+		for _, tag := range fallbackChain(lang) {
+			if l, ok := lookupLocale(c.Localized, tag); ok {
+				if l.DisplayName != "" {
+					t.DisplayName = l.DisplayName
+				}
+				if l.Description != "" {
+					t.Description = l.Description
+				}
+				return t
+			}
+		}
+	}
+	return t
+}
+
+// fallbackChain renvoie tag puis ses préfixes successifs : « zh-Hant-TW »,
+// « zh-Hant », « zh ». Les sous-étiquettes d'un seul caractère (extensions,
+// « x- ») sont retirées avec ce qui les suit.
+func fallbackChain(tag string) []string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, p := range parts {
+		if len(p) == 1 {
+			parts = parts[:i]
+			break
+		}
+	}
+	var chain []string
+	for i := len(parts); i > 0; i-- {
+		if t := strings.Join(parts[:i], "-"); t != "" {
+			chain = append(chain, t)
+		}
+	}
+	return chain
+}
+
+// lookupLocale cherche tag dans m sans tenir compte de la casse, comme le
+// veut BCP-47.
+func lookupLocale(m map[string]LocalizedText, tag string) (LocalizedText, bool) {
+	if l, ok := m[tag]; ok {
+		return l, true
+	}
+	for k, l := range m {
+		if strings.EqualFold(k, tag) {
+			return l, true
+		}
+	}
+	return LocalizedText{}, false
+}
+
+// ParseAcceptLanguage renvoie les étiquettes d'un en-tête Accept-Language,
+// par ordre de préférence décroissante. Les poids nuls et « * » sont
+// ignorés.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if _, err := fmt.Sscanf(v, "%g", &q); err != nil {
+				continue
+			}
+		}
+		if tag == "" || tag == "*" || q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag, q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
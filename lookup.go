@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/jsonpath"
+)
+
+// document expose le contexte sous la forme utilisée par les expressions :
+// trigger, steps (sorties des nœuds), item et failure.
+func (c ExecutionContext) document() map[string]interface{} {
+	return map[string]interface{}{
+		"trigger": c.TriggerData,
+		"steps":   c.NodeOutputs,
+		"item":    c.CurrentItem,
+		"failure": c.FailureData,
+	}
+}
+
+// Lookup évalue une expression JSONPath sur le contexte, par exemple
+// "steps.fetch.body.items[0].id" ou "$.trigger.headers['X-Request-Id']".
+func (c ExecutionContext) Lookup(expr string) (interface{}, error) {
+	return jsonpath.Get(c.document(), expr)
+}
+
+// LookupAs évalue expr sur le contexte et convertit le résultat en T. Les
+// valeurs qui ne sont pas directement de type T sont converties via JSON
+// (utile pour relire une sortie dans une structure).
+func LookupAs[T any](c ExecutionContext, expr string) (T, error) {
+	var out T
+	v, err := c.Lookup(expr)
+	if err != nil {
+		return out, err
+	}
+	if typed, ok := v.(T); ok {
+		return typed, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return out, fmt.Errorf("value at %s is not a %T: %w", expr, out, err)
+	}
+	return out, nil
+}
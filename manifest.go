@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Manifest décrit ce qu'un plugin attend du moteur. Il est transmis en JSON
+// dans la réponse à GetInfo.
+type Manifest struct {
+	// MinEngineVersion est la version minimale du moteur supportée.
+	MinEngineVersion string `json:"minEngineVersion,omitempty"`
+	// Requires liste les fonctionnalités du protocole dont le plugin a
+	// besoin côté moteur.
+	Requires []Feature `json:"requires,omitempty"`
+	// Capabilities décrit chacune des capacités listées par GetCapabilities.
+	Capabilities []CapabilitySpec `json:"capabilities,omitempty"`
+	// MaxConcurrency est le nombre maximal d'exécutions simultanées que le
+	// plugin supporte : 1 s'il n'est pas sûr en concurrence. Zéro : pas de
+	// limite.
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// Settings décrit les réglages globaux acceptés par Configure.
+	Settings []SettingSpec `json:"settings,omitempty"`
+	// DefaultTimeout est la durée accordée par défaut aux exécutions des
+	// capacités du plugin, au format time.ParseDuration ("30s", "30m").
+	// Voir Manifest.Timeout.
+	DefaultTimeout string `json:"defaultTimeout,omitempty"`
+
+	// Métadonnées de la place de marché.
+	Vendor string `json:"vendor,omitempty"`
+	// Icon est une URL https ou une URI data (base64) d'image.
+	Icon             string      `json:"icon,omitempty"`
+	DocumentationURL string      `json:"documentationUrl,omitempty"`
+	PricingTier      PricingTier `json:"pricingTier,omitempty"`
+	// Policy décrit le traitement des données par les capacités du plugin ;
+	// voir DataPolicy.
+	Policy *DataPolicy `json:"policy,omitempty"`
+}
+
+// CapabilitySpec regroupe les métadonnées d'une capacité (valeur de Uses).
+type CapabilitySpec struct {
+	Uses        string       `json:"uses"`
+	Deprecation *Deprecation `json:"deprecation,omitempty"`
+	// DisplayName et Description sont affichés dans le catalogue, en anglais
+	// par défaut.
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Localized associe une étiquette BCP-47 (« fr », « pt-BR ») aux
+	// traductions de DisplayName et Description. Voir Text.
+	Localized map[string]LocalizedText `json:"localized,omitempty"`
+	Category  Category                 `json:"category,omitempty"`
+	// Icon et DocumentationURL remplacent ceux du manifeste pour cette
+	// capacité.
+	Icon             string `json:"icon,omitempty"`
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+	// Examples documente la capacité par des couples entrée/sortie.
+	Examples []Example `json:"examples,omitempty"`
+	// InputSchema décrit With et OutputSchema la sortie de la capacité ;
+	// nil s'ils ne sont pas décrits.
+	InputSchema  *Schema `json:"inputSchema,omitempty"`
+	OutputSchema *Schema `json:"outputSchema,omitempty"`
+	// Policy remplace celle du manifeste pour cette capacité.
+	Policy *DataPolicy `json:"policy,omitempty"`
+	// RequiredScopes sont les permissions que doit détenir l'utilisateur
+	// pour exécuter la capacité (« database/write »).
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
+	// DefaultTimeout remplace celui du manifeste pour cette capacité.
+	DefaultTimeout string `json:"defaultTimeout,omitempty"`
+	// PollInterval est l'intervalle entre deux relevés d'un déclencheur par
+	// relevé (voir Poller), au format time.ParseDuration.
+	PollInterval string `json:"pollInterval,omitempty"`
+	// Continuable autorise la capacité à renvoyer une ScheduleContinuation ;
+	// le client refuse celles des autres capacités.
+	Continuable bool `json:"continuable,omitempty"`
+}
+
+// Deprecation signale qu'une capacité va disparaître.
+type Deprecation struct {
+	// Replacement est la capacité à utiliser à la place, si elle existe.
+	Replacement string `json:"replacement,omitempty"`
+	// Sunset est la date à partir de laquelle la capacité pourra être retirée.
+	Sunset  time.Time `json:"sunset,omitzero"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Capability renvoie la description de la capacité uses, ou nil si le
+// manifeste ne la décrit pas.
+func (m *Manifest) Capability(uses string) *CapabilitySpec {
+	for i := range m.Capabilities {
+		if SameCapability(m.Capabilities[i].Uses, uses) {
+			return &m.Capabilities[i]
+		}
+	}
+	return nil
+}
+
+func toProtoManifest(m Manifest) ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func fromProtoManifest(b []byte) (Manifest, error) {
+	var m Manifest
+	if len(b) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return Manifest{}, err
+	}
+	return m, nil
+}
@@ -0,0 +1,169 @@
+package shared
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Category classe une capacité dans le sélecteur de nœuds.
+type Category string
+
+const (
+	CategoryCommunication Category = "communication"
+	CategoryData          Category = "data"
+	CategoryDeveloper     Category = "developer"
+	CategoryAI            Category = "ai"
+	CategoryProductivity  Category = "productivity"
+	CategoryStorage       Category = "storage"
+	CategoryFinance       Category = "finance"
+	CategoryMarketing     Category = "marketing"
+	CategoryUtility       Category = "utility"
+)
+
+var categories = map[Category]bool{
+	CategoryCommunication: true,
+	CategoryData:          true,
+	CategoryDeveloper:     true,
+	CategoryAI:            true,
+	CategoryProductivity:  true,
+	CategoryStorage:       true,
+	CategoryFinance:       true,
+	CategoryMarketing:     true,
+	CategoryUtility:       true,
+}
+
+// PricingTier indique le modèle de tarification du service tiers utilisé par
+// un plugin.
+type PricingTier string
+
+const (
+	PricingFree       PricingTier = "free"
+	PricingFreemium   PricingTier = "freemium"
+	PricingPaid       PricingTier = "paid"
+	PricingEnterprise PricingTier = "enterprise"
+)
+
+// MaxIconBytes borne la taille d'une icône embarquée en URI data.
+const MaxIconBytes = 64 << 10
+
+// iconMediaTypes sont les types acceptés pour une icône embarquée.
+var iconMediaTypes = map[string]bool{
+	"image/png":     true,
+	"image/svg+xml": true,
+	"image/jpeg":    true,
+	"image/webp":    true,
+}
+
+// Validate vérifie la cohérence du manifeste : capacités uniques, catégories
+// et tarification connues, icônes et URL de documentation bien formées,
+// délais et réglages valides. Toutes les erreurs sont renvoyées, jointes.
+func (m *Manifest) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	if m.PricingTier != "" {
+		switch m.PricingTier {
+		case PricingFree, PricingFreemium, PricingPaid, PricingEnterprise:
+		default:
+			add("unknown pricing tier %q", m.PricingTier)
+		}
+	}
+	if err := validateIcon(m.Icon); err != nil {
+		add("icon: %w", err)
+	}
+	if err := validateHTTPURL(m.DocumentationURL); err != nil {
+		add("documentationUrl: %w", err)
+	}
+	if err := validateTimeout(m.DefaultTimeout); err != nil {
+		add("defaultTimeout: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, c := range m.Capabilities {
+		if c.Uses == "" {
+			add("capability with empty uses")
+			continue
+		}
+		if seen[c.Uses] {
+			add("capability %q declared twice", c.Uses)
+		}
+		seen[c.Uses] = true
+		if c.Category != "" && !categories[c.Category] {
+			add("capability %q: unknown category %q", c.Uses, c.Category)
+		}
+		if err := validateIcon(c.Icon); err != nil {
+			add("capability %q: icon: %w", c.Uses, err)
+		}
+		if err := validateHTTPURL(c.DocumentationURL); err != nil {
+			add("capability %q: documentationUrl: %w", c.Uses, err)
+		}
+		if err := validateTimeout(c.DefaultTimeout); err != nil {
+			add("capability %q: defaultTimeout: %w", c.Uses, err)
+		}
+		if err := validateTimeout(c.PollInterval); err != nil {
+			add("capability %q: pollInterval: %w", c.Uses, err)
+		}
+	}
+	names := map[string]bool{}
+	for _, s := range m.Settings {
+		if s.Name == "" {
+			add("setting with empty name")
+			continue
+		}
+		if names[s.Name] {
+			add("setting %q declared twice", s.Name)
+		}
+		names[s.Name] = true
+		if s.Default != nil {
+			if err := s.check(s.Default); err != nil {
+				add("setting %q: default: %w", s.Name, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateIcon accepte une URL https ou une URI data base64 d'un type
+// d'image usuel, d'au plus MaxIconBytes.
+func validateIcon(icon string) error {
+	if icon == "" {
+		return nil
+	}
+	rest, ok := strings.CutPrefix(icon, "data:")
+	if !ok {
+		u, err := url.Parse(icon)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("expected an https URL or a data URI")
+		}
+		return nil
+	}
+	meta, data, ok := strings.Cut(rest, ",")
+	mediaType, isBase64 := strings.CutSuffix(meta, ";base64")
+	if !ok || !isBase64 {
+		return fmt.Errorf("data URI must be base64-encoded")
+	}
+	if !iconMediaTypes[mediaType] {
+		return fmt.Errorf("unsupported media type %q", mediaType)
+	}
+	if base64.StdEncoding.DecodedLen(len(data)) > MaxIconBytes {
+		return fmt.Errorf("icon exceeds %d bytes", MaxIconBytes)
+	}
+	if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+		return fmt.Errorf("invalid base64 data")
+	}
+	return nil
+}
+
+func validateHTTPURL(s string) error {
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q", s)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxMatrixCombinations borne le nombre d'instances produites par
+// ExpandMatrix, pour qu'une faute de frappe ne lance pas des milliers
+// d'exécutions.
+const MaxMatrixCombinations = 256
+
+// MatrixInstance est une instance d'un nœud issue de l'expansion de sa
+// matrice. Values associe à chaque dimension la valeur retenue ; le moteur
+// l'expose aux expressions sous le nom « matrix ».
+type MatrixInstance struct {
+	Node   *Node
+	Values map[string]interface{}
+}
+
+// ExpandMatrix produit le produit cartésien de n.Matrix, à la manière de
+// strategy.matrix de GitHub Actions. Chaque instance est une copie de n sans
+// matrice, dont l'ID est suffixé par les valeurs retenues (« build[os=linux] »).
+// Les dimensions sont parcourues dans l'ordre alphabétique, ce qui rend le
+// résultat déterministe. Un nœud sans matrice donne une seule instance, n
+// lui-même. With, Needs et les nœuds enfants sont partagés avec n.
+//
+// Dans l'ID, les valeurs qui ne sont pas des chaînes sont écrites en JSON,
+// comme les chaînes qui pourraient être prises pour du JSON (« "1" »), et
+// « \ », « , », « = » et « ] » sont précédés d'une barre oblique inverse :
+// deux combinaisons ne donnent jamais le même ID.
+func ExpandMatrix(n *Node) ([]MatrixInstance, error) {
+	if len(n.Matrix) == 0 {
+		return []MatrixInstance{{Node: n}}, nil
+	}
+	if err := validateMatrix(n.Matrix); err != nil {
+		return nil, fmt.Errorf("node %q: %w", n.ID, err)
+	}
+	keys := make([]string, 0, len(n.Matrix))
+	total := 1
+	for k, values := range n.Matrix {
+		keys = append(keys, k)
+		total *= len(values)
+		if total > MaxMatrixCombinations {
+			return nil, fmt.Errorf("node %q: matrix exceeds %d combinations", n.ID, MaxMatrixCombinations)
+		}
+	}
+	sort.Strings(keys)
+
+	out := make([]MatrixInstance, 0, total)
+	idx := make([]int, len(keys))
+	for {
+		values := make(map[string]interface{}, len(keys))
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			v := n.Matrix[k][idx[i]]
+			values[k] = v
+			parts[i] = escapeMatrixID(k) + "=" + escapeMatrixID(matrixValueID(v))
+		}
+		inst := *n
+		inst.ID = n.ID + "[" + strings.Join(parts, ",") + "]"
+		inst.Matrix = nil
+		out = append(out, MatrixInstance{Node: &inst, Values: values})
+
+		// Incrémente l'index comme un compteur, la dernière dimension
+		// variant le plus vite.
+		i := len(idx) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(n.Matrix[keys[i]]) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return out, nil
+		}
+	}
+}
+
+// matrixValueID représente v dans l'ID d'une instance.
+func matrixValueID(v interface{}) string {
+	if s, ok := v.(string); ok && !json.Valid([]byte(s)) {
+		return s
+	}
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+var matrixIDEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `=`, `\=`, `]`, `\]`)
+
+func escapeMatrixID(s string) string {
+	return matrixIDEscaper.Replace(s)
+}
+
+func validateMatrix(m map[string][]interface{}) error {
+	for k, values := range m {
+		if k == "" {
+			return fmt.Errorf("matrix: empty dimension name")
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("matrix: dimension %q has no values", k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Clés des métadonnées gRPC ajoutées par le client à chaque appel.
+const (
+	MetadataEngineID      = "orkestra-engine-id"
+	MetadataRunID         = "orkestra-run-id"
+	MetadataAuthorization = "authorization"
+	MetadataExecutor      = "orkestra-executor"
+	MetadataRequestID     = "orkestra-request-id"
+	// MetadataPayloadCodec vaut PayloadCodecProto lorsque les charges de la
+	// requête sont encodées par ProtoCodec.
+	MetadataPayloadCodec = "orkestra-payload-codec"
+)
+
+// CallMetadata décrit l'appelant d'une RPC, tel que transmis par le client.
+type CallMetadata struct {
+	EngineID  string
+	RunID     string
+	AuthToken string
+	// Executor est la clé de l'exécuteur visé dans un plugin multiplexé.
+	Executor string
+	// RequestID identifie l'exécution appelée, vide pour les appels de
+	// service.
+	RequestID string
+	// PayloadCodec est le codec des charges de la requête : vide pour JSON,
+	// PayloadCodecProto pour ProtoCodec.
+	PayloadCodec string
+}
+
+// PayloadCodecProto est la valeur de MetadataPayloadCodec pour ProtoCodec.
+const PayloadCodecProto = "proto"
+
+// CallMetadataFromContext lit les métadonnées de l'appel en cours côté
+// serveur.
+func CallMetadataFromContext(ctx context.Context) CallMetadata {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	return CallMetadata{
+		EngineID:     get(MetadataEngineID),
+		RunID:        get(MetadataRunID),
+		AuthToken:    strings.TrimPrefix(get(MetadataAuthorization), "Bearer "),
+		Executor:     get(MetadataExecutor),
+		RequestID:    get(MetadataRequestID),
+		PayloadCodec: get(MetadataPayloadCodec),
+	}
+}
+
+// callContext renvoie le contexte d'un appel borné par timeout et portant
+// les métadonnées du client. runID est vide pour les appels de service.
+func (m *NodeExecutorGRPC) callContext(timeout time.Duration, runID string) (context.Context, context.CancelFunc) {
+	ctx, cancel := timeoutContext(timeout)
+	var kv []string
+	if m.opts.EngineID != "" {
+		kv = append(kv, MetadataEngineID, m.opts.EngineID)
+	}
+	if runID != "" {
+		kv = append(kv, MetadataRunID, runID)
+	}
+	if m.opts.AuthToken != "" {
+		kv = append(kv, MetadataAuthorization, "Bearer "+m.opts.AuthToken)
+	}
+	if m.executor != "" {
+		kv = append(kv, MetadataExecutor, m.executor)
+	}
+	if m.protoPayloads() {
+		kv = append(kv, MetadataPayloadCodec, PayloadCodecProto)
+	}
+	if len(kv) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+	}
+	return ctx, cancel
+}
+
+// authorize vérifie l'appelant d'une RPC selon ServerOptions.AuthToken et
+// ServerOptions.Authorize.
+func (s *NodeExecutorGRPCServer) authorize(ctx context.Context) error {
+	if s.options.AuthToken == "" && s.options.Authorize == nil {
+		return nil
+	}
+	md := CallMetadataFromContext(ctx)
+	if s.options.AuthToken != "" && subtle.ConstantTimeCompare([]byte(md.AuthToken), []byte(s.options.AuthToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid or missing auth token")
+	}
+	if s.options.Authorize != nil {
+		if err := s.options.Authorize(md); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return nil
+}
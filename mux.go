@@ -0,0 +1,297 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/rpc"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// MultiplexPlugin sert plusieurs NodeExecutor d'un même binaire sur un seul
+// processus et une seule connexion. Chaque appel désigne son exécuteur par
+// la métadonnée MetadataExecutor ; un appel qui n'en porte pas vise
+// DefaultPluginKey. Côté moteur, GRPCClient renvoie un *MultiplexClient.
+type MultiplexPlugin struct {
+	plugin.GRPCPlugin
+	// Executors associe à chaque clé son implémentation (côté plugin).
+	Executors map[string]NodeExecutor
+	// ClientOptions paramètre les clients renvoyés par MultiplexClient.
+	ClientOptions ClientOptions
+	// ServerOptions paramètre les serveurs de chaque exécuteur.
+	ServerOptions ServerOptions
+}
+
+func (p *MultiplexPlugin) Server(*plugin.MuxBroker) (interface{}, error) {
+	return nil, fmt.Errorf("NetRPC is not supported")
+}
+
+func (p *MultiplexPlugin) Client(*plugin.MuxBroker, *rpc.Client) (interface{}, error) {
+	return nil, fmt.Errorf("NetRPC is not supported")
+}
+
+func (p *MultiplexPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	mux := &muxServer{servers: make(map[string]*NodeExecutorGRPCServer, len(p.Executors))}
+	for key, impl := range p.Executors {
+		server, err := newNodeExecutorServer(impl, p.ServerOptions, broker)
+		if err != nil {
+			return fmt.Errorf("executor %q: %w", key, err)
+		}
+		mux.servers[key] = server
+	}
+	proto.RegisterNodeExecutorServer(s, mux)
+	if p.ServerOptions.Debug {
+		proto.RegisterPluginDebugServer(s, &muxDebugServer{mux: mux, options: p.ServerOptions})
+	}
+	if _, ok := s.GetServiceInfo()["grpc.reflection.v1alpha.ServerReflection"]; p.ServerOptions.Reflection && !ok {
+		reflection.Register(s)
+	}
+	return nil
+}
+
+func (p *MultiplexPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &MultiplexClient{conn: c, broker: broker, opts: p.ClientOptions}, nil
+}
+
+// MultiplexClient distribue les exécuteurs d'un plugin multiplexé, qui
+// partagent tous sa connexion.
+type MultiplexClient struct {
+	conn   *grpc.ClientConn
+	broker *plugin.GRPCBroker
+	opts   ClientOptions
+
+	mu        sync.Mutex
+	executors map[string]*NodeExecutorGRPC
+}
+
+// Executor renvoie le client de l'exécuteur key, créé au premier appel. La
+// clé n'est vérifiée par le plugin qu'au premier appel de service.
+func (c *MultiplexClient) Executor(key string) (*NodeExecutorGRPC, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if m, ok := c.executors[key]; ok {
+		return m, nil
+	}
+	m, err := newNodeExecutorClient(c.conn, c.broker, c.opts, key)
+	if err != nil {
+		return nil, fmt.Errorf("executor %q: %w", key, err)
+	}
+	if c.executors == nil {
+		c.executors = map[string]*NodeExecutorGRPC{}
+	}
+	c.executors[key] = m
+	return m, nil
+}
+
+// muxServer aiguille chaque RPC vers le serveur de l'exécuteur visé.
+type muxServer struct {
+	proto.UnimplementedNodeExecutorServer
+	servers map[string]*NodeExecutorGRPCServer
+}
+
+func (s *muxServer) route(ctx context.Context) (*NodeExecutorGRPCServer, error) {
+	key := CallMetadataFromContext(ctx).Executor
+	if key == "" {
+		key = DefaultPluginKey
+	}
+	server, ok := s.servers[key]
+	if !ok {
+		keys := make([]string, 0, len(s.servers))
+		for k := range s.servers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, status.Errorf(codes.NotFound, "unknown executor %q (available: %v)", key, keys)
+	}
+	return server, nil
+}
+
+func (s *muxServer) Execute(ctx context.Context, req *proto.ExecuteRequest) (*proto.ExecuteResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Execute(ctx, req)
+}
+
+func (s *muxServer) GetCapabilities(ctx context.Context, req *proto.GetCapabilitiesRequest) (*proto.GetCapabilitiesResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetCapabilities(ctx, req)
+}
+
+func (s *muxServer) GetInfo(ctx context.Context, req *proto.Empty) (*proto.PluginInfo, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetInfo(ctx, req)
+}
+
+func (s *muxServer) ExecuteStream(req *proto.ExecuteRequest, stream proto.NodeExecutor_ExecuteStreamServer) error {
+	server, err := s.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.ExecuteStream(req, stream)
+}
+
+func (s *muxServer) Preflight(ctx context.Context, req *proto.PreflightRequest) (*proto.PreflightResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Preflight(ctx, req)
+}
+
+func (s *muxServer) EstimateCost(ctx context.Context, req *proto.EstimateCostRequest) (*proto.CostEstimate, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.EstimateCost(ctx, req)
+}
+
+func (s *muxServer) SelfTest(ctx context.Context, req *proto.SelfTestRequest) (*proto.PreflightResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SelfTest(ctx, req)
+}
+
+func (s *muxServer) ListOptions(ctx context.Context, req *proto.ListOptionsRequest) (*proto.ListOptionsResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListOptions(ctx, req)
+}
+
+func (s *muxServer) SampleOutput(ctx context.Context, req *proto.SampleOutputRequest) (*proto.SampleOutputResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SampleOutput(ctx, req)
+}
+
+func (s *muxServer) Poll(ctx context.Context, req *proto.PollRequest) (*proto.PollResponse, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Poll(ctx, req)
+}
+
+func (s *muxServer) Ack(ctx context.Context, req *proto.AckRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Ack(ctx, req)
+}
+
+func (s *muxServer) Nack(ctx context.Context, req *proto.AckRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Nack(ctx, req)
+}
+
+func (s *muxServer) GetStats(ctx context.Context, req *proto.Empty) (*proto.PluginStats, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetStats(ctx, req)
+}
+
+func (s *muxServer) Configure(ctx context.Context, req *proto.ConfigureRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Configure(ctx, req)
+}
+
+func (s *muxServer) SetTokenStore(ctx context.Context, req *proto.SetTokenStoreRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SetTokenStore(ctx, req)
+}
+
+func (s *muxServer) Control(stream proto.NodeExecutor_ControlServer) error {
+	server, err := s.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.Control(stream)
+}
+
+func (s *muxServer) SetArtifactStore(ctx context.Context, req *proto.SetArtifactStoreRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SetArtifactStore(ctx, req)
+}
+
+func (s *muxServer) SetIdempotencyStore(ctx context.Context, req *proto.SetIdempotencyStoreRequest) (*proto.Empty, error) {
+	server, err := s.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SetIdempotencyStore(ctx, req)
+}
+
+// muxDebugServer aiguille le service de débogage de la même manière.
+type muxDebugServer struct {
+	proto.UnimplementedPluginDebugServer
+	mux     *muxServer
+	options ServerOptions
+}
+
+func (d *muxDebugServer) debug(ctx context.Context) (*debugServer, error) {
+	server, err := d.mux.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &debugServer{server: server, options: d.options}, nil
+}
+
+func (d *muxDebugServer) RecentRequests(ctx context.Context, req *proto.RecentRequestsRequest) (*proto.RecentRequestsResponse, error) {
+	debug, err := d.debug(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return debug.RecentRequests(ctx, req)
+}
+
+func (d *muxDebugServer) GetConfig(ctx context.Context, req *proto.Empty) (*proto.DebugConfig, error) {
+	debug, err := d.debug(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return debug.GetConfig(ctx, req)
+}
+
+func (d *muxDebugServer) DumpGoroutines(ctx context.Context, req *proto.Empty) (*proto.GoroutineDump, error) {
+	debug, err := d.debug(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return debug.DumpGoroutines(ctx, req)
+}
@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// Bornes de Node.Priority. Zéro est la priorité par défaut ; les valeurs
+// positives sont servies en premier.
+const (
+	MinPriority = -100
+	MaxPriority = 100
+)
+
+// queueName est la forme acceptée pour Node.Queue.
+var queueName = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,62})$`)
+
+// Validate vérifie les champs d'ordonnancement de n et de ses descendants
+// (Do, OnFailure, Compensate). L'erreur désigne le premier nœud invalide rencontré.
+func (n *Node) Validate() error {
+	var err error
+	walkNodes([]*Node{n}, func(node *Node) {
+		if err == nil {
+			if e := node.validateFields(); e != nil {
+				err = fmt.Errorf("node %q: %w", node.ID, e)
+			}
+		}
+	})
+	return err
+}
+
+func (n *Node) validateFields() error {
+	if n.Priority < MinPriority || n.Priority > MaxPriority {
+		return fmt.Errorf("priority %d out of range [%d, %d]", n.Priority, MinPriority, MaxPriority)
+	}
+	if n.Queue != "" && !queueName.MatchString(n.Queue) {
+		return fmt.Errorf("invalid queue name %q", n.Queue)
+	}
+	if n.Timeout < 0 {
+		return fmt.Errorf("negative timeout %s", n.Timeout)
+	}
+	if n.Resources != nil {
+		if err := n.Resources.validate(); err != nil {
+			return err
+		}
+	}
+	if err := n.validateCompensate(); err != nil {
+		return err
+	}
+	return validateMatrix(n.Matrix)
+}
+
+// Resources décrit le budget attendu pour l'exécution d'un nœud. Ce sont des
+// indications pour l'ordonnanceur du moteur ; le plugin les reçoit avec le
+// nœud et peut s'y conformer (taille des lots, parallélisme interne).
+type Resources struct {
+	// CPU est exprimé en cœurs, fractions acceptées (0.5).
+	CPU      float64 `json:"cpu,omitempty"`
+	MemoryMB int64   `json:"memoryMB,omitempty"`
+	GPU      int     `json:"gpu,omitempty"`
+}
+
+func (r *Resources) validate() error {
+	if r.CPU < 0 || r.MemoryMB < 0 || r.GPU < 0 {
+		return fmt.Errorf("negative resource requirement")
+	}
+	return nil
+}
+
+func toProtoResources(r *Resources) *proto.Resources {
+	if r == nil {
+		return nil
+	}
+	return &proto.Resources{Cpu: r.CPU, MemoryMb: r.MemoryMB, Gpu: int32(r.GPU)}
+}
+
+func fromProtoResources(p *proto.Resources) *Resources {
+	if p == nil {
+		return nil
+	}
+	return &Resources{CPU: p.Cpu, MemoryMB: p.MemoryMb, GPU: int(p.Gpu)}
+}
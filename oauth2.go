@@ -0,0 +1,175 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/orkestra-io/orkestra-shared/broker"
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OAuth2Token est un jeton OAuth2 tel que conservé par le moteur pour le
+// compte des plugins d'identifiants.
+type OAuth2Token struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	TokenType    string    `json:"tokenType,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+// Expired indique si le jeton expire dans moins de leeway. Un jeton sans
+// date d'expiration n'expire jamais.
+func (t OAuth2Token) Expired(leeway time.Duration) bool {
+	return !t.Expiry.IsZero() && time.Now().Add(leeway).After(t.Expiry)
+}
+
+// ErrTokenNotFound est renvoyée par TokenStore.Get pour une clé inconnue.
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenStore conserve les jetons OAuth2 de manière centralisée. Le moteur en
+// fournit une implémentation (ClientOptions.TokenStore), que les plugins
+// reçoivent via TokenStoreReceiver : un jeton rafraîchi par une instance du
+// plugin est ainsi visible des autres et survit à leur redémarrage.
+type TokenStore interface {
+	Get(key string) (OAuth2Token, error)
+	Put(key string, token OAuth2Token) error
+	Delete(key string) error
+}
+
+// TokenStoreReceiver peut être implémentée par un NodeExecutor pour recevoir
+// le TokenStore du moteur au démarrage. SetTokenStore n'est pas appelée si le
+// moteur n'en fournit pas.
+type TokenStoreReceiver interface {
+	SetTokenStore(store TokenStore)
+}
+
+// attachTokenStore sert store via le broker de go-plugin et transmet son
+// identifiant au plugin. Les plugins qui ne l'utilisent pas sont ignorés.
+func (m *NodeExecutorGRPC) attachTokenStore(b *plugin.GRPCBroker, store TokenStore) error {
+	srv := broker.Register[proto.TokenStoreServer](b, proto.RegisterTokenStoreServer, &tokenStoreServer{store: store})
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	_, err := m.client.SetTokenStore(callCtx, &proto.SetTokenStoreRequest{BrokerId: srv.ID})
+	if err = fromRPCError("SetTokenStore", err); err != nil {
+		srv.Close()
+		if errors.Is(err, errors.ErrUnsupported) {
+			return nil
+		}
+		return withTimeout(err, m.opts.callTimeout())
+	}
+	return nil
+}
+
+func (s *NodeExecutorGRPCServer) SetTokenStore(ctx context.Context, req *proto.SetTokenStoreRequest) (*proto.Empty, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	r, ok := implementation[TokenStoreReceiver](s.Impl)
+	if !ok || s.broker == nil {
+		return s.UnimplementedNodeExecutorServer.SetTokenStore(ctx, req)
+	}
+	c, err := broker.Dial(s.broker, req.BrokerId, proto.NewTokenStoreClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial token store: %w", err)
+	}
+	// Un nouvel appel remplace la connexion précédente ; la dernière est
+	// fermée lorsque le moteur cesse de servir son TokenStore.
+	c.CloseOnDisconnect()
+	if old := s.tokenStore.Swap(c); old != nil {
+		old.Close()
+	}
+	r.SetTokenStore(&tokenStoreClient{client: c.Service})
+	return &proto.Empty{}, nil
+}
+
+// tokenStoreServer expose le TokenStore du moteur au plugin.
+type tokenStoreServer struct {
+	proto.UnimplementedTokenStoreServer
+	store TokenStore
+}
+
+func (s *tokenStoreServer) GetToken(ctx context.Context, req *proto.TokenKey) (*proto.OAuth2Token, error) {
+	t, err := s.store.Get(req.Key)
+	if errors.Is(err, ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoOAuth2Token(t), nil
+}
+
+func (s *tokenStoreServer) PutToken(ctx context.Context, req *proto.PutTokenRequest) (*proto.Empty, error) {
+	if req.Token == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing token")
+	}
+	if err := s.store.Put(req.Key, fromProtoOAuth2Token(req.Token)); err != nil {
+		return nil, err
+	}
+	return &proto.Empty{}, nil
+}
+
+func (s *tokenStoreServer) DeleteToken(ctx context.Context, req *proto.TokenKey) (*proto.Empty, error) {
+	if err := s.store.Delete(req.Key); err != nil {
+		return nil, err
+	}
+	return &proto.Empty{}, nil
+}
+
+// tokenStoreClient est le TokenStore remis au plugin.
+type tokenStoreClient struct {
+	client proto.TokenStoreClient
+}
+
+func (c *tokenStoreClient) Get(key string) (OAuth2Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	t, err := c.client.GetToken(ctx, &proto.TokenKey{Key: key})
+	if status.Code(err) == codes.NotFound {
+		return OAuth2Token{}, ErrTokenNotFound
+	}
+	if err != nil {
+		return OAuth2Token{}, err
+	}
+	return fromProtoOAuth2Token(t), nil
+}
+
+func (c *tokenStoreClient) Put(key string, token OAuth2Token) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	_, err := c.client.PutToken(ctx, &proto.PutTokenRequest{Key: key, Token: toProtoOAuth2Token(token)})
+	return err
+}
+
+func (c *tokenStoreClient) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+	_, err := c.client.DeleteToken(ctx, &proto.TokenKey{Key: key})
+	return err
+}
+
+func toProtoOAuth2Token(t OAuth2Token) *proto.OAuth2Token {
+	return &proto.OAuth2Token{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    t.TokenType,
+		ExpiryUnixMs: unixMillis(t.Expiry),
+		Scopes:       t.Scopes,
+	}
+}
+
+func fromProtoOAuth2Token(p *proto.OAuth2Token) OAuth2Token {
+	return OAuth2Token{
+		AccessToken:  p.AccessToken,
+		RefreshToken: p.RefreshToken,
+		TokenType:    p.TokenType,
+		Expiry:       fromUnixMillis(p.ExpiryUnixMs),
+		Scopes:       p.Scopes,
+	}
+}
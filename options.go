@@ -0,0 +1,135 @@
+package shared
+
+import "time"
+
+// DefaultCallTimeout borne les appels de service (GetCapabilities, GetInfo)
+// lorsque ClientOptions.CallTimeout n'est pas renseigné.
+const DefaultCallTimeout = 30 * time.Second
+
+// ClientOptions paramètre le client gRPC côté moteur.
+type ClientOptions struct {
+	// ExecuteTimeout borne Execute lorsque ni Node.Timeout, ni le délai par
+	// défaut du manifeste (Manifest.Timeout), ni ExecutionContext.Deadline
+	// ne fixent d'échéance. Zéro : pas de limite.
+	ExecuteTimeout time.Duration
+	// CallTimeout borne les appels de service. Zéro : DefaultCallTimeout.
+	CallTimeout time.Duration
+	// MaxConcurrency limite le nombre d'exécutions simultanées sur le plugin.
+	// La limite effective est la plus petite entre celle-ci et
+	// Manifest.MaxConcurrency, connue après le premier GetInfo. Zéro : pas de
+	// limite côté moteur.
+	MaxConcurrency int
+	// CapabilitiesTTL est la durée pendant laquelle la réponse de
+	// GetCapabilities est gardée en cache. Zéro : pas de cache.
+	CapabilitiesTTL time.Duration
+	// SlowCallThreshold déclenche, lorsqu'une exécution le dépasse, la
+	// capture des statistiques et des piles du plugin. Elles sont attachées
+	// à l'erreur éventuelle (*SlowCallError) et transmises à OnSlowCall.
+	// Zéro : pas de surveillance.
+	SlowCallThreshold time.Duration
+	OnSlowCall        func(SlowCallReport)
+	// EngineID identifie l'instance du moteur dans les métadonnées de chaque
+	// appel, pour corréler les journaux.
+	EngineID string
+	// AuthToken est présenté au plugin à chaque appel (en-tête
+	// authorization), pour les plugins joignables par le réseau.
+	AuthToken string
+	// TokenStore, s'il est renseigné, est mis à disposition des plugins qui
+	// implémentent TokenStoreReceiver.
+	TokenStore TokenStore
+	// Artifacts, s'il est renseigné, reçoit les sorties de nœuds plus
+	// grandes que ArtifactThreshold, transmises alors par référence aux
+	// plugins qui implémentent OutputRefReader.
+	Artifacts ArtifactStore
+	// ArtifactThreshold est la taille sérialisée d'une sortie au-delà de
+	// laquelle elle est transmise par référence. Zéro :
+	// DefaultArtifactThreshold ; négatif : jamais.
+	ArtifactThreshold int
+	// OutputSchema vérifie les sorties contre CapabilitySpec.OutputSchema,
+	// pour détecter les changements d'une intégration avant qu'ils ne cassent
+	// les nœuds en aval. Le manifeste est lu une fois via GetInfo.
+	OutputSchema OutputSchemaMode
+	// StrictWith refuse, avant l'envoi, les nœuds dont With contient des
+	// clés absentes de CapabilitySpec.InputSchema. L'annotation
+	// AnnotationStrictWith d'un nœud l'emporte sur ce réglage.
+	StrictWith bool
+	// Codec sérialise les charges utiles. Nil : JSONCodec.
+	Codec Codec
+	// CompressionThreshold est la taille cumulée de TriggerData et
+	// NodeOutputs au-delà de laquelle ils sont compressés, si le plugin le
+	// supporte. Zéro : DefaultCompressionThreshold ; négatif : jamais.
+	CompressionThreshold int
+	// OutputsDelta ne transmet, d'un appel à l'autre d'une même exécution,
+	// que les sorties de nœuds modifiées, si le plugin le supporte.
+	OutputsDelta bool
+	// Capture conserve les requêtes Execute en échec, masquées, pour les
+	// rejouer avec ReplayCapture.
+	Capture CaptureOptions
+	// Quota reçoit la consommation de chaque Execute réussi et de chaque
+	// ExecuteStream, par locataire et par capacité.
+	Quota QuotaReporter
+	// Policy peut refuser une exécution selon la politique de données du
+	// locataire (ExecutionContext.TenantID) ; voir TenantPolicies.
+	Policy PolicyEvaluator
+	// Scopes vérifie, avant chaque exécution, que ExecutionContext.Identity
+	// détient les permissions requises par la capacité. Voir
+	// DefaultScopeChecker.
+	Scopes ScopeChecker
+	// IsolateTenantSecrets refuse les exécutions dont les secrets cloisonnés
+	// (voir TenantSecretKey) appartiennent à un autre locataire que
+	// ExecutionContext.TenantID, et transmet au plugin ceux du locataire sous
+	// leur nom court.
+	IsolateTenantSecrets bool
+	// Connections résout Node.Connection avant chaque exécution. Nil : les
+	// nœuds qui désignent une connexion sont refusés.
+	Connections ConnectionResolver
+	// Journal reçoit les étapes de chaque exécution, pour un journal durable
+	// des nœuds en cours. Nil : pas de journal.
+	Journal Journal
+	// Idempotency, s'il est renseigné, est mis à disposition des plugins qui
+	// implémentent IdempotencyStoreReceiver.
+	Idempotency IdempotencyStore
+	// IdempotencyNamespace préfixe les clés du plugin dans Idempotency. Vide
+	// : le nom annoncé par GetInfo, le plugin étant refusé s'il n'en annonce
+	// pas.
+	IdempotencyNamespace string
+	// MaxNodeDepth est la profondeur d'imbrication maximale des nœuds
+	// envoyés. Zéro : DefaultMaxNodeDepth.
+	MaxNodeDepth int
+}
+
+func (o ClientOptions) callTimeout() time.Duration {
+	if o.CallTimeout > 0 {
+		return o.CallTimeout
+	}
+	return DefaultCallTimeout
+}
+
+// ServerOptions paramètre le serveur gRPC côté plugin.
+type ServerOptions struct {
+	// Reflection enregistre le service de réflexion gRPC, pour interroger
+	// un plugin en cours d'exécution avec grpcurl. Les serveurs créés par
+	// go-plugin l'exposent déjà ; l'option sert lorsque GRPCServer est
+	// appelée sur un autre serveur.
+	Reflection bool
+	// Debug enregistre le service PluginDebug, qui expose les dernières
+	// exécutions et la configuration courante.
+	Debug bool
+	// DebugHistory est le nombre d'exécutions conservées par le service de
+	// débogage, DefaultDebugHistory par défaut.
+	DebugHistory int
+	// WireLog est la configuration initiale du journal des échanges,
+	// modifiable ensuite via Configure.
+	WireLog WireLogOptions
+	// AuthToken, s'il est renseigné, doit être présenté par le client à
+	// chaque appel ; les autres appels sont refusés (Unauthenticated).
+	AuthToken string `json:"-"`
+	// Authorize est appelée pour chaque RPC avec les métadonnées de
+	// l'appelant ; une erreur refuse l'appel (PermissionDenied).
+	Authorize func(CallMetadata) error `json:"-"`
+	// Codec sérialise les charges utiles. Nil : JSONCodec.
+	Codec Codec `json:"-"`
+	// MaxNodeDepth est la profondeur d'imbrication maximale des nœuds
+	// reçus. Zéro : DefaultMaxNodeDepth.
+	MaxNodeDepth int
+}
@@ -0,0 +1,47 @@
+package shared
+
+// OutputKind indique aux moteurs de rendu et aux nœuds suivants comment
+// interpréter la valeur d'un résultat.
+type OutputKind string
+
+const (
+	OutputJSON   OutputKind = "json"
+	OutputText   OutputKind = "text"
+	OutputBinary OutputKind = "binary"
+	OutputTable  OutputKind = "table"
+	OutputHTML   OutputKind = "html"
+)
+
+// TextResult construit un résultat texte brut.
+func TextResult(s string) *Result {
+	return &Result{Value: s, Kind: OutputText, ContentType: "text/plain; charset=utf-8"}
+}
+
+// HTMLResult construit un résultat HTML.
+func HTMLResult(s string) *Result {
+	return &Result{Value: s, Kind: OutputHTML, ContentType: "text/html; charset=utf-8"}
+}
+
+// BinaryResult construit un résultat binaire. Les octets sont transportés
+// en base64 dans l'encodage JSON du résultat.
+func BinaryResult(b []byte, contentType string) *Result {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Result{Value: b, Kind: OutputBinary, ContentType: contentType}
+}
+
+// OutputKindOf renvoie le type déclaré du résultat, ou le déduit de la forme
+// de la valeur lorsque le plugin ne l'a pas précisé.
+func (r *Result) OutputKindOf() OutputKind {
+	if r.Kind != "" {
+		return r.Kind
+	}
+	switch r.Value.(type) {
+	case string:
+		return OutputText
+	case []byte:
+		return OutputBinary
+	}
+	return OutputJSON
+}
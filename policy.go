@@ -0,0 +1,153 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DataPolicy décrit où une capacité traite les données qu'elle reçoit et de
+// quelle nature elles sont, pour que le moteur applique les politiques de
+// résidence et de sortie des données de chaque locataire.
+type DataPolicy struct {
+	// Regions liste les régions où les données sont traitées ou envoyées
+	// (« eu », « eu-west-1 », « us »). Vide : régions inconnues.
+	Regions []string `json:"regions,omitempty"`
+	// DataClasses liste les catégories de données manipulées (« pii »,
+	// « financial », « health »).
+	DataClasses []string `json:"dataClasses,omitempty"`
+	// Unknown indique que la politique n'est pas connue : ni la capacité ni
+	// le manifeste n'en déclarent, ou le manifeste n'a pas pu être lu.
+	Unknown bool `json:"-"`
+}
+
+// DataPolicy renvoie la politique de la capacité uses : celle de sa
+// description si elle en déclare une, celle du manifeste sinon.
+func (m *Manifest) DataPolicy(uses string) DataPolicy {
+	if spec := m.Capability(uses); spec != nil && spec.Policy != nil {
+		return *spec.Policy
+	}
+	if m.Policy != nil {
+		return *m.Policy
+	}
+	return DataPolicy{Unknown: true}
+}
+
+// ErrPolicyViolation est enveloppée par les erreurs *PolicyViolation.
+var ErrPolicyViolation = errors.New("policy violation")
+
+// PolicyViolation est renvoyée lorsqu'une exécution est refusée par la
+// politique du locataire. Elle n'est pas rejouable.
+type PolicyViolation struct {
+	Tenant string
+	Uses   string
+	// Rule est la règle enfreinte : « region » ou « data-class ».
+	Rule   string
+	Reason string
+}
+
+func (e *PolicyViolation) Error() string {
+	return fmt.Sprintf("policy violation for tenant %q on %s: %s", e.Tenant, e.Uses, e.Reason)
+}
+
+func (e *PolicyViolation) Unwrap() error { return ErrPolicyViolation }
+
+// Code renvoie CodePermissionDenied : voir CodeOf.
+func (e *PolicyViolation) Code() ErrorCode { return CodePermissionDenied }
+
+func (e *PolicyViolation) FailureCode() string { return string(e.Code()) }
+
+func (e *PolicyViolation) Retryable() bool { return false }
+
+// PolicyEvaluator décide, avant chaque exécution, si node peut s'exécuter
+// pour tenant au vu de la politique de sa capacité. Une erreur, de préférence
+// une *PolicyViolation, refuse l'exécution.
+type PolicyEvaluator interface {
+	Evaluate(tenant string, node Node, policy DataPolicy) error
+}
+
+// TenantPolicy est la politique de données d'un locataire.
+type TenantPolicy struct {
+	// AllowedRegions restreint les régions où ses données peuvent aller. Une
+	// région autorise ses sous-régions : « eu » autorise « eu-west-1 ». Les
+	// capacités qui ne déclarent pas de région sont alors refusées. Vide :
+	// toutes les régions.
+	AllowedRegions []string
+	// DeniedDataClasses liste les catégories de données que ses workflows
+	// ne doivent pas confier aux plugins. Les capacités dont la politique
+	// n'est pas connue (DataPolicy.Unknown) sont alors refusées.
+	DeniedDataClasses []string
+}
+
+// Check vérifie policy contre la politique du locataire.
+func (p TenantPolicy) Check(tenant, uses string, policy DataPolicy) error {
+	if len(p.AllowedRegions) > 0 {
+		if len(policy.Regions) == 0 {
+			return &PolicyViolation{Tenant: tenant, Uses: uses, Rule: "region", Reason: "capability does not declare its regions"}
+		}
+		for _, r := range policy.Regions {
+			if !regionAllowed(r, p.AllowedRegions) {
+				return &PolicyViolation{Tenant: tenant, Uses: uses, Rule: "region", Reason: fmt.Sprintf("region %q is not allowed", r)}
+			}
+		}
+	}
+	if len(p.DeniedDataClasses) > 0 && policy.Unknown {
+		return &PolicyViolation{Tenant: tenant, Uses: uses, Rule: "data-class", Reason: "capability does not declare its data classes"}
+	}
+	for _, c := range policy.DataClasses {
+		for _, denied := range p.DeniedDataClasses {
+			if strings.EqualFold(c, denied) {
+				return &PolicyViolation{Tenant: tenant, Uses: uses, Rule: "data-class", Reason: fmt.Sprintf("data class %q is not allowed", c)}
+			}
+		}
+	}
+	return nil
+}
+
+func regionAllowed(region string, allowed []string) bool {
+	region = strings.ToLower(region)
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if region == a || strings.HasPrefix(region, a+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantPolicies est un PolicyEvaluator qui applique à chaque locataire sa
+// TenantPolicy. Les locataires absents ne sont pas restreints.
+type TenantPolicies map[string]TenantPolicy
+
+func (t TenantPolicies) Evaluate(tenant string, node Node, policy DataPolicy) error {
+	p, ok := t[tenant]
+	if !ok {
+		return nil
+	}
+	return p.Check(tenant, node.Uses, policy)
+}
+
+// checkAccess applique les contrôles d'Execute (permissions, politique du
+// locataire) aux autres RPC qui transmettent le contexte et les secrets d'un
+// nœud au plugin. Il précède la résolution de la connexion : aucun secret ne
+// quitte le moteur pour un nœud refusé.
+func (m *NodeExecutorGRPC) checkAccess(node Node, ctx ExecutionContext) error {
+	if err := m.checkScopes(node, ctx); err != nil {
+		return err
+	}
+	return m.checkPolicy(node, ctx)
+}
+
+// checkPolicy soumet node à ClientOptions.Policy avant l'envoi.
+func (m *NodeExecutorGRPC) checkPolicy(node Node, ctx ExecutionContext) error {
+	if m.opts.Policy == nil {
+		return nil
+	}
+	// Sans manifeste, la politique est inconnue : à l'évaluateur de
+	// refuser ce qu'il ne peut pas vérifier.
+	policy := DataPolicy{Unknown: true}
+	if manifest, err := m.manifestOrErr(); err == nil {
+		policy = manifest.DataPolicy(node.Uses)
+	}
+	return m.opts.Policy.Evaluate(ctx.TenantID, node, policy)
+}
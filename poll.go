@@ -0,0 +1,302 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// DefaultPollInterval est l'intervalle entre deux relevés lorsque ni le
+// manifeste (CapabilitySpec.PollInterval) ni le plugin ne le fixent.
+const DefaultPollInterval = time.Minute
+
+// MinPollInterval est le plus court intervalle entre deux relevés, quel que
+// soit celui demandé par le plugin (PollResult.Interval) ou le manifeste.
+const MinPollInterval = time.Second
+
+// MaxPollSeen borne le nombre d'identifiants d'événements retenus par
+// déclencheur pour écarter les doublons.
+const MaxPollSeen = 1000
+
+// PollEvent est un événement relevé par un déclencheur.
+type PollEvent struct {
+	// ID identifie l'événement à la source ; il sert à écarter les
+	// doublons d'un relevé à l'autre. Vide, l'événement n'est pas dédoublonné.
+	ID   string
+	Data interface{}
+	// Time est l'heure de l'événement à la source, zéro si inconnue.
+	Time time.Time
+}
+
+// Payload renvoie le TriggerPayload de l'événement, pour le déclencheur
+// name.
+func (e PollEvent) Payload(name string) TriggerPayload {
+	return TriggerPayload{
+		Source: TriggerSource{Kind: TriggerKindPoll, Name: name, ReceivedAt: time.Now().UTC(), EventID: e.ID},
+		Body:   e.Data,
+	}
+}
+
+// PollResult est le résultat d'un relevé.
+type PollResult struct {
+	Events []PollEvent
+	// Cursor est à repasser au relevé suivant ; vide, le curseur précédent
+	// est conservé.
+	Cursor string
+	// Interval remplace l'intervalle habituel avant le prochain relevé
+	// (plus court s'il reste des événements, plus long si la source le
+	// demande), zéro pour le garder. Il ne descend pas sous MinPollInterval.
+	Interval time.Duration
+}
+
+// Poller peut être implémentée par un NodeExecutor dont les déclencheurs
+// relèvent périodiquement une source qui ne sait pas pousser ses
+// événements. Poll renvoie les événements survenus depuis cursor (vide au
+// premier relevé) et le curseur suivant ; node porte la configuration du
+// déclencheur et ctx ses secrets. Le moteur conserve le curseur et écarte
+// les doublons (voir PollOnce) : le plugin peut renvoyer un même événement
+// deux fois sans risque. Un plugin qui lit une file implémente en outre
+// Acknowledger pour ne retirer les messages qu'une fois confirmés.
+type Poller interface {
+	Poll(node Node, ctx ExecutionContext, cursor string) (PollResult, error)
+}
+
+// PollSource est ce que PollOnce et RunPoller attendent d'un plugin, comme
+// NodeExecutorGRPC.
+type PollSource interface {
+	Poller
+	PollInterval(uses string) time.Duration
+}
+
+// Poll relève les événements du déclencheur node. Les plugins qui ne le
+// supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) Poll(node Node, ctx ExecutionContext, cursor string) (PollResult, error) {
+	pNode, pCtx, err := m.nodeRequest(node, ctx)
+	if err != nil {
+		return PollResult{}, err
+	}
+	timeout := m.executeTimeout(node, ctx)
+	callCtx, cancel := m.callContext(timeout, "")
+	defer cancel()
+	resp, err := m.client.Poll(callCtx, &proto.PollRequest{Node: pNode, Context: pCtx, Cursor: cursor})
+	if err != nil {
+		err = withTimeout(fromRPCError("Poll", err), timeout)
+		m.observeBackpressure(err)
+		return PollResult{}, err
+	}
+	return fromProtoPollResponse(m.codec(), resp)
+}
+
+// PollInterval renvoie l'intervalle entre deux relevés du déclencheur uses,
+// d'après le manifeste du plugin, ou DefaultPollInterval.
+func (m *NodeExecutorGRPC) PollInterval(uses string) time.Duration {
+	if manifest := m.loadManifest(); manifest != nil {
+		if spec := manifest.Capability(uses); spec != nil {
+			if d, err := time.ParseDuration(spec.PollInterval); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return DefaultPollInterval
+}
+
+func (s *NodeExecutorGRPCServer) Poll(ctx context.Context, req *proto.PollRequest) (*proto.PollResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	p, ok := implementation[Poller](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.Poll(ctx, req)
+	}
+	c := s.codec(ctx)
+	node, err := fromProtoNode(c, req.Node, s.options.MaxNodeDepth)
+	if err != nil {
+		return nil, err
+	}
+	var execCtx ExecutionContext
+	if req.Context != nil {
+		if execCtx, err = fromProtoExecutionContext(c, req.Context); err != nil {
+			return nil, err
+		}
+	}
+	execCtx.Deadline, _ = ctx.Deadline()
+	execCtx.done = ctx.Done()
+	res, err := p.Poll(node, execCtx, req.Cursor)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	return toProtoPollResponse(c, res)
+}
+
+// PollCheckpoint est l'état d'un déclencheur par relevé entre deux relevés.
+type PollCheckpoint struct {
+	Cursor string `json:"cursor,omitempty"`
+	// Seen sont les identifiants des derniers événements émis, du plus
+	// ancien au plus récent, au plus MaxPollSeen.
+	Seen []string `json:"seen,omitempty"`
+}
+
+// PollStore conserve les PollCheckpoint du moteur, par clé de déclencheur.
+// Load renvoie un PollCheckpoint vide pour une clé inconnue.
+type PollStore interface {
+	Load(key string) (PollCheckpoint, error)
+	Save(key string, cp PollCheckpoint) error
+}
+
+// MemoryPollStore est un PollStore en mémoire, pour les tests et les moteurs
+// sans persistance. Son zéro est prêt à l'emploi.
+type MemoryPollStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]PollCheckpoint
+}
+
+func (s *MemoryPollStore) Load(key string) (PollCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[key], nil
+}
+
+func (s *MemoryPollStore) Save(key string, cp PollCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoints == nil {
+		s.checkpoints = map[string]PollCheckpoint{}
+	}
+	s.checkpoints[key] = cp
+	return nil
+}
+
+// PollTrigger est un déclencheur par relevé tel que le moteur le planifie.
+type PollTrigger struct {
+	// Key identifie le déclencheur dans le PollStore (workflow et nom du
+	// déclencheur, par exemple).
+	Key     string
+	Node    Node
+	Context ExecutionContext
+	// Dedupe, s'il est renseigné, écarte en plus les événements déjà émis
+	// par un autre déclencheur ou une autre instance du moteur (voir
+	// DedupeKey), au-delà des MaxPollSeen derniers identifiants retenus.
+	Dedupe *Deduplicator
+}
+
+// PollOnce effectue un relevé de t : il charge son curseur, appelle Poll,
+// transmet à emit les événements qui n'ont pas déjà été émis, puis
+// enregistre le nouveau curseur. Si emit échoue, le curseur n'est pas avancé
+// et les événements suivants seront relevés à nouveau. Si src implémente
+// Acknowledger, les événements acceptés (doublons compris) sont ensuite
+// confirmés par Ack et les autres refusés par Nack. Il renvoie le délai
+// avant le prochain relevé.
+func PollOnce(src PollSource, store PollStore, t PollTrigger, emit func(PollEvent) error) (time.Duration, error) {
+	interval := src.PollInterval(t.Node.Uses)
+	cp, err := store.Load(t.Key)
+	if err != nil {
+		return interval, fmt.Errorf("failed to load poll checkpoint %s: %w", t.Key, err)
+	}
+	res, err := src.Poll(t.Node, t.Context, cp.Cursor)
+	if err != nil {
+		if d, ok := BackpressureOf(err); ok && d > interval {
+			interval = d
+		}
+		return interval, err
+	}
+	if res.Interval > 0 {
+		interval = max(res.Interval, MinPollInterval)
+	}
+	seen := make(map[string]bool, len(cp.Seen))
+	for _, id := range cp.Seen {
+		seen[id] = true
+	}
+	var accepted, rejected []string
+	var emitErr error
+	for i, e := range res.Events {
+		if e.ID != "" && seen[e.ID] {
+			accepted = append(accepted, e.ID)
+			continue
+		}
+		if t.Dedupe != nil {
+			if first, _ := t.Dedupe.First(e.Payload(t.Node.ID)); !first {
+				if e.ID != "" {
+					accepted = append(accepted, e.ID)
+				}
+				continue
+			}
+		}
+		if emitErr = emit(e); emitErr != nil {
+			if t.Dedupe != nil {
+				t.Dedupe.Forget(e.Payload(t.Node.ID))
+			}
+			for _, rest := range res.Events[i:] {
+				if rest.ID != "" {
+					rejected = append(rejected, rest.ID)
+				}
+			}
+			break
+		}
+		if e.ID != "" {
+			seen[e.ID] = true
+			cp.Seen = append(cp.Seen, e.ID)
+			accepted = append(accepted, e.ID)
+		}
+	}
+	if len(cp.Seen) > MaxPollSeen {
+		cp.Seen = append([]string(nil), cp.Seen[len(cp.Seen)-MaxPollSeen:]...)
+	}
+	if emitErr == nil && res.Cursor != "" {
+		cp.Cursor = res.Cursor
+	}
+	if err := store.Save(t.Key, cp); err != nil {
+		// Rien n'est confirmé : le plugin relivrera les événements.
+		return interval, errors.Join(emitErr, fmt.Errorf("failed to save poll checkpoint %s: %w", t.Key, err))
+	}
+	return interval, errors.Join(emitErr, acknowledge(src, t, accepted, true), acknowledge(src, t, rejected, false))
+}
+
+// RunPoller relève t à intervalles réguliers jusqu'à l'annulation de ctx,
+// qu'il renvoie alors. Les erreurs d'un relevé sont transmises à onError,
+// si elle est renseignée, et n'interrompent pas la boucle.
+func RunPoller(ctx context.Context, src PollSource, store PollStore, t PollTrigger, emit func(PollEvent) error, onError func(error)) error {
+	for {
+		interval, err := PollOnce(src, store, t, emit)
+		// src peut être un PollSource tiers dont PollInterval n'est pas borné.
+		interval = max(interval, MinPollInterval)
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func toProtoPollResponse(c Codec, r PollResult) (*proto.PollResponse, error) {
+	events := make([]*proto.PollEvent, len(r.Events))
+	for i, e := range r.Events {
+		data, err := c.Marshal(e.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode poll event %q: %w", e.ID, err)
+		}
+		events[i] = &proto.PollEvent{Id: e.ID, Data: data, TimeUnixMs: unixMillis(e.Time)}
+	}
+	return &proto.PollResponse{Events: events, Cursor: r.Cursor, IntervalMs: r.Interval.Milliseconds()}, nil
+}
+
+func fromProtoPollResponse(c Codec, p *proto.PollResponse) (PollResult, error) {
+	events := make([]PollEvent, len(p.Events))
+	for i, e := range p.Events {
+		events[i] = PollEvent{ID: e.Id, Time: fromUnixMillis(e.TimeUnixMs)}
+		if len(e.Data) > 0 {
+			if err := c.Unmarshal(e.Data, &events[i].Data); err != nil {
+				return PollResult{}, fmt.Errorf("invalid poll event %q: %w", e.Id, err)
+			}
+		}
+	}
+	return PollResult{Events: events, Cursor: p.Cursor, Interval: time.Duration(p.IntervalMs) * time.Millisecond}, nil
+}
@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"errors"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrEmptyPool est renvoyée par un Pool sans instance.
+var ErrEmptyPool = errors.New("plugin pool is empty")
+
+// Pool répartit les exécutions entre plusieurs instances d'un même plugin.
+// Les nœuds sans Affinity sont distribués à tour de rôle. Les nœuds qui
+// partagent une même Affinity sont toujours envoyés à la même instance, ce
+// dont ont besoin les plugins à état (session websocket, analyseur
+// incrémental). L'instance est choisie par hachage de rendez-vous : ajouter
+// ou retirer une instance ne déplace que les clés qui lui étaient associées.
+//
+// Pool implémente NodeExecutor et peut être utilisé à la place d'une
+// instance unique.
+type Pool struct {
+	mu        sync.RWMutex
+	names     []string
+	instances map[string]NodeExecutor
+	next      atomic.Uint64
+}
+
+// NewPool crée un pool vide.
+func NewPool() *Pool {
+	return &Pool{instances: map[string]NodeExecutor{}}
+}
+
+// Add ajoute ou remplace l'instance nommée name. Le nom doit être stable
+// d'un redémarrage à l'autre pour préserver le routage par affinité.
+func (p *Pool) Add(name string, executor NodeExecutor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.instances[name]; !ok {
+		p.names = append(p.names, name)
+		sort.Strings(p.names)
+	}
+	p.instances[name] = executor
+}
+
+// Remove retire l'instance nommée name. Les clés d'affinité qui lui étaient
+// associées sont redistribuées entre les instances restantes.
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.instances[name]; !ok {
+		return
+	}
+	delete(p.instances, name)
+	for i, n := range p.names {
+		if n == name {
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// Len renvoie le nombre d'instances du pool.
+func (p *Pool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.names)
+}
+
+// Pick renvoie le nom et l'instance qui exécuteraient node.
+func (p *Pool) Pick(node Node) (string, NodeExecutor, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.names) == 0 {
+		return "", nil, ErrEmptyPool
+	}
+	var name string
+	if node.Affinity == "" {
+		name = p.names[(p.next.Add(1)-1)%uint64(len(p.names))]
+	} else {
+		name = rendezvous(p.names, node.Affinity)
+	}
+	return name, p.instances[name], nil
+}
+
+// Execute exécute node sur l'instance choisie par Pick.
+func (p *Pool) Execute(node Node, ctx ExecutionContext) (interface{}, error) {
+	_, executor, err := p.Pick(node)
+	if err != nil {
+		return nil, err
+	}
+	return executor.Execute(node, ctx)
+}
+
+// ExecuteResult exécute node sur l'instance choisie par Pick et renvoie
+// l'enveloppe complète du résultat (voir ExecuteResult).
+func (p *Pool) ExecuteResult(node Node, ctx ExecutionContext) (*Result, error) {
+	_, executor, err := p.Pick(node)
+	if err != nil {
+		return nil, err
+	}
+	return ExecuteResult(executor, node, ctx)
+}
+
+// ExecuteStream transmet à emit les éléments produits par node sur
+// l'instance choisie par Pick (voir ForEachItem).
+func (p *Pool) ExecuteStream(node Node, ctx ExecutionContext, emit func(item interface{}) error) error {
+	_, executor, err := p.Pick(node)
+	if err != nil {
+		return err
+	}
+	return ForEachItem(executor, node, ctx, emit)
+}
+
+// GetCapabilities interroge une instance du pool ; toutes les instances
+// sont supposées exécuter le même binaire.
+func (p *Pool) GetCapabilities() ([]string, error) {
+	_, executor, err := p.Pick(Node{})
+	if err != nil {
+		return nil, err
+	}
+	return executor.GetCapabilities()
+}
+
+// rendezvous renvoie le nom de plus fort poids pour key.
+func rendezvous(names []string, key string) string {
+	var best string
+	var bestWeight uint64
+	for i, name := range names {
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		if w := h.Sum64(); i == 0 || w > bestWeight {
+			best, bestWeight = name, w
+		}
+	}
+	return best
+}
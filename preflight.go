@@ -0,0 +1,137 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// CheckStatus est l'issue d'une vérification préalable.
+type CheckStatus string
+
+const (
+	CheckOK      CheckStatus = "ok"
+	CheckWarning CheckStatus = "warning"
+	CheckError   CheckStatus = "error"
+)
+
+// PreflightCheck est le diagnostic d'une dépendance externe du plugin.
+type PreflightCheck struct {
+	// Name identifie la vérification ("credentials", "api-reachable"...).
+	Name    string
+	Status  CheckStatus
+	Message string
+	// Remediation suggère à l'opérateur comment corriger le problème.
+	Remediation string
+	Duration    time.Duration
+}
+
+// PreflightReport regroupe les vérifications d'un plugin.
+type PreflightReport struct {
+	Checks []PreflightCheck
+}
+
+// Preflighter peut être implémentée par un NodeExecutor pour vérifier ses
+// dépendances externes (identifiants valides, API joignable) au moment de
+// son enregistrement, avant qu'un workflow ne s'exécute. Les échecs sont
+// décrits dans le rapport ; une erreur signale que les vérifications n'ont pas
+// pu être menées.
+type Preflighter interface {
+	Preflight(secrets map[string]string) (PreflightReport, error)
+}
+
+// Check exécute fn et ajoute son diagnostic au rapport : CheckOK si fn
+// réussit, CheckError avec le message de l'erreur sinon.
+func (r *PreflightReport) Check(name, remediation string, fn func() error) {
+	start := time.Now()
+	err := fn()
+	c := PreflightCheck{Name: name, Status: CheckOK, Duration: time.Since(start)}
+	if err != nil {
+		c.Status = CheckError
+		c.Message = err.Error()
+		c.Remediation = remediation
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+// OK indique qu'aucune vérification n'est en erreur.
+func (r PreflightReport) OK() bool {
+	for _, c := range r.Checks {
+		if c.Status == CheckError {
+			return false
+		}
+	}
+	return true
+}
+
+// Err renvoie une erreur résumant les vérifications en échec, ou nil.
+func (r PreflightReport) Err() error {
+	var failed []string
+	for _, c := range r.Checks {
+		if c.Status == CheckError {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name, c.Message))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("preflight failed: %s", strings.Join(failed, "; "))
+}
+
+// Preflight demande au plugin de vérifier ses dépendances. Les plugins qui ne
+// le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) Preflight(secrets map[string]string) (PreflightReport, error) {
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.Preflight(callCtx, &proto.PreflightRequest{Secrets: secrets})
+	if err != nil {
+		return PreflightReport{}, withTimeout(fromRPCError("Preflight", err), m.opts.callTimeout())
+	}
+	return fromProtoPreflightResponse(resp), nil
+}
+
+func (s *NodeExecutorGRPCServer) Preflight(ctx context.Context, req *proto.PreflightRequest) (*proto.PreflightResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	p, ok := implementation[Preflighter](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.Preflight(ctx, req)
+	}
+	report, err := p.Preflight(req.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoPreflightResponse(report), nil
+}
+
+func toProtoPreflightResponse(r PreflightReport) *proto.PreflightResponse {
+	checks := make([]*proto.PreflightCheck, len(r.Checks))
+	for i, c := range r.Checks {
+		checks[i] = &proto.PreflightCheck{
+			Name:        c.Name,
+			Status:      string(c.Status),
+			Message:     c.Message,
+			Remediation: c.Remediation,
+			DurationMs:  c.Duration.Milliseconds(),
+		}
+	}
+	return &proto.PreflightResponse{Checks: checks}
+}
+
+func fromProtoPreflightResponse(p *proto.PreflightResponse) PreflightReport {
+	checks := make([]PreflightCheck, len(p.Checks))
+	for i, c := range p.Checks {
+		checks[i] = PreflightCheck{
+			Name:        c.Name,
+			Status:      CheckStatus(c.Status),
+			Message:     c.Message,
+			Remediation: c.Remediation,
+			Duration:    time.Duration(c.DurationMs) * time.Millisecond,
+		}
+	}
+	return PreflightReport{Checks: checks}
+}
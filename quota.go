@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"fmt"
+	"math"
+)
+
+// QuotaReporter reçoit la consommation des exécutions, pour la facturation à
+// l'usage et les limites par locataire, sans instrumenter chaque plugin.
+// ReportUsage est appelée par le client après chaque Execute réussi, et à la
+// fin de chaque ExecuteStream avec une unité par élément reçu, y compris
+// lorsque le flux échoue après en avoir livré. Elle ne doit pas bloquer.
+type QuotaReporter interface {
+	ReportUsage(tenant, capability string, units float64)
+}
+
+// usageUnits renvoie la consommation de res : Result.Units, une unité par
+// défaut.
+func usageUnits(res *Result) float64 {
+	if res.Units != nil {
+		return *res.Units
+	}
+	return 1
+}
+
+// checkUnits refuse une consommation négative, infinie ou NaN, que
+// QuotaReporter ne saurait pas facturer.
+func checkUnits(units *float64) error {
+	if units != nil && (math.IsNaN(*units) || math.IsInf(*units, 0) || *units < 0) {
+		return fmt.Errorf("invalid usage units %v: must be finite and non-negative", *units)
+	}
+	return nil
+}
+
+// reportUsage transmet la consommation de node à ClientOptions.Quota.
+func (m *NodeExecutorGRPC) reportUsage(node Node, ctx ExecutionContext, res *Result) {
+	m.reportUnits(node, ctx, usageUnits(res))
+}
+
+func (m *NodeExecutorGRPC) reportUnits(node Node, ctx ExecutionContext, units float64) {
+	if m.opts.Quota == nil {
+		return
+	}
+	m.opts.Quota.ReportUsage(ctx.TenantID, node.Uses, units)
+}
@@ -0,0 +1,214 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// RateLimit est l'état du quota d'une API tierce, tel que le plugin l'a
+// observé. Le plugin le joint à un résultat (Result.RateLimit) ou à une
+// erreur (RateLimitError) ; le client le retient par capacité pour que le
+// moteur retarde les appels suivants au lieu d'épuiser ses tentatives.
+type RateLimit struct {
+	// Limit est la taille de la fenêtre, 0 si elle est inconnue.
+	Limit     int64
+	Remaining int64
+	// Reset est la fin de la fenêtre, zéro si elle est inconnue.
+	Reset time.Time
+}
+
+// Exhausted indique que le quota est épuisé jusqu'à Reset.
+func (r RateLimit) Exhausted() bool {
+	return r.Remaining <= 0 && !r.Reset.IsZero()
+}
+
+// Delay renvoie le temps à attendre avant le prochain appel, nul si le
+// quota n'est pas épuisé.
+func (r RateLimit) Delay() time.Duration {
+	if !r.Exhausted() {
+		return 0
+	}
+	if d := time.Until(r.Reset); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// RateLimitFromHeader lit l'état du quota dans les en-têtes d'une réponse
+// HTTP : Retry-After, puis les en-têtes X-RateLimit-* et RateLimit-*. Elle
+// renvoie false si aucun n'est présent.
+func RateLimitFromHeader(h http.Header) (RateLimit, bool) {
+	var r RateLimit
+	found := false
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if n, err := strconv.ParseInt(h.Get(prefix+"Limit"), 10, 64); err == nil {
+			r.Limit, found = n, true
+		}
+		if n, err := strconv.ParseInt(h.Get(prefix+"Remaining"), 10, 64); err == nil {
+			r.Remaining, found = n, true
+		}
+		if n, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+			// Selon les API, Reset est une date Unix ou un délai en secondes.
+			if n > 1e9 {
+				r.Reset = time.Unix(n, 0)
+			} else {
+				r.Reset = time.Now().Add(time.Duration(n) * time.Second)
+			}
+			found = true
+		}
+		if found {
+			break
+		}
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			r.Remaining, r.Reset, found = 0, time.Now().Add(time.Duration(n)*time.Second), true
+		} else if t, err := http.ParseTime(v); err == nil {
+			r.Remaining, r.Reset, found = 0, t, true
+		}
+	}
+	return r, found
+}
+
+// RateLimitError est une erreur de quota accompagnée de son état. Elle
+// enveloppe ErrRateLimited et traverse gRPC avec son état.
+type RateLimitError struct {
+	RateLimit RateLimit
+	// Err est la cause, facultative.
+	Err error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return ErrRateLimited.Error()
+}
+
+func (e *RateLimitError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrRateLimited, e.Err}
+	}
+	return []error{ErrRateLimited}
+}
+
+func (e *RateLimitError) FailureCode() string { return string(CodeRateLimited) }
+
+func (e *RateLimitError) Retryable() bool { return true }
+
+// Clés des détails d'un maillon RateLimitError.
+const (
+	rateLimitLimit     = "rateLimit.limit"
+	rateLimitRemaining = "rateLimit.remaining"
+	rateLimitReset     = "rateLimit.reset"
+)
+
+func (e *RateLimitError) ErrorDetails() map[string]string {
+	return map[string]string{
+		rateLimitLimit:     strconv.FormatInt(e.RateLimit.Limit, 10),
+		rateLimitRemaining: strconv.FormatInt(e.RateLimit.Remaining, 10),
+		rateLimitReset:     strconv.FormatInt(unixMillis(e.RateLimit.Reset), 10),
+	}
+}
+
+// RateLimitOf renvoie l'état du quota porté par err, qu'elle soit une
+// *RateLimitError ou son équivalent reçu d'un plugin.
+func RateLimitOf(err error) (RateLimit, bool) {
+	var rle *RateLimitError
+	if errors.As(err, &rle) {
+		return rle.RateLimit, true
+	}
+	var re *RemoteError
+	if !errors.As(err, &re) {
+		return RateLimit{}, false
+	}
+	for _, l := range re.Chain {
+		reset, ok := l.Details[rateLimitReset]
+		if !ok {
+			continue
+		}
+		var r RateLimit
+		r.Limit, _ = strconv.ParseInt(l.Details[rateLimitLimit], 10, 64)
+		r.Remaining, _ = strconv.ParseInt(l.Details[rateLimitRemaining], 10, 64)
+		ms, _ := strconv.ParseInt(reset, 10, 64)
+		r.Reset = fromUnixMillis(ms)
+		return r, true
+	}
+	return RateLimit{}, false
+}
+
+// RateLimitTracker est implémentée par les clients qui retiennent l'état des
+// quotas rapporté par le plugin, comme NodeExecutorGRPC. Le moteur consulte
+// RateLimitDelay avant de planifier un nœud utilisant uses.
+type RateLimitTracker interface {
+	RateLimit(uses string) (RateLimit, bool)
+	RateLimitDelay(uses string) time.Duration
+}
+
+// rateLimits retient le dernier état de quota connu par capacité.
+type rateLimits struct {
+	mu     sync.Mutex
+	byUses map[string]RateLimit
+}
+
+func (r *rateLimits) record(uses string, rl RateLimit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byUses == nil {
+		r.byUses = map[string]RateLimit{}
+	}
+	r.byUses[uses] = rl
+}
+
+func (r *rateLimits) get(uses string) (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rl, ok := r.byUses[uses]
+	if ok && !rl.Reset.IsZero() && !time.Now().Before(rl.Reset) {
+		// La fenêtre est écoulée : l'état n'est plus significatif.
+		delete(r.byUses, uses)
+		return RateLimit{}, false
+	}
+	return rl, ok
+}
+
+// observeRateLimit retient l'état du quota rapporté pour node, par son
+// résultat ou son erreur.
+func (m *NodeExecutorGRPC) observeRateLimit(node Node, res *Result, err error) {
+	if res != nil && res.RateLimit != nil {
+		m.rateLimits.record(node.Uses, *res.RateLimit)
+	} else if rl, ok := RateLimitOf(err); ok {
+		m.rateLimits.record(node.Uses, rl)
+	}
+}
+
+// RateLimit renvoie le dernier état de quota rapporté pour la capacité uses.
+func (m *NodeExecutorGRPC) RateLimit(uses string) (RateLimit, bool) {
+	return m.rateLimits.get(uses)
+}
+
+// RateLimitDelay renvoie le temps à attendre avant d'appeler à nouveau la
+// capacité uses, nul si son quota n'est pas épuisé.
+func (m *NodeExecutorGRPC) RateLimitDelay(uses string) time.Duration {
+	rl, _ := m.rateLimits.get(uses)
+	return rl.Delay()
+}
+
+func toProtoRateLimit(r *RateLimit) *proto.RateLimit {
+	if r == nil {
+		return nil
+	}
+	return &proto.RateLimit{Limit: r.Limit, Remaining: r.Remaining, ResetUnixMs: unixMillis(r.Reset)}
+}
+
+func fromProtoRateLimit(r *proto.RateLimit) *RateLimit {
+	if r == nil {
+		return nil
+	}
+	return &RateLimit{Limit: r.Limit, Remaining: r.Remaining, Reset: fromUnixMillis(r.ResetUnixMs)}
+}
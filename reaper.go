@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultReapInterval est la période de ReaperOptions.Interval par défaut.
+const DefaultReapInterval = 30 * time.Second
+
+// ReaperOptions paramètre un Reaper.
+type ReaperOptions struct {
+	// TTL arrête les plugins inactifs depuis plus longtemps. Zéro : pas de
+	// limite de durée.
+	TTL time.Duration
+	// MaxRunning borne le nombre de processus résidents : au-delà, les
+	// plugins inactifs les moins récemment utilisés sont arrêtés. Zéro : pas
+	// de limite.
+	MaxRunning int
+	// Interval est la période des balayages, DefaultReapInterval par défaut.
+	Interval time.Duration
+	// OnReap est appelée pour chaque plugin arrêté, avec sa durée
+	// d'inactivité.
+	OnReap func(name string, idle time.Duration)
+}
+
+// Reaper arrête les processus des LazyPlugin restés inactifs, pour les
+// installations à la mémoire comptée. Les appels en cours ne sont jamais
+// interrompus, et un plugin arrêté est relancé par son prochain appel.
+type Reaper struct {
+	opts ReaperOptions
+
+	mu      sync.Mutex
+	plugins map[string]*LazyPlugin
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewReaper démarre un Reaper. Close doit être appelée pour l'arrêter.
+func NewReaper(opts ReaperOptions) *Reaper {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultReapInterval
+	}
+	r := &Reaper{
+		opts:    opts,
+		plugins: map[string]*LazyPlugin{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go r.loop()
+	return r
+}
+
+// Add place p sous la surveillance du Reaper, sous le nom name.
+func (r *Reaper) Add(name string, p *LazyPlugin) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plugins[name] = p
+}
+
+// Remove retire name de la surveillance, sans arrêter son processus.
+func (r *Reaper) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.plugins, name)
+}
+
+// Close arrête les balayages. Les processus des plugins ne sont pas arrêtés.
+func (r *Reaper) Close() {
+	close(r.stop)
+	<-r.done
+}
+
+func (r *Reaper) loop() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.Sweep()
+		}
+	}
+}
+
+// Sweep applique TTL puis MaxRunning et renvoie les noms des plugins
+// arrêtés. Elle est appelée périodiquement et peut l'être à la demande.
+func (r *Reaper) Sweep() []string {
+	type candidate struct {
+		name     string
+		plugin   *LazyPlugin
+		lastUsed time.Time
+	}
+	r.mu.Lock()
+	var idle []candidate
+	running := 0
+	for name, p := range r.plugins {
+		if !p.Running() {
+			continue
+		}
+		running++
+		if lastUsed, ok := p.idleSince(); ok {
+			idle = append(idle, candidate{name, p, lastUsed})
+		}
+	}
+	r.mu.Unlock()
+
+	// Les moins récemment utilisés d'abord.
+	sort.Slice(idle, func(i, j int) bool { return idle[i].lastUsed.Before(idle[j].lastUsed) })
+	now := time.Now()
+	var reaped []string
+	for _, c := range idle {
+		expired := r.opts.TTL > 0 && now.Sub(c.lastUsed) >= r.opts.TTL
+		excess := r.opts.MaxRunning > 0 && running > r.opts.MaxRunning
+		if !expired && !excess {
+			continue
+		}
+		if !c.plugin.stopIfIdleSince(c.lastUsed) {
+			continue
+		}
+		running--
+		reaped = append(reaped, c.name)
+		if r.opts.OnReap != nil {
+			r.opts.OnReap(c.name, now.Sub(c.lastUsed))
+		}
+	}
+	return reaped
+}
@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderRequestID est l'en-tête HTTP qui propage l'identifiant de requête
+// vers les API externes.
+const HeaderRequestID = "X-Request-ID"
+
+// NewRequestID renvoie un identifiant de requête aléatoire de 128 bits.
+func NewRequestID() string {
+	return newEventID()
+}
+
+// SetRequestHeader ajoute l'identifiant de requête de l'exécution aux
+// en-têtes d'un appel sortant, pour le retrouver dans les journaux de l'API.
+func (c ExecutionContext) SetRequestHeader(h http.Header) {
+	if c.RequestID != "" {
+		h.Set(HeaderRequestID, c.RequestID)
+	}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID renvoie une copie de ctx portant l'identifiant de
+// requête id.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext renvoie l'identifiant de requête porté par ctx : celui
+// de ContextWithRequestID, ou à défaut celui des métadonnées de l'appel gRPC
+// en cours.
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return CallMetadataFromContext(ctx).RequestID
+}
+
+// withRequestID renvoie ctx avec un identifiant de requête, généré s'il
+// n'en a pas.
+func withRequestID(ctx ExecutionContext) ExecutionContext {
+	if ctx.RequestID == "" {
+		ctx.RequestID = NewRequestID()
+	}
+	return ctx
+}
+
+// executeContext est callContext pour une exécution : il porte en plus
+// l'identifiant de requête de ctx (voir withRequestID).
+func (m *NodeExecutorGRPC) executeContext(timeout time.Duration, ctx ExecutionContext) (context.Context, context.CancelFunc) {
+	callCtx, cancel := m.callContext(timeout, ctx.RunID)
+	return metadata.AppendToOutgoingContext(callCtx, MetadataRequestID, ctx.RequestID), cancel
+}
+
+// RequestIDError enveloppe une erreur d'exécution avec l'identifiant de la
+// requête, pour la retrouver dans les journaux du plugin.
+type RequestIDError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestIDError) Error() string {
+	return fmt.Sprintf("%v (request %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestIDError) Unwrap() error { return e.Err }
+
+// RequestIDOf renvoie l'identifiant de requête porté par err, vide s'il n'en
+// porte pas.
+func RequestIDOf(err error) string {
+	var r *RequestIDError
+	if errors.As(err, &r) {
+		return r.RequestID
+	}
+	return ""
+}
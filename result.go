@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/vcr"
+)
+
+// Result est l'enveloppe qu'un NodeExecutor peut renvoyer depuis Execute (en
+// valeur ou en pointeur) pour accompagner sa sortie de métadonnées. Une
+// valeur quelconque est équivalente à &Result{Value: valeur}.
+type Result struct {
+	Value interface{}
+	// NextCursor est renseigné par une capacité « source » paginée lorsque
+	// d'autres pages sont disponibles. Le moteur rappelle alors Execute avec
+	// ExecutionContext.Cursor égal à cette valeur.
+	NextCursor string
+	// Warnings sont des problèmes non bloquants que le moteur affiche dans
+	// l'interface d'exécution sans faire échouer le nœud.
+	Warnings []Warning
+	// ContentType est le type MIME de Value lorsqu'il est connu.
+	ContentType string
+	// Kind indique comment afficher Value ; voir OutputKindOf.
+	Kind OutputKind
+	// Cassette porte les échanges HTTP enregistrés en ModeRecord, à
+	// conserver pour rejouer le nœud.
+	Cassette *vcr.Cassette
+	// RateLimit est l'état du quota de l'API tierce observé pendant
+	// l'exécution, s'il est connu.
+	RateLimit *RateLimit
+	// Units est la consommation de l'exécution, dans l'unité de facturation
+	// de la capacité (appels, crédits d'API, lignes), finie et positive ou
+	// nulle. Nil compte pour une unité ; voir SetUnits et QuotaReporter.
+	Units *float64
+	// Continuation demande au moteur de rappeler le nœud plus tard ; Value
+	// est alors une sortie provisoire. Voir ContinueAfter.
+	Continuation *ScheduleContinuation
+	// RequestID est l'identifiant de la requête, renseigné par le client
+	// gRPC ; il n'est pas transmis par le plugin.
+	RequestID string
+}
+
+// Warning est un avertissement attaché à un résultat réussi.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// Codes d'avertissement courants. Les plugins peuvent en définir d'autres.
+const (
+	WarningDeprecatedAPI  = "deprecated-api"
+	WarningRateLimitNear  = "rate-limit-near"
+	WarningRowsSkipped    = "rows-skipped"
+	WarningTruncated      = "truncated"
+	WarningPartialContent = "partial-content"
+	// WarningSchemaDrift signale une sortie non conforme au schéma déclaré
+	// par la capacité (voir ClientOptions.OutputSchema).
+	WarningSchemaDrift = "schema-drift"
+	// WarningSchemaUnchecked signale une sortie qui n'a pas pu être vérifiée,
+	// faute de manifeste.
+	WarningSchemaUnchecked = "schema-unchecked"
+)
+
+// SetUnits fixe la consommation de l'exécution ; zéro la déclare gratuite.
+func (r *Result) SetUnits(units float64) {
+	r.Units = &units
+}
+
+// Warn ajoute un avertissement au résultat.
+func (r *Result) Warn(code, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, Warning{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// ResultExecutor est implémentée par les clients capables de renvoyer
+// l'enveloppe complète du résultat, comme NodeExecutorGRPC.
+type ResultExecutor interface {
+	ExecuteResult(node Node, ctx ExecutionContext) (*Result, error)
+}
+
+// ExecuteResult exécute node et renvoie toujours une enveloppe, que exec soit
+// un client gRPC ou une implémentation appelée en processus.
+func ExecuteResult(exec NodeExecutor, node Node, ctx ExecutionContext) (*Result, error) {
+	if re, ok := exec.(ResultExecutor); ok {
+		return re.ExecuteResult(node, ctx)
+	}
+	v, err := exec.Execute(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return asResult(v), nil
+}
+
+// Paginate appelle Execute page après page en propageant le curseur, et
+// transmet chaque valeur à fn. Elle s'arrête lorsque la capacité ne renvoie
+// plus de curseur ou que fn renvoie une erreur.
+func Paginate(exec NodeExecutor, node Node, ctx ExecutionContext, fn func(page interface{}) error) error {
+	seen := map[string]bool{}
+	for {
+		res, err := ExecuteResult(exec, node, ctx)
+		if err != nil {
+			return err
+		}
+		if err := fn(res.Value); err != nil {
+			return err
+		}
+		if res.NextCursor == "" {
+			return nil
+		}
+		if seen[res.NextCursor] {
+			return fmt.Errorf("node %q returned cursor %q twice", node.ID, res.NextCursor)
+		}
+		seen[res.NextCursor] = true
+		ctx.Cursor = res.NextCursor
+	}
+}
+
+func asResult(v interface{}) *Result {
+	switch r := v.(type) {
+	case *Result:
+		if r == nil {
+			return &Result{}
+		}
+		return r
+	case Result:
+		return &r
+	}
+	return &Result{Value: v}
+}
@@ -0,0 +1,109 @@
+package shared
+
+import "time"
+
+// RunStatus est l'état d'une exécution de workflow.
+type RunStatus string
+
+const (
+	RunPending   RunStatus = "pending"
+	RunRunning   RunStatus = "running"
+	RunSuspended RunStatus = "suspended"
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	RunCancelled RunStatus = "cancelled"
+)
+
+// Terminal indique si l'exécution est terminée.
+func (s RunStatus) Terminal() bool {
+	return s == RunSucceeded || s == RunFailed || s == RunCancelled
+}
+
+// NodeStatus est l'état d'un nœud au sein d'une exécution.
+type NodeStatus string
+
+const (
+	NodePending   NodeStatus = "pending"
+	NodeRunning   NodeStatus = "running"
+	NodeRetrying  NodeStatus = "retrying"
+	NodeSucceeded NodeStatus = "succeeded"
+	NodeFailed    NodeStatus = "failed"
+	NodeSkipped   NodeStatus = "skipped"
+	NodeCancelled NodeStatus = "cancelled"
+)
+
+// Terminal indique si le nœud ne sera plus exécuté.
+func (s NodeStatus) Terminal() bool {
+	switch s {
+	case NodeSucceeded, NodeFailed, NodeSkipped, NodeCancelled:
+		return true
+	}
+	return false
+}
+
+// RunRecord est l'état canonique d'une exécution, partagé par l'API du
+// moteur, la CLI et les tableaux de bord. Les heures nulles signalent une
+// étape non atteinte et sont omises en JSON.
+type RunRecord struct {
+	RunID      string       `json:"runId"`
+	Workflow   string       `json:"workflow"`
+	Status     RunStatus    `json:"status"`
+	StartedAt  time.Time    `json:"startedAt,omitzero"`
+	FinishedAt time.Time    `json:"finishedAt,omitzero"`
+	Error      string       `json:"error,omitempty"`
+	Nodes      []NodeRecord `json:"nodes"`
+}
+
+// NodeRecord est l'état d'un nœud au sein d'une exécution.
+type NodeRecord struct {
+	NodeID     string     `json:"nodeId"`
+	Uses       string     `json:"uses"`
+	Status     NodeStatus `json:"status"`
+	StartedAt  time.Time  `json:"startedAt,omitzero"`
+	FinishedAt time.Time  `json:"finishedAt,omitzero"`
+	Attempts   []Attempt  `json:"attempts,omitempty"`
+	// OutputRef désigne la sortie stockée par le moteur (clé d'objet, URL) ;
+	// la sortie elle-même n'est pas portée par l'enregistrement.
+	OutputRef string `json:"outputRef,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Attempt est une tentative d'exécution d'un nœud, numérotée à partir de 1.
+type Attempt struct {
+	Number     int       `json:"number"`
+	StartedAt  time.Time `json:"startedAt,omitzero"`
+	FinishedAt time.Time `json:"finishedAt,omitzero"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Duration renvoie la durée de l'exécution, ou le temps écoulé si elle est
+// en cours.
+func (r RunRecord) Duration() time.Duration {
+	return elapsed(r.StartedAt, r.FinishedAt)
+}
+
+// Node renvoie l'enregistrement du nœud id, ou nil.
+func (r *RunRecord) Node(id string) *NodeRecord {
+	for i := range r.Nodes {
+		if r.Nodes[i].NodeID == id {
+			return &r.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Duration renvoie la durée d'exécution du nœud, toutes tentatives
+// comprises, ou le temps écoulé s'il est en cours.
+func (n NodeRecord) Duration() time.Duration {
+	return elapsed(n.StartedAt, n.FinishedAt)
+}
+
+func elapsed(start, end time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	if end.IsZero() {
+		return time.Since(start)
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,109 @@
+package shared
+
+import "errors"
+
+// CompensateSuffix est ajouté à l'identifiant d'un nœud pour nommer sa
+// compensation lorsque Node.Compensate n'en fixe pas.
+const CompensateSuffix = ".compensate"
+
+// Compensation est une action à exécuter lors du dénouement d'une saga.
+type Compensation struct {
+	// For est l'identifiant du nœud dont l'effet est défait.
+	For string
+	// Node est Node.Compensate, son identifiant renseigné.
+	Node *Node
+}
+
+// validateCompensate vérifie la compensation de n : elle est exécutée par
+// le moteur au dénouement, hors du graphe des dépendances, et ne se
+// compense pas elle-même.
+func (n *Node) validateCompensate() error {
+	c := n.Compensate
+	if c == nil {
+		return nil
+	}
+	if c.Compensate != nil {
+		return errors.New("compensation cannot declare its own compensation")
+	}
+	if len(c.Needs) > 0 {
+		return errors.New("compensation cannot declare needs")
+	}
+	return nil
+}
+
+// UnwindOrder renvoie les compensations à exécuter lorsqu'un nœud échoue
+// après que les nœuds completed ont réussi, dans l'ordre où ils se sont
+// terminés : celles des nœuds qui en déclarent une, du plus récent au plus
+// ancien. Un nœud est ainsi compensé avant ceux dont il dépend. Un nœud
+// terminé plusieurs fois (itérations de Do) est compensé autant de fois ;
+// les identifiants inconnus de nodes sont ignorés.
+func UnwindOrder(nodes []*Node, completed []string) []Compensation {
+	byID := map[string]*Node{}
+	walkNodes(nodes, func(n *Node) {
+		if n.Compensate != nil {
+			byID[n.ID] = n
+		}
+	})
+	var out []Compensation
+	for i := len(completed) - 1; i >= 0; i-- {
+		n, ok := byID[completed[i]]
+		if !ok {
+			continue
+		}
+		c := *n.Compensate
+		if c.ID == "" {
+			c.ID = n.ID + CompensateSuffix
+		}
+		out = append(out, Compensation{For: n.ID, Node: &c})
+	}
+	return out
+}
+
+// UnwindLevels regroupe les compensations de UnwindOrder par niveau : celles
+// d'un même niveau peuvent s'exécuter en parallèle, chaque niveau après le
+// précédent. Une compensation est placée après celles des nœuds terminés
+// qui dépendent (Needs) de son nœud.
+func UnwindLevels(nodes []*Node, completed []string) [][]Compensation {
+	order := UnwindOrder(nodes, completed)
+	needs := map[string][]string{}
+	walkNodes(nodes, func(n *Node) {
+		needs[n.ID] = n.Needs
+	})
+	// level[id] est le niveau de la dernière compensation de id placée.
+	level := map[string]int{}
+	var levels [][]Compensation
+	for _, c := range order {
+		l := 0
+		for id, lv := range level {
+			if lv >= l && dependsOn(needs, id, c.For) {
+				l = lv + 1
+			}
+		}
+		if l == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], c)
+		level[c.For] = l
+	}
+	return levels
+}
+
+// dependsOn indique si from dépend, directement ou non, de to.
+func dependsOn(needs map[string][]string, from, to string) bool {
+	seen := map[string]bool{}
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range needs[id] {
+			if dep == to {
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				stack = append(stack, dep)
+			}
+		}
+	}
+	return false
+}
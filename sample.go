@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// SampleProvider peut être implémentée par un NodeExecutor pour renvoyer une
+// sortie réaliste de node avant toute exécution, afin que l'auteur d'un
+// workflow puisse y désigner les champs utilisés en aval. Le plugin peut
+// faire un appel réel léger (lire un seul enregistrement) avec les secrets et
+// la connexion de ctx, mais ne doit rien modifier.
+type SampleProvider interface {
+	SampleOutput(node Node, ctx ExecutionContext) (interface{}, error)
+}
+
+// SampleOutput demande au plugin une sortie d'exemple de node, après les
+// mêmes contrôles de permissions et de politique qu'Execute. Si le plugin
+// ne le supporte pas, elle renvoie la sortie du premier exemple du manifeste
+// pour la capacité, ou une erreur errors.ErrUnsupported s'il n'y en a pas.
+func (m *NodeExecutorGRPC) SampleOutput(node Node, ctx ExecutionContext) (interface{}, error) {
+	output, err := m.sampleOutput(node, ctx)
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return output, err
+	}
+	if manifest := m.loadManifest(); manifest != nil {
+		if spec := manifest.Capability(node.Uses); spec != nil {
+			for _, e := range spec.Examples {
+				if e.Output != nil {
+					return normalizeJSON(e.Output)
+				}
+			}
+		}
+	}
+	return nil, err
+}
+
+func (m *NodeExecutorGRPC) sampleOutput(node Node, ctx ExecutionContext) (interface{}, error) {
+	pNode, pCtx, err := m.nodeRequest(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.SampleOutput(callCtx, &proto.SampleOutputRequest{Node: pNode, Context: pCtx})
+	if err != nil {
+		return nil, withTimeout(fromRPCError("SampleOutput", err), m.opts.callTimeout())
+	}
+	var output interface{}
+	if len(resp.Output) > 0 {
+		if err := m.codec().Unmarshal(resp.Output, &output); err != nil {
+			return nil, fmt.Errorf("invalid sample output: %w", err)
+		}
+	}
+	return output, nil
+}
+
+func (s *NodeExecutorGRPCServer) SampleOutput(ctx context.Context, req *proto.SampleOutputRequest) (*proto.SampleOutputResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	p, ok := implementation[SampleProvider](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.SampleOutput(ctx, req)
+	}
+	c := s.codec(ctx)
+	node, err := fromProtoNode(c, req.Node, s.options.MaxNodeDepth)
+	if err != nil {
+		return nil, err
+	}
+	var execCtx ExecutionContext
+	if req.Context != nil {
+		if execCtx, err = fromProtoExecutionContext(c, req.Context); err != nil {
+			return nil, err
+		}
+	}
+	output, err := p.SampleOutput(node, execCtx)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	b, err := c.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sample output: %w", err)
+	}
+	return &proto.SampleOutputResponse{Output: b}, nil
+}
@@ -0,0 +1,320 @@
+// Package schedule analyse les expressions cron utilisées par les
+// déclencheurs Orkestra et calcule leurs prochaines occurrences. Le moteur,
+// les plugins de déclencheurs et la CLI l'utilisent pour valider les
+// planifications de manière identique.
+//
+// Formats acceptés :
+//
+//	min heure jour-du-mois mois jour-de-semaine
+//	sec min heure jour-du-mois mois jour-de-semaine
+//	@yearly, @monthly, @weekly, @daily, @hourly, @every <durée>
+//
+// Un préfixe "CRON_TZ=<zone>" (ou "TZ=<zone>") fixe le fuseau horaire.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule est une expression cron analysée.
+type Schedule struct {
+	expr string
+	loc  *time.Location
+
+	second, minute, hour, dom, month, dow uint64
+	// domStar et dowStar suivent la règle cron classique : si les deux champs
+	// sont restreints, un jour correspond s'il satisfait l'un OU l'autre.
+	domStar, dowStar bool
+
+	every time.Duration
+}
+
+type bounds struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secondBounds = bounds{name: "second", min: 0, max: 59}
+	minuteBounds = bounds{name: "minute", min: 0, max: 59}
+	hourBounds   = bounds{name: "hour", min: 0, max: 23}
+	domBounds    = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds  = bounds{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Parse analyse une expression cron. Sans préfixe de fuseau, l'expression est
+// évaluée en UTC.
+func Parse(expr string) (*Schedule, error) {
+	return ParseInLocation(expr, time.UTC)
+}
+
+// ParseInLocation est comme Parse mais utilise loc comme fuseau par défaut.
+func ParseInLocation(expr string, loc *time.Location) (*Schedule, error) {
+	s := &Schedule{expr: expr, loc: loc}
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.IndexAny(spec, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid schedule %q: missing expression after timezone", expr)
+		}
+		name := spec[strings.IndexByte(spec, '=')+1 : i]
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: unknown timezone %q", expr, name)
+		}
+		s.loc = l
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be at least 1s", expr)
+		}
+		s.every = d
+		return s, nil
+	}
+	if d, ok := descriptors[spec]; ok {
+		spec = d
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("invalid schedule %q: unknown descriptor %q", expr, spec)
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	parse := func(field string, b bounds) uint64 {
+		if err != nil {
+			return 0
+		}
+		var bits uint64
+		bits, err = parseField(field, b)
+		if err != nil {
+			err = fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		return bits
+	}
+	s.second = parse(fields[0], secondBounds)
+	s.minute = parse(fields[1], minuteBounds)
+	s.hour = parse(fields[2], hourBounds)
+	s.dom = parse(fields[3], domBounds)
+	s.month = parse(fields[4], monthBounds)
+	s.dow = parse(fields[5], dowBounds)
+	if err != nil {
+		return nil, err
+	}
+	// 7 est un alias de dimanche.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = fields[3] == "*" || fields[3] == "?"
+	s.dowStar = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+// Validate renvoie une erreur si expr n'est pas une planification valide.
+func Validate(expr string) error {
+	_, err := Parse(expr)
+	return err
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= r
+	}
+	return bits, nil
+}
+
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangePart, step := expr, uint(1)
+	if i := strings.IndexByte(expr, '/'); i >= 0 {
+		n, err := strconv.ParseUint(expr[i+1:], 10, 0)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("%s: invalid step in %q", b.name, expr)
+		}
+		rangePart, step = expr[:i], uint(n)
+	}
+
+	var lo, hi uint
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		lo, hi = b.min, b.max
+		if b.name == dowBounds.name {
+			hi = 6
+		}
+	case strings.Contains(rangePart, "-"):
+		ends := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if lo, err = parseValue(ends[0], b); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(ends[1], b); err != nil {
+			return 0, err
+		}
+	default:
+		v, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+		lo, hi = v, v
+		if strings.Contains(expr, "/") {
+			hi = b.max
+		}
+	}
+	if lo > hi {
+		return 0, fmt.Errorf("%s: range %q is inverted", b.name, expr)
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", b.name, s)
+	}
+	if uint(n) < b.min || uint(n) > b.max {
+		return 0, fmt.Errorf("%s: %d out of range [%d, %d]", b.name, n, b.min, b.max)
+	}
+	return uint(n), nil
+}
+
+// Location renvoie le fuseau dans lequel la planification est évaluée.
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// String renvoie l'expression d'origine.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next renvoie la première occurrence strictement postérieure à after, dans
+// le fuseau de after. Elle renvoie le temps zéro si aucune occurrence n'existe
+// dans les cinq années suivantes (par exemple "0 0 30 2 *").
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s.every > 0 {
+		return after.Add(s.every - time.Duration(after.Nanosecond())).Truncate(time.Second)
+	}
+
+	origLoc := after.Location()
+	t := after.In(s.loc)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	added := false
+	yearLimit := t.Year() + 5
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for s.month&(1<<uint(t.Month())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		// Un changement d'heure peut décaler minuit.
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(-time.Duration(t.Hour()) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	for s.second&(1<<uint(t.Second())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+	return t.In(origLoc)
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
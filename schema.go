@@ -0,0 +1,275 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema décrit la forme d'une valeur JSON. C'est un sous-ensemble de JSON
+// Schema suffisant pour décrire les sorties des capacités.
+type Schema struct {
+	// Type vaut object, array, string, number, integer, boolean ou null.
+	// Vide : tout type.
+	Type        string             `json:"type,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// SchemaViolation est un écart entre une valeur et son schéma.
+type SchemaViolation struct {
+	// Path est le chemin JSON de l'écart (« $.items[0].id »).
+	Path    string
+	Message string
+}
+
+// SchemaError regroupe les écarts relevés par Schema.Check.
+type SchemaError struct {
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Path + ": " + v.Message
+	}
+	return "schema violation: " + strings.Join(parts, "; ")
+}
+
+// Check vérifie v, normalisé comme après un décodage JSON, et renvoie une
+// *SchemaError listant tous les écarts : champs requis absents et types
+// différents. Les propriétés non décrites sont acceptées.
+func (s *Schema) Check(v interface{}) error {
+	return s.validate(v, false)
+}
+
+// CheckStrict est comme Check, mais signale aussi les propriétés inconnues
+// des objets dont le schéma décrit les propriétés : une faute de frappe
+// (« methd ») est ainsi détectée au lieu d'être ignorée.
+func (s *Schema) CheckStrict(v interface{}) error {
+	return s.validate(v, true)
+}
+
+func (s *Schema) validate(v interface{}, strict bool) error {
+	v, err := normalizeJSON(v)
+	if err != nil {
+		return err
+	}
+	var violations []SchemaViolation
+	s.check("$", v, strict, &violations)
+	if len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+	return nil
+}
+
+func (s *Schema) check(path string, v interface{}, strict bool, out *[]SchemaViolation) {
+	if s == nil || s.Type == "" {
+		return
+	}
+	if v == nil {
+		if !s.Nullable && s.Type != "null" {
+			*out = append(*out, SchemaViolation{Path: path, Message: "expected " + s.Type + ", got null"})
+		}
+		return
+	}
+	kind := jsonKind(v)
+	switch {
+	case s.Type == "integer" && kind == "number":
+		if f := v.(float64); f != float64(int64(f)) {
+			*out = append(*out, SchemaViolation{Path: path, Message: fmt.Sprintf("expected integer, got %v", f)})
+		}
+		return
+	case s.Type != kind:
+		*out = append(*out, SchemaViolation{Path: path, Message: "expected " + s.Type + ", got " + kind})
+		return
+	}
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := x[name]; !ok {
+				*out = append(*out, SchemaViolation{Path: path + "." + name, Message: "missing required field"})
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if child, ok := x[name]; ok {
+				s.Properties[name].check(path+"."+name, child, strict, out)
+			}
+		}
+		if strict && len(s.Properties) > 0 {
+			var unknown []string
+			for name := range x {
+				if _, ok := s.Properties[name]; !ok {
+					unknown = append(unknown, name)
+				}
+			}
+			sort.Strings(unknown)
+			for _, name := range unknown {
+				*out = append(*out, SchemaViolation{Path: path + "." + name, Message: "unknown field"})
+			}
+		}
+	case []interface{}:
+		for i, item := range x {
+			s.Items.check(path+"["+strconv.Itoa(i)+"]", item, strict, out)
+		}
+	}
+}
+
+// OutputSchemaMode règle la vérification des sorties par le client gRPC.
+type OutputSchemaMode string
+
+const (
+	// OutputSchemaOff ne vérifie pas les sorties.
+	OutputSchemaOff OutputSchemaMode = ""
+	// OutputSchemaWarn ajoute un avertissement WarningSchemaDrift au
+	// résultat non conforme.
+	OutputSchemaWarn OutputSchemaMode = "warn"
+	// OutputSchemaReject fait échouer l'exécution avec une *SchemaError.
+	OutputSchemaReject OutputSchemaMode = "reject"
+)
+
+// checkOutputSchema applique ClientOptions.OutputSchema à res. Le manifeste
+// est lu au premier appel ; les plugins sans manifeste ne sont pas vérifiés.
+// S'il ne peut pas être lu, l'exécution échoue en mode OutputSchemaReject et
+// le résultat porte un avertissement WarningSchemaUnchecked sinon.
+func (m *NodeExecutorGRPC) checkOutputSchema(node Node, res *Result) (*Result, error) {
+	if m.opts.OutputSchema == OutputSchemaOff {
+		return res, nil
+	}
+	manifest, err := m.manifestOrErr()
+	if err != nil {
+		if m.opts.OutputSchema == OutputSchemaReject {
+			return nil, fmt.Errorf("node %q: cannot check output of %s: %w", node.ID, node.Uses, err)
+		}
+		res.Warn(WarningSchemaUnchecked, "output of %s: %v", node.Uses, err)
+		return res, nil
+	}
+	spec := manifest.Capability(node.Uses)
+	if spec == nil || spec.OutputSchema == nil {
+		return res, nil
+	}
+	err = spec.OutputSchema.Check(res.Value)
+	if err == nil {
+		return res, nil
+	}
+	if m.opts.OutputSchema == OutputSchemaReject {
+		return nil, fmt.Errorf("node %q: output of %s: %w", node.ID, node.Uses, err)
+	}
+	res.Warn(WarningSchemaDrift, "output of %s: %v", node.Uses, err)
+	return res, nil
+}
+
+// loadManifest renvoie le manifeste du plugin, lu via GetInfo au premier
+// appel. Il renvoie nil si le plugin n'a pu être interrogé ; un plugin sans
+// GetInfo a un manifeste vide.
+func (m *NodeExecutorGRPC) loadManifest() *Manifest {
+	manifest, _ := m.manifestOrErr()
+	return manifest
+}
+
+// Délais entre deux tentatives de lecture du manifeste après un échec de
+// GetInfo, doublé à chaque échec consécutif.
+const (
+	manifestRetryMin = time.Second
+	manifestRetryMax = time.Minute
+)
+
+// manifestFailure est le dernier échec de GetInfo, renvoyé sans nouvel appel
+// jusqu'à retryAt.
+type manifestFailure struct {
+	err     error
+	retryAt time.Time
+	backoff time.Duration
+}
+
+// manifestOrErr est loadManifest pour les contrôles qui doivent échouer
+// fermés : lorsque le manifeste ne peut pas être lu, l'erreur de GetInfo est
+// renvoyée plutôt qu'un manifeste nil. Après un échec, GetInfo n'est
+// rappelé qu'au bout d'un délai croissant, pour ne pas doubler chaque appel
+// d'un plugin en difficulté.
+func (m *NodeExecutorGRPC) manifestOrErr() (*Manifest, error) {
+	if manifest := m.manifest.Load(); manifest != nil {
+		return manifest, nil
+	}
+	last := m.manifestErr.Load()
+	if last != nil && time.Now().Before(last.retryAt) {
+		return nil, last.err
+	}
+	_, err := m.GetInfo()
+	if errors.Is(err, errors.ErrUnsupported) {
+		m.manifest.Store(&Manifest{})
+	}
+	if manifest := m.manifest.Load(); manifest != nil {
+		m.manifestErr.Store(nil)
+		return manifest, nil
+	}
+	if err == nil {
+		err = errors.New("plugin returned no manifest")
+	}
+	err = fmt.Errorf("failed to load plugin manifest: %w", err)
+	backoff := manifestRetryMin
+	if last != nil {
+		backoff = min(2*last.backoff, manifestRetryMax)
+	}
+	m.manifestErr.Store(&manifestFailure{err: err, retryAt: time.Now().Add(backoff), backoff: backoff})
+	return nil, err
+}
+
+// AnnotationStrictWith active (« true ») ou désactive (« false ») la
+// vérification stricte de With pour un nœud, quelle que soit
+// ClientOptions.StrictWith.
+const AnnotationStrictWith = "orkestra.io/strict-with"
+
+// CheckWith vérifie n.With contre le schéma d'entrée de sa capacité, s'il est
+// connu. En mode strict, les clés inconnues sont refusées ; l'annotation
+// AnnotationStrictWith du nœud l'emporte sur strict.
+func (m *Manifest) CheckWith(n Node, strict bool) error {
+	spec := m.Capability(n.Uses)
+	if spec == nil || spec.InputSchema == nil {
+		return nil
+	}
+	switch n.Annotations[AnnotationStrictWith] {
+	case "true":
+		strict = true
+	case "false":
+		strict = false
+	}
+	var err error
+	if strict {
+		err = spec.InputSchema.CheckStrict(n.With)
+	} else {
+		err = spec.InputSchema.Check(n.With)
+	}
+	if err != nil {
+		return fmt.Errorf("node %q: with: %w", n.ID, err)
+	}
+	return nil
+}
+
+// checkWith applique ClientOptions.StrictWith avant l'envoi de node. Si le
+// mode strict est demandé et que le manifeste ne peut pas être lu, l'appel
+// échoue plutôt que de partir sans contrôle.
+func (m *NodeExecutorGRPC) checkWith(node Node) error {
+	if !m.opts.StrictWith && node.Annotations[AnnotationStrictWith] != "true" {
+		return nil
+	}
+	manifest, err := m.manifestOrErr()
+	if err != nil {
+		if node.Annotations[AnnotationStrictWith] == "false" {
+			return nil
+		}
+		return fmt.Errorf("node %q: cannot check with: %w", node.ID, err)
+	}
+	return manifest.CheckWith(node, m.opts.StrictWith)
+}
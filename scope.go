@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// Identity est l'utilisateur pour le compte duquel un workflow s'exécute.
+type Identity struct {
+	Subject string
+	// Scopes sont les permissions accordées (« database/read »,
+	// « database/* »).
+	Scopes []string
+}
+
+// HasScope indique si l'identité détient scope. « * » accorde toutes les
+// permissions, « database/* » toutes celles de database.
+func (id Identity) HasScope(scope string) bool {
+	for _, s := range id.Scopes {
+		if s == scope || s == "*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(s, "*"); ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(scope, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeError est renvoyée lorsque l'identité ne détient pas les permissions
+// requises par une capacité. Elle enveloppe ErrPermissionDenied.
+type ScopeError struct {
+	Subject string
+	Uses    string
+	Missing []string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("%q may not run %s: missing scopes %s", e.Subject, e.Uses, strings.Join(e.Missing, ", "))
+}
+
+func (e *ScopeError) Unwrap() error { return ErrPermissionDenied }
+
+func (e *ScopeError) FailureCode() string { return string(CodePermissionDenied) }
+
+func (e *ScopeError) Retryable() bool { return false }
+
+// ScopeChecker décide si identity peut exécuter node, dont la capacité
+// requiert required (CapabilitySpec.RequiredScopes). Une erreur refuse
+// l'exécution.
+type ScopeChecker interface {
+	CheckScopes(identity Identity, node Node, required []string) error
+}
+
+// DefaultScopeChecker exige que l'identité détienne chacune des permissions
+// requises (voir Identity.HasScope).
+type DefaultScopeChecker struct{}
+
+func (DefaultScopeChecker) CheckScopes(identity Identity, node Node, required []string) error {
+	var missing []string
+	for _, scope := range required {
+		if !identity.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return &ScopeError{Subject: identity.Subject, Uses: node.Uses, Missing: missing}
+	}
+	return nil
+}
+
+// checkScopes soumet node à ClientOptions.Scopes avant l'envoi.
+func (m *NodeExecutorGRPC) checkScopes(node Node, ctx ExecutionContext) error {
+	if m.opts.Scopes == nil {
+		return nil
+	}
+	// Sans manifeste, les permissions requises sont inconnues : l'exécution
+	// est refusée plutôt qu'autorisée à tous.
+	manifest, err := m.manifestOrErr()
+	if err != nil {
+		return fmt.Errorf("cannot check scopes of node %q: %w", node.ID, err)
+	}
+	var required []string
+	if spec := manifest.Capability(node.Uses); spec != nil {
+		required = spec.RequiredScopes
+	}
+	return m.opts.Scopes.CheckScopes(ctx.Identity, node, required)
+}
+
+func toProtoIdentity(id Identity) *proto.Identity {
+	if id.Subject == "" && len(id.Scopes) == 0 {
+		return nil
+	}
+	return &proto.Identity{Subject: id.Subject, Scopes: id.Scopes}
+}
+
+func fromProtoIdentity(p *proto.Identity) Identity {
+	if p == nil {
+		return Identity{}
+	}
+	return Identity{Subject: p.Subject, Scopes: p.Scopes}
+}
@@ -0,0 +1,186 @@
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// secretRef reconnaît secrets.<nom> et secrets['<nom>'].
+var secretRef = regexp.MustCompile(`\bsecrets(?:\.([\w-]+)|\[['"]([^'"\]]+)['"]\])`)
+
+// SecretUsage indique quels nœuds référencent un secret.
+type SecretUsage struct {
+	Name string
+	// Nodes contient les IDs des nœuds qui l'utilisent, triés.
+	Nodes []string
+}
+
+// SecretUsages parcourt l'arbre de nœuds et renvoie chaque secret référencé
+// dans les expressions de With, trié par nom.
+func SecretUsages(nodes []*Node) []SecretUsage {
+	byName := map[string]map[string]bool{}
+	walkNodes(nodes, func(n *Node) {
+		scanExpressions(n.With, func(expr string) {
+			for _, m := range secretRef.FindAllStringSubmatch(expr, -1) {
+				name := m[1]
+				if name == "" {
+					name = m[2]
+				}
+				if byName[name] == nil {
+					byName[name] = map[string]bool{}
+				}
+				byName[name][n.ID] = true
+			}
+		})
+	})
+	usages := make([]SecretUsage, 0, len(byName))
+	for name, ids := range byName {
+		u := SecretUsage{Name: name, Nodes: make([]string, 0, len(ids))}
+		for id := range ids {
+			u.Nodes = append(u.Nodes, id)
+		}
+		sort.Strings(u.Nodes)
+		usages = append(usages, u)
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Name < usages[j].Name })
+	return usages
+}
+
+// MissingSecretsError est renvoyée par CheckSecrets lorsque des secrets
+// référencés ne sont pas disponibles.
+type MissingSecretsError struct {
+	Missing []SecretUsage
+}
+
+func (e *MissingSecretsError) Error() string {
+	parts := make([]string, len(e.Missing))
+	for i, u := range e.Missing {
+		parts[i] = fmt.Sprintf("%s (used by %s)", u.Name, strings.Join(u.Nodes, ", "))
+	}
+	return "missing secrets: " + strings.Join(parts, "; ")
+}
+
+// CheckSecrets vérifie, avant le lancement d'une exécution, que chaque
+// secret référencé par l'arbre de nœuds figure dans available. Une
+// référence à un secret inconnu est signalée de la même manière, qu'il
+// s'agisse d'une faute de frappe ou d'un secret non provisionné.
+func CheckSecrets(nodes []*Node, available []string) error {
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+	var missing []SecretUsage
+	for _, u := range SecretUsages(nodes) {
+		if !known[u.Name] {
+			missing = append(missing, u)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingSecretsError{Missing: missing}
+	}
+	return nil
+}
+
+// TenantSecretPrefix marque une clé de secret cloisonnée :
+// « tenant:acme/db-password ». Sans lui, une clé est partagée, même si elle
+// contient TenantSecretSeparator (« github/token »).
+const TenantSecretPrefix = "tenant:"
+
+// TenantSecretSeparator sépare le locataire du nom dans une clé de secret
+// cloisonnée. Le locataire ne peut pas le contenir ; le nom, si.
+const TenantSecretSeparator = "/"
+
+// TenantSecretKey renvoie la clé de name cloisonnée pour tenant.
+func TenantSecretKey(tenant, name string) string {
+	return TenantSecretPrefix + tenant + TenantSecretSeparator + name
+}
+
+// SplitTenantSecretKey sépare une clé cloisonnée en locataire et nom. Elle
+// renvoie false pour une clé non cloisonnée.
+func SplitTenantSecretKey(key string) (tenant, name string, ok bool) {
+	rest, ok := strings.CutPrefix(key, TenantSecretPrefix)
+	if !ok {
+		return "", key, false
+	}
+	tenant, name, ok = strings.Cut(rest, TenantSecretSeparator)
+	if !ok || tenant == "" || name == "" {
+		return "", key, false
+	}
+	return tenant, name, true
+}
+
+// NamespaceSecrets cloisonne les clés de secrets pour tenant.
+func NamespaceSecrets(tenant string, secrets map[string]string) map[string]string {
+	namespaced := make(map[string]string, len(secrets))
+	for name, v := range secrets {
+		namespaced[TenantSecretKey(tenant, name)] = v
+	}
+	return namespaced
+}
+
+// TenantSecrets extrait de all, dont les clés sont cloisonnées, les secrets
+// de tenant sous leur nom court. Les clés des autres locataires et les clés
+// non cloisonnées sont ignorées.
+func TenantSecrets(tenant string, all map[string]string) map[string]string {
+	secrets := map[string]string{}
+	for key, v := range all {
+		if t, name, ok := SplitTenantSecretKey(key); ok && t == tenant {
+			secrets[name] = v
+		}
+	}
+	return secrets
+}
+
+// CrossTenantSecretError est renvoyée par CheckTenantSecrets lorsqu'un
+// contexte porte des secrets d'un autre locataire. Elle enveloppe
+// ErrPermissionDenied.
+type CrossTenantSecretError struct {
+	Tenant string
+	// Keys sont les clés en cause, triées. Les valeurs ne sont jamais
+	// reprises.
+	Keys []string
+}
+
+func (e *CrossTenantSecretError) Error() string {
+	return fmt.Sprintf("context of tenant %q carries secrets of other tenants: %s", e.Tenant, strings.Join(e.Keys, ", "))
+}
+
+func (e *CrossTenantSecretError) Unwrap() error { return ErrPermissionDenied }
+
+// CheckTenantSecrets vérifie que les secrets cloisonnés de ctx appartiennent
+// tous à ctx.TenantID. Sans locataire, aucun secret cloisonné n'est admis.
+func CheckTenantSecrets(ctx ExecutionContext) error {
+	var foreign []string
+	for key := range ctx.Secrets {
+		if t, _, ok := SplitTenantSecretKey(key); ok && t != ctx.TenantID {
+			foreign = append(foreign, key)
+		}
+	}
+	if len(foreign) > 0 {
+		sort.Strings(foreign)
+		return &CrossTenantSecretError{Tenant: ctx.TenantID, Keys: foreign}
+	}
+	return nil
+}
+
+// isolateTenantSecrets applique ClientOptions.IsolateTenantSecrets : les
+// secrets de ctx sont vérifiés, puis ceux du locataire transmis sous leur nom
+// court. Ils l'emportent sur les secrets non cloisonnés de même nom.
+func isolateTenantSecrets(ctx ExecutionContext) (ExecutionContext, error) {
+	if err := CheckTenantSecrets(ctx); err != nil {
+		return ExecutionContext{}, err
+	}
+	secrets := make(map[string]string, len(ctx.Secrets))
+	for key, v := range ctx.Secrets {
+		if _, _, ok := SplitTenantSecretKey(key); !ok {
+			secrets[key] = v
+		}
+	}
+	for name, v := range TenantSecrets(ctx.TenantID, ctx.Secrets) {
+		secrets[name] = v
+	}
+	ctx.Secrets = secrets
+	return ctx, nil
+}
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitTenantSecretKey(t *testing.T) {
+	tests := []struct {
+		key        string
+		wantTenant string
+		wantName   string
+		wantOK     bool
+	}{
+		{key: "db-password", wantName: "db-password"},
+		{key: "github/token", wantName: "github/token"},
+		{key: "tenant:acme/db-password", wantTenant: "acme", wantName: "db-password", wantOK: true},
+		{key: "tenant:acme/github/token", wantTenant: "acme", wantName: "github/token", wantOK: true},
+		{key: "tenant:acme", wantName: "tenant:acme"},
+		{key: "tenant:/token", wantName: "tenant:/token"},
+		{key: "tenant:acme/", wantName: "tenant:acme/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			tenant, name, ok := SplitTenantSecretKey(tt.key)
+			if tenant != tt.wantTenant || name != tt.wantName || ok != tt.wantOK {
+				t.Errorf("SplitTenantSecretKey(%q) = %q, %q, %v; want %q, %q, %v", tt.key, tenant, name, ok, tt.wantTenant, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTenantSecretKeyRoundTrip(t *testing.T) {
+	for _, name := range []string{"db-password", "github/token", "a/b/c"} {
+		tenant, got, ok := SplitTenantSecretKey(TenantSecretKey("acme", name))
+		if !ok || tenant != "acme" || got != name {
+			t.Errorf("round trip of %q = %q, %q, %v", name, tenant, got, ok)
+		}
+	}
+}
+
+func TestIsolateTenantSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenant  string
+		secrets map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "shared slash keys are kept",
+			tenant:  "acme",
+			secrets: map[string]string{"github/token": "g", "db": "d"},
+			want:    map[string]string{"github/token": "g", "db": "d"},
+		},
+		{
+			name:    "shared slash keys without tenant",
+			secrets: map[string]string{"github/token": "g"},
+			want:    map[string]string{"github/token": "g"},
+		},
+		{
+			name:    "tenant keys are unwrapped and win",
+			tenant:  "acme",
+			secrets: map[string]string{"db": "shared", "tenant:acme/db": "acme", "tenant:acme/github/token": "t"},
+			want:    map[string]string{"db": "acme", "github/token": "t"},
+		},
+		{
+			name:    "foreign tenant keys are refused",
+			tenant:  "acme",
+			secrets: map[string]string{"tenant:other/db": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "tenant keys without tenant are refused",
+			secrets: map[string]string{"tenant:acme/db": "x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isolateTenantSecrets(ExecutionContext{TenantID: tt.tenant, Secrets: tt.secrets})
+			if tt.wantErr {
+				if !errors.Is(err, ErrPermissionDenied) {
+					t.Fatalf("err = %v, want ErrPermissionDenied", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Secrets, tt.want) {
+				t.Errorf("secrets = %v, want %v", got.Secrets, tt.want)
+			}
+		})
+	}
+}
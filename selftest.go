@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// SelfTestScope restreint les diagnostics lancés par SelfTest.
+type SelfTestScope string
+
+const (
+	// SelfTestAll lance tous les diagnostics du plugin.
+	SelfTestAll SelfTestScope = ""
+	// SelfTestCredentials vérifie seulement que les identifiants configurés
+	// sont acceptés.
+	SelfTestCredentials SelfTestScope = "credentials"
+	// SelfTestRead effectue en plus un appel de lecture d'exemple.
+	SelfTestRead SelfTestScope = "read"
+)
+
+// SelfTester peut être implémentée par un NodeExecutor pour lancer ses
+// propres diagnostics avec la configuration en place (identifiants, appel de
+// lecture d'exemple). Le moteur l'expose comme un bouton « Tester la
+// connexion » par intégration. Contrairement à Preflight, qui vérifie des
+// identifiants fournis avant l'enregistrement, SelfTest éprouve le plugin tel
+// qu'il tourne. Un plugin ignore les portées qu'il ne connaît pas et lance
+// alors tous ses diagnostics.
+type SelfTester interface {
+	SelfTest(scope SelfTestScope) (PreflightReport, error)
+}
+
+// SelfTest demande au plugin de lancer ses diagnostics. Les plugins qui ne
+// le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) SelfTest(scope SelfTestScope) (PreflightReport, error) {
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.SelfTest(callCtx, &proto.SelfTestRequest{Scope: string(scope)})
+	if err != nil {
+		return PreflightReport{}, withTimeout(fromRPCError("SelfTest", err), m.opts.callTimeout())
+	}
+	return fromProtoPreflightResponse(resp), nil
+}
+
+func (s *NodeExecutorGRPCServer) SelfTest(ctx context.Context, req *proto.SelfTestRequest) (*proto.PreflightResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	t, ok := implementation[SelfTester](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.SelfTest(ctx, req)
+	}
+	report, err := t.SelfTest(SelfTestScope(req.Scope))
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+	return toProtoPreflightResponse(report), nil
+}
@@ -0,0 +1,130 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver est une version sémantique réduite à ce dont le contrôle de
+// compatibilité a besoin. Les métadonnées de build sont ignorées.
+type semver struct {
+	major, minor, patch int
+	pre                 string
+}
+
+// parseSemver accepte les formes "1", "1.2", "1.2.3" et "v1.2.3-rc1".
+func parseSemver(s string) (semver, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, v.pre = s[:i], s[i+1:]
+		if !validPrerelease(v.pre) {
+			return semver{}, fmt.Errorf("invalid version %q", raw)
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 || parts[0] == "" {
+		return semver{}, fmt.Errorf("invalid version %q", raw)
+	}
+	nums := []*int{&v.major, &v.minor, &v.patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q", raw)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// compare renvoie -1, 0 ou 1. Une pré-version est inférieure à la version
+// finale correspondante ; deux pré-versions sont comparées selon SemVer §11.
+func (v semver) compare(o semver) int {
+	for _, d := range [][2]int{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.pre == o.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case o.pre == "":
+		return -1
+	}
+	a, b := strings.Split(v.pre, "."), strings.Split(o.pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePrereleaseID(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleaseID compare deux identifiants de pré-version : les
+// numériques par valeur et avant les alphanumériques, ceux-ci dans l'ordre
+// ASCII.
+func comparePrereleaseID(a, b string) int {
+	an, bn := isNumericID(a), isNumericID(b)
+	switch {
+	case an && bn:
+		// Sans zéro initial (voir validPrerelease), le plus long est le plus
+		// grand, sans risque de débordement.
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumericID(id string) bool {
+	for i := 0; i < len(id); i++ {
+		if id[i] < '0' || id[i] > '9' {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// validPrerelease vérifie pre selon SemVer §9 : identifiants non vides,
+// alphanumériques ou tirets, les numériques sans zéro initial.
+func validPrerelease(pre string) bool {
+	for _, id := range strings.Split(pre, ".") {
+		if id == "" {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return false
+			}
+		}
+		if isNumericID(id) && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SettingType est le type d'un réglage de plugin.
+type SettingType string
+
+const (
+	SettingString   SettingType = "string"
+	SettingInt      SettingType = "int"
+	SettingFloat    SettingType = "float"
+	SettingBool     SettingType = "bool"
+	SettingDuration SettingType = "duration"
+	SettingURL      SettingType = "url"
+	// SettingSecret est une chaîne masquée dans les formulaires et les
+	// journaux.
+	SettingSecret SettingType = "secret"
+)
+
+// SettingSpec décrit un réglage global d'un plugin (URL de base d'une API,
+// concurrence interne). Le moteur génère le formulaire de configuration à
+// partir de Manifest.Settings et valide les valeurs avant Configure.
+type SettingSpec struct {
+	Name        string        `json:"name"`
+	Type        SettingType   `json:"type"`
+	Required    bool          `json:"required,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Min         *float64      `json:"min,omitempty"`
+	Max         *float64      `json:"max,omitempty"`
+	Label       string        `json:"label,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Placeholder string        `json:"placeholder,omitempty"`
+	// Group regroupe les réglages en sections dans le formulaire.
+	Group string `json:"group,omitempty"`
+}
+
+// Configurable peut être implémentée par un NodeExecutor qui accepte des
+// réglages globaux, transmis par Configuration.Settings. Les réglages sont
+// validés au préalable contre Manifest.Settings et complétés par leurs
+// valeurs par défaut lorsque le plugin implémente InfoProvider.
+type Configurable interface {
+	Configure(settings map[string]interface{}) error
+}
+
+// ValidateSettings vérifie settings selon schema et renvoie une copie
+// complétée des valeurs par défaut. Les nombres sont acceptés sous forme
+// float64 (décodage JSON) ou entière ; les durées sous forme de chaîne
+// ("30s").
+// Toutes les erreurs sont renvoyées, jointes.
+func ValidateSettings(schema []SettingSpec, settings map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(schema))
+	known := make(map[string]bool, len(schema))
+	var errs []error
+	for _, spec := range schema {
+		known[spec.Name] = true
+		v, ok := settings[spec.Name]
+		if !ok || v == nil {
+			if spec.Default != nil {
+				out[spec.Name] = spec.Default
+			} else if spec.Required {
+				errs = append(errs, fmt.Errorf("setting %q is required", spec.Name))
+			}
+			continue
+		}
+		if err := spec.check(v); err != nil {
+			errs = append(errs, fmt.Errorf("setting %q: %w", spec.Name, err))
+			continue
+		}
+		out[spec.Name] = v
+	}
+	for name := range settings {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("unknown setting %q", name))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return out, nil
+}
+
+func (spec SettingSpec) check(v interface{}) error {
+	switch spec.Type {
+	case SettingString, SettingSecret:
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected a string, got %T", v)
+		}
+	case SettingURL:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a URL string, got %T", v)
+		}
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid URL %q", s)
+		}
+	case SettingBool:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %T", v)
+		}
+	case SettingInt, SettingFloat:
+		f, ok := settingNumber(v)
+		if !ok {
+			return fmt.Errorf("expected a number, got %T", v)
+		}
+		if spec.Type == SettingInt && f != float64(int64(f)) {
+			return fmt.Errorf("expected an integer, got %v", f)
+		}
+		if spec.Min != nil && f < *spec.Min {
+			return fmt.Errorf("%v is below minimum %v", f, *spec.Min)
+		}
+		if spec.Max != nil && f > *spec.Max {
+			return fmt.Errorf("%v is above maximum %v", f, *spec.Max)
+		}
+	case SettingDuration:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a duration string, got %T", v)
+		}
+		if _, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("invalid duration %q", s)
+		}
+	default:
+		return fmt.Errorf("unknown setting type %q", spec.Type)
+	}
+	if len(spec.Enum) > 0 {
+		for _, allowed := range spec.Enum {
+			if settingEqual(allowed, v) {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %v is not one of %v", v, spec.Enum)
+	}
+	return nil
+}
+
+// settingNumber renvoie la valeur de v s'il s'agit d'un nombre : float64
+// une fois décodé du JSON, entier s'il vient d'un manifeste écrit en Go.
+func settingNumber(v interface{}) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	f, err := toFloat64(v)
+	return f, err == nil
+}
+
+// settingEqual compare une valeur de Enum à un réglage ; les nombres sont
+// comparés par valeur, quel que soit leur type.
+func settingEqual(allowed, v interface{}) bool {
+	if a, ok := settingNumber(allowed); ok {
+		f, ok := settingNumber(v)
+		return ok && a == f
+	}
+	return allowed == v
+}
+
+// applySettings valide et transmet les réglages reçus par Configure au
+// plugin. Il renvoie les réglages appliqués, complétés de leurs valeurs par
+// défaut.
+func (s *NodeExecutorGRPCServer) applySettings(raw []byte) (map[string]interface{}, error) {
+	c, ok := implementation[Configurable](s.Impl)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "plugin does not accept settings")
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid settings: %v", err)
+	}
+	if ip, ok := implementation[InfoProvider](s.Impl); ok {
+		info, err := ip.GetInfo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin info: %w", err)
+		}
+		if len(info.Manifest.Settings) > 0 {
+			if settings, err = ValidateSettings(info.Manifest.Settings, settings); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+	}
+	if err := c.Configure(settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// SlowCallReport rassemble les diagnostics capturés lorsqu'un appel dépasse
+// ClientOptions.SlowCallThreshold.
+type SlowCallReport struct {
+	Method    string
+	NodeID    string
+	Threshold time.Duration
+	// Stats est nil si le plugin n'a pas répondu à GetStats.
+	Stats *PluginStats
+	// Goroutines est le dump des piles du plugin, vide si son service de
+	// débogage n'est pas activé (ServerOptions.Debug).
+	Goroutines []byte
+}
+
+// SlowCallError est renvoyée à la place de l'erreur d'un appel qui a
+// dépassé ClientOptions.SlowCallThreshold ; elle enveloppe l'erreur
+// d'origine.
+type SlowCallError struct {
+	Report  SlowCallReport
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *SlowCallError) Error() string {
+	return fmt.Sprintf("%v (slow call: %s on node %q took %s)", e.Err, e.Report.Method, e.Report.NodeID, e.Elapsed.Round(time.Millisecond))
+}
+
+func (e *SlowCallError) Unwrap() error {
+	return e.Err
+}
+
+// slowCallWatch surveille un appel en cours.
+type slowCallWatch struct {
+	start  time.Time
+	timer  *time.Timer
+	done   chan struct{}
+	report SlowCallReport
+}
+
+// watchSlowCall arme le chien de garde de l'appel method sur node. Il renvoie
+// nil si aucun seuil n'est configuré.
+func (m *NodeExecutorGRPC) watchSlowCall(method string, node Node) *slowCallWatch {
+	threshold := m.opts.SlowCallThreshold
+	if threshold <= 0 {
+		return nil
+	}
+	w := &slowCallWatch{
+		start:  time.Now(),
+		done:   make(chan struct{}),
+		report: SlowCallReport{Method: method, NodeID: node.ID, Threshold: threshold},
+	}
+	w.timer = time.AfterFunc(threshold, func() {
+		defer close(w.done)
+		m.captureSlowCall(&w.report)
+		if m.opts.OnSlowCall != nil {
+			m.opts.OnSlowCall(w.report)
+		}
+	})
+	return w
+}
+
+// captureSlowCall relève les statistiques et les piles du plugin. Les
+// appels de diagnostic ne passent pas par le limiteur de concurrence.
+func (m *NodeExecutorGRPC) captureSlowCall(r *SlowCallReport) {
+	if stats, err := m.GetStats(); err == nil {
+		r.Stats = &stats
+	}
+	if m.debug == nil {
+		return
+	}
+	ctx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	if dump, err := m.debug.DumpGoroutines(ctx, &proto.Empty{}); err == nil {
+		r.Goroutines = dump.Dump
+	}
+}
+
+// stop désarme le chien de garde. Si l'appel a été signalé lent et a
+// échoué, les diagnostics sont attachés à err.
+func (w *slowCallWatch) stop(err error) error {
+	if w == nil {
+		return err
+	}
+	if w.timer.Stop() || err == nil {
+		return err
+	}
+	<-w.done
+	return &SlowCallError{Report: w.report, Elapsed: time.Since(w.start), Err: err}
+}
@@ -0,0 +1,148 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// PluginStats est un relevé de la consommation de ressources d'un plugin.
+// Les champs mémoire, goroutines, compteurs et durée de vie sont renseignés
+// par la bibliothèque ; OpenConnections et Custom par le plugin s'il
+// implémente StatsProvider.
+type PluginStats struct {
+	HeapBytes       uint64
+	SysBytes        uint64
+	Goroutines      int
+	OpenConnections int
+	// Calls, Errors et InFlight comptent les exécutions (Execute et
+	// ExecuteStream) depuis le démarrage du plugin.
+	Calls    int64
+	Errors   int64
+	InFlight int64
+	Uptime   time.Duration
+	// Custom contient des compteurs propres au plugin (taille d'un pool, d'un
+	// cache...).
+	Custom map[string]float64
+}
+
+// StatsProvider peut être implémentée par un NodeExecutor pour compléter
+// ses statistiques.
+type StatsProvider interface {
+	// Stats renvoie les statistiques propres au plugin ; les champs gérés
+	// par la bibliothèque sont ignorés.
+	Stats() (PluginStats, error)
+}
+
+// serverStats compte les exécutions côté serveur.
+type serverStats struct {
+	started                 time.Time
+	calls, errors, inFlight atomic.Int64
+}
+
+func (s *serverStats) begin() {
+	s.calls.Add(1)
+	s.inFlight.Add(1)
+}
+
+func (s *serverStats) end(err error) {
+	s.inFlight.Add(-1)
+	if err != nil {
+		s.errors.Add(1)
+	}
+}
+
+// GetStats interroge le plugin sur sa consommation de ressources. Les
+// plugins qui ne le supportent pas renvoient une erreur errors.ErrUnsupported.
+func (m *NodeExecutorGRPC) GetStats() (PluginStats, error) {
+	callCtx, cancel := m.callContext(m.opts.callTimeout(), "")
+	defer cancel()
+	resp, err := m.client.GetStats(callCtx, &proto.Empty{})
+	if err != nil {
+		return PluginStats{}, withTimeout(fromRPCError("GetStats", err), m.opts.callTimeout())
+	}
+	return fromProtoPluginStats(resp), nil
+}
+
+// WatchStats relève les statistiques du plugin toutes les interval et les
+// transmet à fn, jusqu'à l'annulation de ctx. Elle s'arrête immédiatement si
+// le plugin ne supporte pas GetStats. Un interval nul ou négatif est
+// transmis à fn comme une erreur, sans relevé.
+func (m *NodeExecutorGRPC) WatchStats(ctx context.Context, interval time.Duration, fn func(PluginStats, error)) {
+	if interval <= 0 {
+		fn(PluginStats{}, fmt.Errorf("invalid stats interval %s: must be positive", interval))
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		stats, err := m.GetStats()
+		fn(stats, err)
+		if errors.Is(err, errors.ErrUnsupported) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *NodeExecutorGRPCServer) GetStats(ctx context.Context, req *proto.Empty) (*proto.PluginStats, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	var stats PluginStats
+	if p, ok := implementation[StatsProvider](s.Impl); ok {
+		var err error
+		if stats, err = p.Stats(); err != nil {
+			return nil, err
+		}
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats.HeapBytes = mem.HeapAlloc
+	stats.SysBytes = mem.Sys
+	stats.Goroutines = runtime.NumGoroutine()
+	stats.Calls = s.stats.calls.Load()
+	stats.Errors = s.stats.errors.Load()
+	stats.InFlight = s.stats.inFlight.Load()
+	if !s.stats.started.IsZero() {
+		stats.Uptime = time.Since(s.stats.started)
+	}
+	return toProtoPluginStats(stats), nil
+}
+
+func toProtoPluginStats(s PluginStats) *proto.PluginStats {
+	return &proto.PluginStats{
+		HeapBytes:       s.HeapBytes,
+		SysBytes:        s.SysBytes,
+		Goroutines:      int32(s.Goroutines),
+		OpenConnections: int32(s.OpenConnections),
+		Calls:           s.Calls,
+		Errors:          s.Errors,
+		InFlight:        s.InFlight,
+		UptimeMs:        s.Uptime.Milliseconds(),
+		Custom:          s.Custom,
+	}
+}
+
+func fromProtoPluginStats(p *proto.PluginStats) PluginStats {
+	return PluginStats{
+		HeapBytes:       p.HeapBytes,
+		SysBytes:        p.SysBytes,
+		Goroutines:      int(p.Goroutines),
+		OpenConnections: int(p.OpenConnections),
+		Calls:           p.Calls,
+		Errors:          p.Errors,
+		InFlight:        p.InFlight,
+		Uptime:          time.Duration(p.UptimeMs) * time.Millisecond,
+		Custom:          p.Custom,
+	}
+}
@@ -0,0 +1,202 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+	"google.golang.org/grpc/status"
+)
+
+// ItemStreamer peut être implémentée par un NodeExecutor dont les capacités
+// « source » produisent leurs éléments un à un (exports volumineux, curseurs
+// de base de données) au lieu de matérialiser un tableau complet.
+type ItemStreamer interface {
+	// ExecuteStream appelle emit pour chaque élément. Une erreur renvoyée par
+	// emit interrompt le flux et doit être propagée.
+	ExecuteStream(node Node, ctx ExecutionContext, emit func(item interface{}) error) error
+}
+
+// ExecuteStream ouvre le flux d'éléments du plugin. Pour les plugins qui
+// n'annoncent pas FeatureItemStreaming, il se replie sur Execute et transmet
+// les éléments du résultat (voir ForEachItem). Si les fonctionnalités du
+// plugin ne peuvent pas être lues, l'erreur est renvoyée sans repli.
+func (m *NodeExecutorGRPC) ExecuteStream(node Node, ctx ExecutionContext, emit func(item interface{}) error) error {
+	streaming, err := m.SupportsOrErr(FeatureItemStreaming)
+	if err != nil {
+		return err
+	}
+	if !streaming {
+		res, err := m.ExecuteResult(node, ctx)
+		if err != nil {
+			return err
+		}
+		return emitItems(res.Value, emit)
+	}
+	ctx = withRequestID(ctx)
+	if err := m.executeStreamRequest(node, ctx, emit); err != nil {
+		return &RequestIDError{RequestID: ctx.RequestID, Err: err}
+	}
+	return nil
+}
+
+func (m *NodeExecutorGRPC) executeStreamRequest(node Node, ctx ExecutionContext, emit func(item interface{}) error) error {
+	if err := m.checkWith(node); err != nil {
+		return err
+	}
+	if err := m.checkScopes(node, ctx); err != nil {
+		return err
+	}
+	if err := m.checkPolicy(node, ctx); err != nil {
+		return err
+	}
+	req, err := m.executeRequest(node, ctx)
+	if err != nil {
+		return err
+	}
+	timeout := m.executeTimeout(node, ctx)
+	ctx, entry, err := m.journal(node, ctx)
+	if err != nil {
+		m.deltas.reset(req.Context.OutputsDelta)
+		return err
+	}
+	var items int
+	err = m.executeStream(node, ctx, req, timeout, entry, func(item interface{}) error {
+		items++
+		return emit(item)
+	})
+	m.capture(node, ctx, err != nil)
+	if err == nil || items > 0 {
+		m.reportUnits(node, ctx, float64(items))
+	}
+	entry.done(nil, err)
+	return err
+}
+
+// executeStream envoie req et transmet les éléments reçus à emit.
+func (m *NodeExecutorGRPC) executeStream(node Node, ctx ExecutionContext, req *proto.ExecuteRequest, timeout time.Duration, entry *journalEntry, emit func(item interface{}) error) error {
+	callCtx, cancel := m.executeContext(timeout, ctx)
+	defer cancel()
+	release, err := m.limit.acquire(callCtx)
+	if err != nil {
+		m.deltas.reset(req.Context.OutputsDelta)
+		return &TimeoutError{Method: "ExecuteStream", Timeout: timeout}
+	}
+	defer release()
+	stream, err := m.client.ExecuteStream(callCtx, req)
+	if err != nil {
+		return withTimeout(fromRPCError("ExecuteStream", err), timeout)
+	}
+	for first := true; ; first = false {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if first && m.deltaMissed(err, req) {
+			entry.retry(err)
+			if req, err = m.executeRequest(node, ctx); err != nil {
+				return err
+			}
+			if stream, err = m.client.ExecuteStream(callCtx, req); err != nil {
+				return withTimeout(fromRPCError("ExecuteStream", err), timeout)
+			}
+			continue
+		}
+		if err != nil {
+			err = withTimeout(fromRPCError("ExecuteStream", err), timeout)
+			m.observeRateLimit(node, nil, err)
+			m.observeBackpressure(err)
+			return err
+		}
+		item, err := decodeValue(m.codec(), msg.Item, msg.ItemHints)
+		if err != nil {
+			return fmt.Errorf("failed to convert stream item from proto: %w", err)
+		}
+		if err := emit(item); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *NodeExecutorGRPCServer) ExecuteStream(req *proto.ExecuteRequest, stream proto.NodeExecutor_ExecuteStreamServer) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+	streamer, ok := implementation[ItemStreamer](s.Impl)
+	if !ok {
+		return s.UnimplementedNodeExecutorServer.ExecuteStream(req, stream)
+	}
+	wl := s.wireLog.Load()
+	wl.logRequest("ExecuteStream", req)
+	node, execCtx, err := fromProtoExecuteRequest(s.options.Codec, req, s.options.MaxNodeDepth)
+	if err != nil {
+		return fmt.Errorf("failed to convert request from proto: %w", err)
+	}
+	if err := s.deltas.apply(req.Context.GetOutputsDelta(), &execCtx); err != nil {
+		return err
+	}
+	format, _ := wireFormat(req.FormatVersion)
+	execCtx.Deadline, _ = stream.Context().Deadline()
+	execCtx.done = stream.Context().Done()
+	md := CallMetadataFromContext(stream.Context())
+	execCtx.RunID, execCtx.RequestID = md.RunID, md.RequestID
+	execCtx.resolve = s.outputResolver()
+	execCtx.fetch = s.artifactFetcher()
+	release, err := s.limit.acquire(stream.Context())
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	defer release()
+	codec := s.codec(stream.Context())
+	start := time.Now()
+	s.stats.begin()
+	err = streamer.ExecuteStream(node, execCtx, func(item interface{}) error {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+		b, hints, err := encodeValueFor(codec, item, format)
+		if err != nil {
+			return fmt.Errorf("failed to convert stream item to proto: %w", err)
+		}
+		wl.logStreamItem(req, b)
+		return stream.Send(&proto.StreamItem{Item: b, ItemHints: hintsFor(format, hints)})
+	})
+	wl.logResponse("ExecuteStream", req, nil, err)
+	s.stats.end(err)
+	s.recorder.record(node, execCtx.Secrets, start, err)
+	return toRPCError(err)
+}
+
+// ForEachItem transmet à fn chaque élément produit par node. Le flux est
+// utilisé lorsque exec le supporte ; sinon Execute est appelé et son résultat
+// parcouru s'il s'agit d'un tableau, ou transmis tel quel.
+func ForEachItem(exec NodeExecutor, node Node, ctx ExecutionContext, fn func(item interface{}) error) error {
+	if streamer, ok := exec.(ItemStreamer); ok {
+		err := streamer.ExecuteStream(node, ctx, fn)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+	v, err := exec.Execute(node, ctx)
+	if err != nil {
+		return err
+	}
+	return emitItems(asResult(v).Value, fn)
+}
+
+// emitItems transmet à fn les éléments de value s'il s'agit d'un tableau,
+// value elle-même sinon.
+func emitItems(value interface{}, fn func(item interface{}) error) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return fn(value)
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package shared
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType est le type des valeurs d'une colonne de Table.
+type ColumnType string
+
+const (
+	ColumnString ColumnType = "string"
+	ColumnInt    ColumnType = "int"
+	ColumnFloat  ColumnType = "float"
+	ColumnBool   ColumnType = "bool"
+	ColumnTime   ColumnType = "time"
+	ColumnBytes  ColumnType = "bytes"
+	// ColumnJSON accepte n'importe quelle valeur sérialisable en JSON.
+	ColumnJSON ColumnType = "json"
+)
+
+// Column décrit une colonne.
+type Column struct {
+	Name     string     `json:"name"`
+	Type     ColumnType `json:"type"`
+	Nullable bool       `json:"nullable,omitempty"`
+}
+
+// Table est le format d'échange des données tabulaires entre plugins
+// (tableurs, bases de données, analytique). Chaque ligne contient une valeur
+// par colonne, de type Go string, int64, float64, bool, time.Time, []byte,
+// une valeur JSON quelconque pour ColumnJSON, ou nil.
+type Table struct {
+	Columns []Column        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// TableResult enveloppe une table dans un Result de type OutputTable.
+func TableResult(t *Table) *Result {
+	return &Result{Value: t, Kind: OutputTable}
+}
+
+// Records convertit la table en liste d'objets indexés par nom de colonne.
+func (t *Table) Records() []map[string]interface{} {
+	out := make([]map[string]interface{}, len(t.Rows))
+	for i, row := range t.Rows {
+		rec := make(map[string]interface{}, len(t.Columns))
+		for j, col := range t.Columns {
+			if j < len(row) {
+				rec[col.Name] = row[j]
+			}
+		}
+		out[i] = rec
+	}
+	return out
+}
+
+// TableFromValue relit une table depuis une sortie de nœud décodée du JSON.
+// Les valeurs sont ramenées au type de leur colonne.
+func TableFromValue(v interface{}) (*Table, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var t Table
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("value is not a table: %w", err)
+	}
+	for i, row := range t.Rows {
+		for j, cell := range row {
+			if j >= len(t.Columns) || cell == nil {
+				continue
+			}
+			if row[j], err = coerceCell(t.Columns[j].Type, cell); err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, t.Columns[j].Name, err)
+			}
+		}
+	}
+	return &t, nil
+}
+
+func coerceCell(typ ColumnType, v interface{}) (interface{}, error) {
+	switch typ {
+	case ColumnInt:
+		if f, ok := v.(float64); ok {
+			return int64(f), nil
+		}
+	case ColumnTime:
+		if s, ok := v.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	case ColumnBytes:
+		if s, ok := v.(string); ok {
+			return base64.StdEncoding.DecodeString(s)
+		}
+	}
+	return v, nil
+}
+
+// TableFromCSV lit un CSV dont la première ligne contient les noms de
+// colonnes. Lorsque infer est vrai, le type de chaque colonne est déduit
+// (int, float, bool, sinon string) et les cellules vides deviennent nil.
+func TableFromCSV(r io.Reader, infer bool) (*Table, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return &Table{Columns: []Column{}, Rows: [][]interface{}{}}, nil
+	}
+	t := &Table{Rows: make([][]interface{}, 0, len(records)-1)}
+	for _, name := range records[0] {
+		t.Columns = append(t.Columns, Column{Name: name, Type: ColumnString})
+	}
+	body := records[1:]
+	if infer {
+		for j := range t.Columns {
+			t.Columns[j].Type, t.Columns[j].Nullable = inferCSVColumn(body, j)
+		}
+	}
+	for i, rec := range body {
+		row := make([]interface{}, len(t.Columns))
+		for j, col := range t.Columns {
+			if j >= len(rec) || (infer && rec[j] == "") {
+				continue
+			}
+			if row[j], err = parseCSVCell(col.Type, rec[j]); err != nil {
+				return nil, fmt.Errorf("line %d, column %q: %w", i+2, col.Name, err)
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+func inferCSVColumn(records [][]string, j int) (ColumnType, bool) {
+	isInt, isFloat, isBool, nullable, seen := true, true, true, false, false
+	for _, rec := range records {
+		if j >= len(rec) || rec[j] == "" {
+			nullable = true
+			continue
+		}
+		seen = true
+		s := rec[j]
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			isInt = false
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			isFloat = false
+		}
+		if _, err := strconv.ParseBool(s); err != nil {
+			isBool = false
+		}
+	}
+	switch {
+	case !seen:
+		return ColumnString, nullable
+	case isInt:
+		return ColumnInt, nullable
+	case isFloat:
+		return ColumnFloat, nullable
+	case isBool:
+		return ColumnBool, nullable
+	}
+	return ColumnString, nullable
+}
+
+func parseCSVCell(typ ColumnType, s string) (interface{}, error) {
+	switch typ {
+	case ColumnInt:
+		return strconv.ParseInt(s, 10, 64)
+	case ColumnFloat:
+		return strconv.ParseFloat(s, 64)
+	case ColumnBool:
+		return strconv.ParseBool(s)
+	}
+	return s, nil
+}
+
+// TableFromRows lit toutes les lignes d'un résultat database/sql. Les types
+// de colonnes sont déduits des métadonnées du driver. rows n'est pas fermé.
+func TableFromRows(rows *sql.Rows) (*Table, error) {
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{Rows: [][]interface{}{}}
+	for _, ct := range colTypes {
+		nullable, _ := ct.Nullable()
+		t.Columns = append(t.Columns, Column{Name: ct.Name(), Type: sqlColumnType(ct), Nullable: nullable})
+	}
+	for rows.Next() {
+		raw := make([]interface{}, len(colTypes))
+		ptrs := make([]interface{}, len(colTypes))
+		for i := range raw {
+			ptrs[i] = &raw[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range raw {
+			raw[i] = normalizeSQLValue(t.Columns[i].Type, v)
+		}
+		t.Rows = append(t.Rows, raw)
+	}
+	return t, rows.Err()
+}
+
+func sqlColumnType(ct *sql.ColumnType) ColumnType {
+	if st := ct.ScanType(); st != nil {
+		if st == reflect.TypeOf(time.Time{}) || st == reflect.TypeOf(sql.NullTime{}) {
+			return ColumnTime
+		}
+		switch st.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return ColumnInt
+		case reflect.Float32, reflect.Float64:
+			return ColumnFloat
+		case reflect.Bool:
+			return ColumnBool
+		case reflect.String:
+			return ColumnString
+		}
+	}
+	name := strings.ToUpper(ct.DatabaseTypeName())
+	switch {
+	case strings.Contains(name, "INT"):
+		return ColumnInt
+	case strings.Contains(name, "FLOAT"), strings.Contains(name, "DOUBLE"), strings.Contains(name, "REAL"):
+		return ColumnFloat
+	case strings.Contains(name, "BOOL"):
+		return ColumnBool
+	case strings.Contains(name, "TIME"), strings.Contains(name, "DATE"):
+		return ColumnTime
+	case strings.Contains(name, "JSON"):
+		return ColumnJSON
+	case strings.Contains(name, "BLOB"), strings.Contains(name, "BINARY"), name == "BYTEA":
+		return ColumnBytes
+	}
+	return ColumnString
+}
+
+func normalizeSQLValue(typ ColumnType, v interface{}) interface{} {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	switch typ {
+	case ColumnBytes:
+		return append([]byte(nil), b...)
+	case ColumnJSON:
+		var j interface{}
+		if json.Unmarshal(b, &j) == nil {
+			return j
+		}
+	case ColumnInt:
+		if n, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return n
+		}
+	case ColumnFloat:
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return f
+		}
+	}
+	return string(b)
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
@@ -0,0 +1,96 @@
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// FormatTime est l'encodage canonique des dates dans With et dans les
+// sorties : RFC 3339 avec nanosecondes, fuseau conservé.
+func FormatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// ParseTime accepte un time.Time, une chaîne RFC 3339 (avec ou sans
+// fractions), une date seule ("2006-01-02") ou un horodatage Unix en
+// secondes.
+func ParseTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as RFC 3339 time", t)
+	case float64:
+		sec, frac := int64(t), t-float64(int64(t))
+		return time.Unix(sec, int64(frac*1e9)).UTC(), nil
+	case int64:
+		return time.Unix(t, 0).UTC(), nil
+	case int:
+		return time.Unix(int64(t), 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %T to time", v)
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration accepte un time.Duration, une durée Go ("1h30m"), une durée
+// ISO 8601 sans années ni mois ("P1DT2H") ou un nombre de secondes.
+func ParseDuration(v interface{}) (time.Duration, error) {
+	switch d := v.(type) {
+	case time.Duration:
+		return d, nil
+	case string:
+		if parsed, err := time.ParseDuration(d); err == nil {
+			return parsed, nil
+		}
+		m := isoDuration.FindStringSubmatch(d)
+		if m == nil || d == "P" || d == "PT" {
+			return 0, fmt.Errorf("cannot parse %q as a duration", d)
+		}
+		var total time.Duration
+		for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+			if m[i+1] != "" {
+				n, _ := strconv.Atoi(m[i+1])
+				total += time.Duration(n) * unit
+			}
+		}
+		if m[4] != "" {
+			sec, _ := strconv.ParseFloat(m[4], 64)
+			total += time.Duration(sec * float64(time.Second))
+		}
+		return total, nil
+	case float64:
+		return time.Duration(d * float64(time.Second)), nil
+	case int64:
+		return time.Duration(d) * time.Second, nil
+	case int:
+		return time.Duration(d) * time.Second, nil
+	}
+	return 0, fmt.Errorf("cannot convert %T to duration", v)
+}
+
+// unixMillis renvoie t en millisecondes Unix, 0 pour l'heure nulle.
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromUnixMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError est renvoyée par le client lorsqu'un appel au plugin dépasse
+// son échéance. errors.Is(err, context.DeadlineExceeded) est vrai.
+type TimeoutError struct {
+	Method string
+	// Timeout est la durée accordée, zéro si l'échéance venait de l'appelant.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("plugin call %s timed out after %s", e.Method, e.Timeout.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("plugin call %s exceeded its deadline", e.Method)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// timeoutContext renvoie le contexte d'un appel borné par timeout (aucune
+// limite si timeout est nul).
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// Timeout renvoie la durée accordée par défaut aux exécutions de uses :
+// DefaultTimeout de la capacité, sinon celui du manifeste ; zéro si aucun
+// n'est renseigné ou valide.
+func (m *Manifest) Timeout(uses string) time.Duration {
+	for _, s := range []string{m.capabilityTimeout(uses), m.DefaultTimeout} {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func (m *Manifest) capabilityTimeout(uses string) string {
+	if spec := m.Capability(uses); spec != nil {
+		return spec.DefaultTimeout
+	}
+	return ""
+}
+
+func validateTimeout(s string) error {
+	if s == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("expected a positive duration, got %q", s)
+	}
+	return nil
+}
+
+// executeTimeout est ClientOptions.executeTimeout, le délai par défaut du
+// manifeste (Manifest.Timeout) tenant lieu de Node.Timeout s'il est nul.
+func (m *NodeExecutorGRPC) executeTimeout(node Node, ctx ExecutionContext) time.Duration {
+	if node.Timeout <= 0 {
+		if manifest := m.loadManifest(); manifest != nil {
+			node.Timeout = manifest.Timeout(node.Uses)
+		}
+	}
+	return m.opts.executeTimeout(node, ctx)
+}
+
+// executeTimeout calcule la durée accordée à l'exécution de node : la plus
+// courte entre Node.Timeout et l'échéance du contexte, ou à défaut
+// ClientOptions.ExecuteTimeout.
+func (o ClientOptions) executeTimeout(node Node, ctx ExecutionContext) time.Duration {
+	timeout := node.Timeout
+	if !ctx.Deadline.IsZero() {
+		remaining := time.Until(ctx.Deadline)
+		if remaining <= 0 {
+			// Échéance déjà atteinte : l'appel échouera immédiatement.
+			remaining = time.Nanosecond
+		}
+		if timeout <= 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	if timeout <= 0 {
+		timeout = o.ExecuteTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,281 @@
+// Package tmplfuncs expose l'ensemble canonique des fonctions de template
+// (chaînes, dates, calcul, encodage) partagé par l'évaluateur d'expressions
+// du moteur et par le templating côté plugin, afin que les deux produisent
+// des résultats identiques.
+//
+// Les ensembles de fonctions sont versionnés : un workflow écrit pour V1
+// continue d'utiliser V1 même lorsque des versions ultérieures changent le
+// comportement d'une fonction.
+package tmplfuncs
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// Version identifie un ensemble de fonctions.
+type Version int
+
+const (
+	V1 Version = 1
+
+	// Latest est la version utilisée par défaut pour les nouveaux workflows.
+	Latest = V1
+)
+
+// Options paramètre les fonctions dépendantes de l'environnement.
+type Options struct {
+	// Now remplace l'horloge système pour "now" (exécutions déterministes).
+	Now func() time.Time
+}
+
+// FuncMap renvoie les fonctions de la version v, utilisable avec
+// text/template et html/template.
+func FuncMap(v Version, opts Options) (template.FuncMap, error) {
+	build, ok := sets[v]
+	if !ok {
+		return nil, fmt.Errorf("unknown template function set version %d", v)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return build(opts), nil
+}
+
+// Names renvoie les noms des fonctions de la version v, triés.
+func Names(v Version) []string {
+	build, ok := sets[v]
+	if !ok {
+		return nil
+	}
+	fm := build(Options{Now: time.Now})
+	names := make([]string, 0, len(fm))
+	for name := range fm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var sets = map[Version]func(Options) template.FuncMap{
+	V1: v1,
+}
+
+func v1(opts Options) template.FuncMap {
+	return template.FuncMap{
+		// Chaînes
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       join,
+		"truncate":   truncate,
+		"quote":      strconv.Quote,
+		"default":    defaultValue,
+		"toString":   func(v interface{}) string { return fmt.Sprint(v) },
+
+		// Dates
+		"now":        func() time.Time { return opts.Now().UTC() },
+		"formatDate": formatDate,
+		"parseDate":  parseDate,
+		"addDuration": func(d string, t interface{}) (time.Time, error) {
+			dur, err := time.ParseDuration(d)
+			if err != nil {
+				return time.Time{}, err
+			}
+			tt, err := toTime(t)
+			return tt.Add(dur), err
+		},
+		"unix": func(t interface{}) (int64, error) {
+			tt, err := toTime(t)
+			return tt.Unix(), err
+		},
+
+		// Calcul
+		"add": func(a, b interface{}) (float64, error) {
+			return arith(a, b, func(x, y float64) float64 { return x + y })
+		},
+		"sub": func(a, b interface{}) (float64, error) {
+			return arith(a, b, func(x, y float64) float64 { return x - y })
+		},
+		"mul": func(a, b interface{}) (float64, error) {
+			return arith(a, b, func(x, y float64) float64 { return x * y })
+		},
+		"div":   div,
+		"mod":   func(a, b interface{}) (float64, error) { return arith(a, b, math.Mod) },
+		"min":   func(a, b interface{}) (float64, error) { return arith(a, b, math.Min) },
+		"max":   func(a, b interface{}) (float64, error) { return arith(a, b, math.Max) },
+		"round": func(places int, v interface{}) (float64, error) { return round(v, places) },
+		"floor": func(v interface{}) (float64, error) { f, err := toFloat(v); return math.Floor(f), err },
+		"ceil":  func(v interface{}) (float64, error) { f, err := toFloat(v); return math.Ceil(f), err },
+
+		// Encodage
+		"base64Encode": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"base64Decode": func(s string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(s)
+			return string(b), err
+		},
+		"urlEncode": url.QueryEscape,
+		"urlDecode": url.QueryUnescape,
+		"toJson":    toJSON,
+		"fromJson":  fromJSON,
+		"sha256":    func(s string) string { sum := sha256.Sum256([]byte(s)); return hex.EncodeToString(sum[:]) },
+		"hexEncode": func(s string) string { return hex.EncodeToString([]byte(s)) },
+	}
+}
+
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		out := r
+		if unicode.IsSpace(prev) || prev == '-' || prev == '_' {
+			out = unicode.ToTitle(r)
+		}
+		prev = r
+		return out
+	}, s)
+}
+
+func join(sep string, v interface{}) (string, error) {
+	switch l := v.(type) {
+	case []string:
+		return strings.Join(l, sep), nil
+	case []interface{}:
+		parts := make([]string, len(l))
+		for i, p := range l {
+			parts[i] = fmt.Sprint(p)
+		}
+		return strings.Join(parts, sep), nil
+	}
+	return "", fmt.Errorf("join: expected a list, got %T", v)
+}
+
+func truncate(n int, s string) string {
+	r := []rune(s)
+	if n < 0 || len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// defaultValue renvoie def lorsque v est nil ou une chaîne vide.
+func defaultValue(def, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+	if s, ok := v.(string); ok && s == "" {
+		return def
+	}
+	return v
+}
+
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		return parseDate(t)
+	}
+	if f, err := toFloat(v); err == nil {
+		return time.Unix(int64(f), 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("expected a time, got %T", v)
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a date", s)
+}
+
+func formatDate(layout string, v interface{}) (string, error) {
+	t, err := toTime(v)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	case json.Number:
+		return n.Float64()
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
+
+func arith(a, b interface{}, op func(x, y float64) float64) (float64, error) {
+	x, err := toFloat(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toFloat(b)
+	if err != nil {
+		return 0, err
+	}
+	return op(x, y), nil
+}
+
+func div(a, b interface{}) (float64, error) {
+	y, err := toFloat(b)
+	if err != nil {
+		return 0, err
+	}
+	if y == 0 {
+		return 0, fmt.Errorf("div: division by zero")
+	}
+	return arith(a, y, func(x, y float64) float64 { return x / y })
+}
+
+func round(v interface{}, places int) (float64, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return 0, err
+	}
+	p := math.Pow(10, float64(places))
+	return math.Round(f*p) / p, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func fromJSON(s string) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal([]byte(s), &v)
+	return v, err
+}
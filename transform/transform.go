@@ -0,0 +1,277 @@
+// Package transform définit le contrat du nœud "core/transform" : une suite
+// d'opérations de mise en forme (renommage, sélection, aplatissement,
+// conversion de type) appliquée à un objet ou à chaque objet d'une liste.
+// Il fournit l'évaluateur de référence utilisé par le moteur.
+//
+// Exemple de With :
+//
+//	input: "{{ steps.fetch.items }}"
+//	ops:
+//	  - {op: pick, fields: [id, owner.email, amount]}
+//	  - {op: rename, from: owner.email, to: email}
+//	  - {op: cast, path: amount, type: float}
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Uses est la capacité implémentée par le moteur.
+const Uses = "core/transform"
+
+// OpKind identifie une opération.
+type OpKind string
+
+const (
+	OpRename  OpKind = "rename"
+	OpPick    OpKind = "pick"
+	OpOmit    OpKind = "omit"
+	OpFlatten OpKind = "flatten"
+	OpCast    OpKind = "cast"
+)
+
+// Types acceptés par OpCast.
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	TypeBool   = "bool"
+)
+
+// Op est une opération. Les chemins utilisent la notation pointée ("a.b").
+type Op struct {
+	Op     OpKind   `json:"op"`
+	From   string   `json:"from,omitempty"`
+	To     string   `json:"to,omitempty"`
+	Fields []string `json:"fields,omitempty"`
+	Path   string   `json:"path,omitempty"`
+	Type   string   `json:"type,omitempty"`
+	// Separator est utilisé par OpFlatten, "." par défaut.
+	Separator string `json:"separator,omitempty"`
+}
+
+// Spec est le contenu du With d'un nœud core/transform.
+type Spec struct {
+	Input interface{} `json:"input"`
+	Ops   []Op        `json:"ops"`
+}
+
+// FromWith décode et valide le With d'un nœud core/transform.
+func FromWith(with map[string]interface{}) (Spec, error) {
+	var s Spec
+	b, err := json.Marshal(with)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("invalid transform spec: %w", err)
+	}
+	return s, s.Validate()
+}
+
+// Validate vérifie que chaque opération est complète.
+func (s Spec) Validate() error {
+	for i, op := range s.Ops {
+		var err error
+		switch op.Op {
+		case OpRename:
+			if op.From == "" || op.To == "" {
+				err = fmt.Errorf("rename requires from and to")
+			}
+		case OpPick, OpOmit:
+			if len(op.Fields) == 0 {
+				err = fmt.Errorf("%s requires fields", op.Op)
+			}
+		case OpFlatten:
+		case OpCast:
+			switch op.Type {
+			case TypeString, TypeInt, TypeFloat, TypeBool:
+			default:
+				err = fmt.Errorf("cast: unknown type %q", op.Type)
+			}
+			if op.Path == "" {
+				err = fmt.Errorf("cast requires path")
+			}
+		default:
+			err = fmt.Errorf("unknown op %q", op.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("ops[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Apply exécute les opérations sur s.Input.
+func (s Spec) Apply() (interface{}, error) {
+	return Apply(s.Ops, s.Input)
+}
+
+// Apply exécute ops sur v, qui doit être un objet ou une liste d'objets.
+// L'entrée n'est pas modifiée.
+func Apply(ops []Op, v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return applyRecord(ops, x)
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, item := range x {
+			rec, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item %d: expected an object, got %T", i, item)
+			}
+			r, err := applyRecord(ops, rec)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			out[i] = r
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("expected an object or a list of objects, got %T", v)
+}
+
+func applyRecord(ops []Op, rec map[string]interface{}) (map[string]interface{}, error) {
+	rec = deepCopy(rec)
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case OpRename:
+			if v, ok := getPath(rec, op.From); ok {
+				deletePath(rec, op.From)
+				setPath(rec, op.To, v)
+			}
+		case OpPick:
+			picked := map[string]interface{}{}
+			for _, f := range op.Fields {
+				if v, ok := getPath(rec, f); ok {
+					setPath(picked, f, v)
+				}
+			}
+			rec = picked
+		case OpOmit:
+			for _, f := range op.Fields {
+				deletePath(rec, f)
+			}
+		case OpFlatten:
+			sep := op.Separator
+			if sep == "" {
+				sep = "."
+			}
+			flat := map[string]interface{}{}
+			flatten(flat, "", sep, rec)
+			rec = flat
+		case OpCast:
+			v, ok := getPath(rec, op.Path)
+			if !ok {
+				continue
+			}
+			if v, err = cast(v, op.Type); err == nil {
+				setPath(rec, op.Path, v)
+			}
+		default:
+			err = fmt.Errorf("unknown op %q", op.Op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ops[%d] (%s): %w", i, op.Op, err)
+		}
+	}
+	return rec, nil
+}
+
+func cast(v interface{}, typ string) (interface{}, error) {
+	s := fmt.Sprint(v)
+	switch typ {
+	case TypeString:
+		if f, ok := v.(float64); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return s, nil
+	case TypeInt:
+		if f, ok := v.(float64); ok {
+			return int64(f), nil
+		}
+		return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	case TypeFloat:
+		if f, ok := v.(float64); ok {
+			return f, nil
+		}
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case TypeBool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return strconv.ParseBool(strings.TrimSpace(s))
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func flatten(dst map[string]interface{}, prefix, sep string, v map[string]interface{}) {
+	for k, child := range v {
+		key := k
+		if prefix != "" {
+			key = prefix + sep + k
+		}
+		if m, ok := child.(map[string]interface{}); ok && len(m) > 0 {
+			flatten(dst, key, sep, m)
+			continue
+		}
+		dst[key] = child
+	}
+}
+
+func getPath(rec map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	var cur interface{} = rec
+	for _, p := range parts {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[p]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func setPath(rec map[string]interface{}, path string, v interface{}) {
+	parts := strings.Split(path, ".")
+	m := rec
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[p] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = v
+}
+
+func deletePath(rec map[string]interface{}, path string) {
+	parts := strings.Split(path, ".")
+	m := rec
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, parts[len(parts)-1])
+}
+
+func deepCopy(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if child, ok := v.(map[string]interface{}); ok {
+			v = deepCopy(child)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TriggerKind identifie la famille de déclencheur à l'origine d'une exécution.
+type TriggerKind string
+
+const (
+	TriggerKindWebhook TriggerKind = "webhook"
+	TriggerKindCron    TriggerKind = "cron"
+	TriggerKindQueue   TriggerKind = "queue"
+	TriggerKindManual  TriggerKind = "manual"
+	TriggerKindPoll    TriggerKind = "poll"
+)
+
+// TriggerPayload est la forme canonique de ExecutionContext.TriggerData,
+// identique quel que soit le type de déclencheur.
+type TriggerPayload struct {
+	Source  TriggerSource     `json:"source"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Query   map[string]string `json:"query,omitempty"`
+	RawBody []byte            `json:"rawBody,omitempty"`
+	// Body est le corps décodé lorsqu'il s'agit de JSON.
+	Body      interface{}      `json:"body,omitempty"`
+	Signature *SignatureResult `json:"signature,omitempty"`
+}
+
+// TriggerSource décrit d'où vient l'événement.
+type TriggerSource struct {
+	Kind TriggerKind `json:"kind"`
+	// Name est l'identifiant du déclencheur dans le workflow.
+	Name       string    `json:"name,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt,omitzero"`
+	// EventID est l'identifiant fourni par la source (livraison webhook,
+	// message de file), s'il existe.
+	EventID    string `json:"eventId,omitempty"`
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	Schedule   string `json:"schedule,omitempty"`
+	Queue      string `json:"queue,omitempty"`
+	// ReplayOf est l'identifiant du TriggerEvent rejoué, vide pour un
+	// événement reçu en direct.
+	ReplayOf string `json:"replayOf,omitempty"`
+}
+
+// SignatureResult est le résultat de la vérification de signature d'un
+// webhook entrant.
+type SignatureResult struct {
+	Verified bool   `json:"verified"`
+	Scheme   string `json:"scheme,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// NewWebhookPayload construit un TriggerPayload à partir d'une requête HTTP
+// entrante. Le corps est lu dans la limite de maxBody octets. Source.EventID
+// reprend l'identifiant de livraison des en-têtes usuels (Idempotency-Key,
+// X-GitHub-Delivery...), s'il y en a un.
+func NewWebhookPayload(name string, r *http.Request, maxBody int64) (TriggerPayload, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody+1))
+	if err != nil {
+		return TriggerPayload{}, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	if int64(len(body)) > maxBody {
+		return TriggerPayload{}, fmt.Errorf("webhook body exceeds %d bytes", maxBody)
+	}
+	p := TriggerPayload{
+		Source: TriggerSource{
+			Kind:       TriggerKindWebhook,
+			Name:       name,
+			ReceivedAt: time.Now().UTC(),
+			RemoteAddr: r.RemoteAddr,
+		},
+		Headers: flattenHeader(r.Header),
+		Query:   flattenHeader(http.Header(r.URL.Query())),
+		RawBody: body,
+	}
+	for _, h := range webhookDeliveryHeaders {
+		if id := r.Header.Get(h); id != "" {
+			p.Source.EventID = id
+			break
+		}
+	}
+	if isJSONContentType(r.Header.Get("Content-Type")) && len(body) > 0 {
+		if err := json.Unmarshal(body, &p.Body); err != nil {
+			return TriggerPayload{}, fmt.Errorf("invalid JSON webhook body: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// ToTriggerData convertit le payload dans la forme attendue par
+// ExecutionContext.TriggerData.
+func (p TriggerPayload) ToTriggerData() (map[string]interface{}, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Trigger relit TriggerData sous sa forme canonique.
+func (c ExecutionContext) Trigger() (TriggerPayload, error) {
+	var p TriggerPayload
+	b, err := json.Marshal(c.TriggerData)
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(b, &p); err != nil {
+		return p, fmt.Errorf("trigger data is not a trigger payload: %w", err)
+	}
+	return p, nil
+}
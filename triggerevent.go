@@ -0,0 +1,179 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TriggerEventVersion est la version de l'enveloppe TriggerEvent produite
+// par cette bibliothèque. Comme EventVersion, elle ne change que lorsque la
+// signification d'un champ change.
+const TriggerEventVersion = 1
+
+// TriggerEvent conserve un événement de déclencheur tel qu'il a été reçu :
+// la requête brute (méthode, URL, en-têtes multi-valués, corps octet pour
+// octet) à côté de sa forme décodée. Les en-têtes d'identification
+// (Authorization, Cookie...) ne sont pas conservés. Il permet de rejouer un événement
+// historique contre une nouvelle version d'un workflow (voir Replay).
+type TriggerEvent struct {
+	Version int    `json:"version"`
+	ID      string `json:"id"`
+	// Method et URL ne sont renseignés que pour les webhooks.
+	Method string      `json:"method,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	// RawBody est le corps exact de l'événement ; Payload.RawBody est vide
+	// dans l'enveloppe sérialisée pour ne pas le stocker deux fois.
+	RawBody []byte         `json:"rawBody,omitempty"`
+	Payload TriggerPayload `json:"payload"`
+}
+
+// NewTriggerEvent enveloppe un payload déjà décodé, pour les déclencheurs
+// qui ne reçoivent pas de requête HTTP (cron, file, polling).
+func NewTriggerEvent(p TriggerPayload) TriggerEvent {
+	e := TriggerEvent{Version: TriggerEventVersion, ID: newEventID(), RawBody: p.RawBody, Payload: p}
+	e.Payload.RawBody = nil
+	if len(p.Headers) > 0 {
+		e.Payload.Headers = make(map[string]string, len(p.Headers))
+		for k, v := range p.Headers {
+			if !credentialHeader(k) {
+				e.Payload.Headers[k] = v
+			}
+		}
+	}
+	return e
+}
+
+// credentialHeaders sont les en-têtes qui portent des identifiants, exclus
+// des événements conservés.
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+func credentialHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	for _, h := range credentialHeaders {
+		if name == h {
+			return true
+		}
+	}
+	return false
+}
+
+// CaptureWebhook lit une requête HTTP entrante et renvoie son enveloppe, dont
+// le payload est celui de NewWebhookPayload. Le corps est lu dans la limite
+// de maxBody octets.
+func CaptureWebhook(name string, r *http.Request, maxBody int64) (TriggerEvent, error) {
+	p, err := NewWebhookPayload(name, r, maxBody)
+	if err != nil {
+		return TriggerEvent{}, err
+	}
+	e := NewTriggerEvent(p)
+	e.Method = r.Method
+	e.URL = r.URL.String()
+	e.Header = r.Header.Clone()
+	for _, h := range credentialHeaders {
+		e.Header.Del(h)
+	}
+	if r.Host != "" && e.Header.Get("Host") == "" {
+		e.Header.Set("Host", r.Host)
+	}
+	return e, nil
+}
+
+// TriggerPayload renvoie le payload complet de l'événement, corps brut
+// compris, tel qu'il a été reçu.
+func (e TriggerEvent) TriggerPayload() TriggerPayload {
+	p := e.Payload
+	p.RawBody = e.RawBody
+	return p
+}
+
+// Request reconstruit la requête HTTP d'un webhook telle qu'elle a été
+// reçue.
+func (e TriggerEvent) Request() (*http.Request, error) {
+	if e.Method == "" {
+		return nil, fmt.Errorf("trigger event %s is not an HTTP request", e.ID)
+	}
+	r, err := http.NewRequest(e.Method, e.URL, bytes.NewReader(e.RawBody))
+	if err != nil {
+		return nil, fmt.Errorf("invalid trigger event request: %w", err)
+	}
+	r.Header = e.Header.Clone()
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	if host := r.Header.Get("Host"); host != "" {
+		r.Host = host
+		r.Header.Del("Host")
+	}
+	r.RemoteAddr = e.Payload.Source.RemoteAddr
+	return r, nil
+}
+
+// Replay renvoie le payload à soumettre pour rejouer l'événement. Le corps
+// des webhooks est décodé à nouveau depuis la requête brute, si bien qu'un
+// changement du décodage s'applique aux événements historiques ; l'heure de
+// réception et la vérification de signature d'origine sont conservées.
+// Source.ReplayOf désigne l'événement rejoué ; la clé de déduplication du
+// rejeu en tient compte (voir DedupeKey), si bien qu'il n'est pas écarté
+// comme doublon de l'original.
+func (e TriggerEvent) Replay() (TriggerPayload, error) {
+	p := e.TriggerPayload()
+	if e.Method != "" {
+		r, err := e.Request()
+		if err != nil {
+			return TriggerPayload{}, err
+		}
+		replayed, err := NewWebhookPayload(p.Source.Name, r, int64(len(e.RawBody)))
+		if err != nil {
+			return TriggerPayload{}, err
+		}
+		replayed.Source.ReceivedAt = p.Source.ReceivedAt
+		replayed.Source.EventID = p.Source.EventID
+		replayed.Signature = p.Signature
+		p = replayed
+	}
+	p.Source.ReplayOf = e.ID
+	return p, nil
+}
+
+// Marshal sérialise l'enveloppe en JSON.
+func (e TriggerEvent) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// ParseTriggerEvent décode une enveloppe produite par Marshal. Les versions
+// plus récentes que TriggerEventVersion sont refusées.
+func ParseTriggerEvent(data []byte) (TriggerEvent, error) {
+	var e TriggerEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return e, fmt.Errorf("invalid trigger event: %w", err)
+	}
+	if e.Version < 1 || e.Version > TriggerEventVersion {
+		return e, fmt.Errorf("unsupported trigger event version %d (max %d)", e.Version, TriggerEventVersion)
+	}
+	return e, nil
+}
+
+// ReadTriggerEvents décode une suite d'enveloppes au format JSON Lines, pour
+// rejouer un historique exporté.
+func ReadTriggerEvents(r io.Reader, fn func(TriggerEvent) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid trigger event: %w", err)
+		}
+		e, err := ParseTriggerEvent(raw)
+		if err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+}
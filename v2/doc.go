@@ -0,0 +1,30 @@
+// Package v2 est la deuxième version de l'interface des plugins de nœuds.
+// Par rapport à shared.NodeExecutor :
+//
+//   - Execute reçoit un context.Context, annulé à l'échéance de l'appel, et
+//     renvoie toujours l'enveloppe *Result ;
+//   - les capacités sont lues dans le manifeste renvoyé par Info, au lieu
+//     d'une liste parallèle renvoyée par GetCapabilities ;
+//   - les erreurs portent un code du catalogue commun (NewError, WrapError),
+//     que le moteur exploite sans analyser les messages.
+//
+// Le protocole gRPC ne change pas : ToV1 adapte un NodeExecutor v2 à
+// l'interface servie par shared.NodeExecutorPlugin, si bien que moteur et
+// plugins migrent indépendamment.
+//
+// # Migration
+//
+//  1. Remplacer Execute(node, ctx) par Execute(ctx, req), où req.Node et
+//     req.Context sont les anciens arguments, et renvoyer &Result{Value: v}.
+//  2. Déplacer la liste de GetCapabilities dans Manifest.Capabilities,
+//     renvoyé par Info avec le nom et la version du plugin.
+//  3. Remplacer les fmt.Errorf destinés au moteur par NewError ou WrapError.
+//  4. Servir le plugin avec &shared.NodeExecutorPlugin{Impl: v2.ToV1(impl)}.
+//     Les interfaces optionnelles de shared (ItemStreamer, Poller…) se
+//     gardent telles quelles ; ctx.Done() y signale l'abandon de l'appel.
+//
+// Pendant la transition, FromV1 présente une implémentation v1 sous
+// l'interface v2. Compiler avec l'étiquette orkestra_v2only retire FromV1 :
+// toute dépendance restante à une implémentation v1 devient une erreur de
+// compilation.
+package v2
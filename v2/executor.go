@@ -0,0 +1,112 @@
+package v2
+
+import (
+	"context"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+)
+
+// Types repris sans changement de la première version.
+type (
+	Node             = shared.Node
+	ExecutionContext = shared.ExecutionContext
+	Result           = shared.Result
+	Manifest         = shared.Manifest
+	PluginInfo       = shared.PluginInfo
+	ErrorCode        = shared.ErrorCode
+	CodedError       = shared.CodedError
+)
+
+// Request est la requête d'exécution d'un nœud.
+type Request struct {
+	Node    Node
+	Context ExecutionContext
+}
+
+// NodeExecutor est l'interface des plugins de nœuds, version 2. Execute peut
+// être appelée de manière concurrente, sauf si le manifeste déclare une
+// limite via MaxConcurrency.
+type NodeExecutor interface {
+	Execute(ctx context.Context, req Request) (*Result, error)
+	// Info décrit le plugin ; Manifest.Capabilities liste ses capacités.
+	Info(ctx context.Context) (PluginInfo, error)
+}
+
+// NewError renvoie une erreur de code code ; voir shared.NewError.
+func NewError(code ErrorCode, format string, args ...interface{}) error {
+	return shared.NewError(code, format, args...)
+}
+
+// WrapError renvoie une erreur de code code dont la cause est err ; voir
+// shared.WrapError.
+func WrapError(code ErrorCode, err error, format string, args ...interface{}) error {
+	return shared.WrapError(code, err, format, args...)
+}
+
+// ToV1 présente exec sous l'interface servie par shared.NodeExecutorPlugin.
+// Les interfaces optionnelles de shared (ItemStreamer, Preflighter, Poller…)
+// implémentées par exec restent servies : l'adaptateur les expose via
+// shared.Unwrapper.
+func ToV1(exec NodeExecutor) shared.NodeExecutor {
+	return v1Adapter{exec}
+}
+
+type v1Adapter struct {
+	exec NodeExecutor
+}
+
+// Unwrap renvoie l'implémentation v2, pour ses interfaces optionnelles.
+func (a v1Adapter) Unwrap() interface{} {
+	return a.exec
+}
+
+// context renvoie un contexte annulé à l'échéance de l'exécution ou lorsque
+// le moteur abandonne l'appel.
+func (a v1Adapter) context(execCtx ExecutionContext) (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if execCtx.Deadline.IsZero() {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = context.WithDeadline(context.Background(), execCtx.Deadline)
+	}
+	if done := execCtx.Done(); done != nil {
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	return ctx, cancel
+}
+
+func (a v1Adapter) Execute(node Node, execCtx ExecutionContext) (interface{}, error) {
+	ctx, cancel := a.context(execCtx)
+	defer cancel()
+	res, err := a.exec.Execute(ctx, Request{Node: node, Context: execCtx})
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &Result{}, nil
+	}
+	return res, nil
+}
+
+func (a v1Adapter) GetCapabilities() ([]string, error) {
+	info, err := a.GetInfo()
+	if err != nil {
+		return nil, err
+	}
+	uses := make([]string, len(info.Manifest.Capabilities))
+	for i, c := range info.Manifest.Capabilities {
+		uses[i] = c.Uses
+	}
+	return uses, nil
+}
+
+func (a v1Adapter) GetInfo() (PluginInfo, error) {
+	return a.exec.Info(context.Background())
+}
@@ -0,0 +1,55 @@
+//go:build !orkestra_v2only
+
+package v2
+
+import (
+	"context"
+	"errors"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+)
+
+// FromV1 présente une implémentation v1 sous l'interface v2, le temps de sa
+// migration. Elle est absente des compilations avec l'étiquette
+// orkestra_v2only.
+//
+// Deprecated: migrer exec vers NodeExecutor (voir le guide du paquet).
+func FromV1(exec shared.NodeExecutor) NodeExecutor {
+	return v2Adapter{exec}
+}
+
+type v2Adapter struct {
+	exec shared.NodeExecutor
+}
+
+func (a v2Adapter) Execute(ctx context.Context, req Request) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok && (req.Context.Deadline.IsZero() || deadline.Before(req.Context.Deadline)) {
+		req.Context.Deadline = deadline
+	}
+	return shared.ExecuteResult(a.exec, req.Node, req.Context)
+}
+
+// Info complète le manifeste des capacités listées par GetCapabilities qu'il
+// ne décrit pas.
+func (a v2Adapter) Info(ctx context.Context) (PluginInfo, error) {
+	var info PluginInfo
+	if p, ok := a.exec.(shared.InfoProvider); ok {
+		var err error
+		if info, err = p.GetInfo(); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return PluginInfo{}, err
+		}
+	}
+	uses, err := a.exec.GetCapabilities()
+	if err != nil {
+		return PluginInfo{}, err
+	}
+	for _, u := range uses {
+		if info.Manifest.Capability(u) == nil {
+			info.Manifest.Capabilities = append(info.Manifest.Capabilities, shared.CapabilitySpec{Uses: u})
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,230 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Les valeurs que JSON ne sait pas restituer (dates, durées, décimaux) sont encodées
+// sous une forme textuelle canonique, et leur type est transmis à côté dans
+// une table d'indications (pointeur JSON -> type). Un pair qui ignore ces
+// indications voit simplement des chaînes.
+
+// valueKind décrit un type Go préservé par les indications de type.
+type valueKind struct {
+	name   string
+	encode func(v interface{}) (interface{}, bool)
+	decode func(v interface{}) (interface{}, error)
+}
+
+var valueKinds = []valueKind{
+	{
+		name: "time",
+		encode: func(v interface{}) (interface{}, bool) {
+			t, ok := v.(time.Time)
+			if !ok {
+				return nil, false
+			}
+			return FormatTime(t), true
+		},
+		decode: func(v interface{}) (interface{}, error) { return ParseTime(v) },
+	},
+	{
+		name: "duration",
+		encode: func(v interface{}) (interface{}, bool) {
+			d, ok := v.(time.Duration)
+			if !ok {
+				return nil, false
+			}
+			return d.String(), true
+		},
+		decode: func(v interface{}) (interface{}, error) { return ParseDuration(v) },
+	},
+	{
+		name: "decimal",
+		encode: func(v interface{}) (interface{}, bool) {
+			d, ok := v.(Decimal)
+			if !ok {
+				return nil, false
+			}
+			return d.String(), true
+		},
+		decode: func(v interface{}) (interface{}, error) { return DecimalFromValue(v) },
+	},
+	{
+		name: "money",
+		encode: func(v interface{}) (interface{}, bool) {
+			m, ok := v.(Money)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{"amount": m.Amount.String(), "currency": m.Currency}, true
+		},
+		decode: func(v interface{}) (interface{}, error) { return MoneyFromValue(v) },
+	},
+}
+
+// encodeValue sérialise v avec c et renvoie les indications de type
+// associées, nil si aucune valeur n'en a besoin.
+func encodeValue(c Codec, v interface{}) ([]byte, []byte, error) {
+	hints := map[string]string{}
+	converted, _ := hintValue(v, "", hints)
+	data, err := c.Marshal(converted)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(hints) == 0 {
+		return data, nil, nil
+	}
+	raw, err := json.Marshal(hints)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, raw, nil
+}
+
+// encodeValueFor est encodeValue pour un pair qui lit format. En FormatV1,
+// v est sérialisée telle quelle par c, sans forme textuelle ni indications :
+// le pair la lit comme l'aurait produite une bibliothèque antérieure.
+func encodeValueFor(c Codec, v interface{}, format uint32) ([]byte, []byte, error) {
+	if format < FormatV2 {
+		data, err := c.Marshal(v)
+		return data, nil, err
+	}
+	return encodeValue(c, v)
+}
+
+// hintValue remplace les valeurs typées par leur forme textuelle. Les
+// conteneurs ne sont copiés que lorsqu'un de leurs descendants change.
+func hintValue(v interface{}, path string, hints map[string]string) (interface{}, bool) {
+	for _, k := range valueKinds {
+		if enc, ok := k.encode(v); ok {
+			hints[path] = k.name
+			return enc, true
+		}
+	}
+	switch x := v.(type) {
+	case map[string]interface{}:
+		var out map[string]interface{}
+		for key, child := range x {
+			c, changed := hintValue(child, path+"/"+escapePointer(key), hints)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = make(map[string]interface{}, len(x))
+				for k, v := range x {
+					out[k] = v
+				}
+			}
+			out[key] = c
+		}
+		if out != nil {
+			return out, true
+		}
+	case []interface{}:
+		var out []interface{}
+		for i, child := range x {
+			c, changed := hintValue(child, path+"/"+strconv.Itoa(i), hints)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = append([]interface{}(nil), x...)
+			}
+			out[i] = c
+		}
+		if out != nil {
+			return out, true
+		}
+	}
+	return v, false
+}
+
+// decodeValue désérialise data avec c et restaure les valeurs typées.
+func decodeValue(c Codec, data, hints []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := c.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return applyHints(v, hints)
+}
+
+// applyHints restaure les valeurs typées désignées par hints dans v et
+// renvoie la nouvelle racine.
+func applyHints(v interface{}, raw []byte) (interface{}, error) {
+	if len(raw) == 0 {
+		return v, nil
+	}
+	var hints map[string]string
+	if err := json.Unmarshal(raw, &hints); err != nil {
+		return nil, fmt.Errorf("invalid value hints: %w", err)
+	}
+	for path, name := range hints {
+		var kind *valueKind
+		for i := range valueKinds {
+			if valueKinds[i].name == name {
+				kind = &valueKinds[i]
+			}
+		}
+		if kind == nil {
+			// Type inconnu de cette version : la forme textuelle est conservée.
+			continue
+		}
+		var err error
+		if v, err = replaceAt(v, splitPointer(path), kind.decode); err != nil {
+			return nil, fmt.Errorf("value hint %q: %w", path, err)
+		}
+	}
+	return v, nil
+}
+
+func replaceAt(v interface{}, segments []string, decode func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(segments) == 0 {
+		return decode(v)
+	}
+	switch x := v.(type) {
+	case map[string]interface{}:
+		child, ok := x[segments[0]]
+		if !ok {
+			return v, nil
+		}
+		c, err := replaceAt(child, segments[1:], decode)
+		if err != nil {
+			return nil, err
+		}
+		x[segments[0]] = c
+	case []interface{}:
+		i, err := strconv.Atoi(segments[0])
+		if err != nil || i < 0 || i >= len(x) {
+			return v, nil
+		}
+		c, err := replaceAt(x[i], segments[1:], decode)
+		if err != nil {
+			return nil, err
+		}
+		x[i] = c
+	}
+	return v, nil
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func splitPointer(p string) []string {
+	if p == "" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, s := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return parts
+}
@@ -0,0 +1,159 @@
+// Package vcr enregistre les échanges HTTP d'un plugin avec des API
+// externes puis les rejoue, pour réexécuter un nœud à l'identique sans
+// solliciter à nouveau ces API.
+//
+// Les en-têtes des requêtes ne sont pas enregistrés : ils portent le plus
+// souvent des identifiants. Une requête rejouée est reconnue à sa méthode,
+// son URL et son corps ; les requêtes identiques sont servies dans l'ordre
+// de leur enregistrement.
+package vcr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrNoInteraction est renvoyée en rejeu pour une requête absente de la
+// cassette.
+var ErrNoInteraction = errors.New("vcr: no recorded interaction")
+
+// Mode est le mode d'un Transport.
+type Mode int
+
+const (
+	// ModeRecord transmet les requêtes et enregistre les échanges.
+	ModeRecord Mode = iota
+	// ModeReplay sert les réponses enregistrées sans émettre de requête.
+	ModeReplay
+)
+
+// Request est une requête enregistrée.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   []byte `json:"body,omitempty"`
+}
+
+// Response est une réponse enregistrée.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// Interaction est un échange enregistré.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette est la suite des échanges d'une exécution. Elle se sérialise en
+// JSON.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	mu sync.Mutex
+	// used marque les échanges déjà rejoués.
+	used []bool
+}
+
+// add enregistre un échange.
+func (c *Cassette) add(i Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, i)
+}
+
+// take renvoie le premier échange non rejoué qui correspond à req.
+func (c *Cassette) take(req Request) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.used) < len(c.Interactions) {
+		c.used = append(c.used, make([]bool, len(c.Interactions)-len(c.used))...)
+	}
+	for i, in := range c.Interactions {
+		if c.used[i] || in.Request.Method != req.Method || in.Request.URL != req.URL || !bytes.Equal(in.Request.Body, req.Body) {
+			continue
+		}
+		c.used[i] = true
+		return in.Response, true
+	}
+	return Response{}, false
+}
+
+// Transport enregistre ou rejoue les échanges HTTP dans une cassette.
+type Transport struct {
+	Cassette *Cassette
+	Mode     Mode
+	// Base émet les requêtes en ModeRecord, http.DefaultTransport par
+	// défaut.
+	Base http.RoundTripper
+}
+
+// NewTransport renvoie un Transport pour c en mode mode.
+func NewTransport(c *Cassette, mode Mode, base http.RoundTripper) *Transport {
+	return &Transport{Cassette: c, Mode: mode, Base: base}
+}
+
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	req := Request{Method: r.Method, URL: r.URL.String()}
+	if r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if t.Mode == ModeReplay {
+		resp, ok := t.Cassette.take(req)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+		}
+		return toHTTPResponse(r, resp), nil
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpResp, err := base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(httpResp.Body)
+	httpResp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	httpResp.Body = io.NopCloser(bytes.NewReader(body))
+	t.Cassette.add(Interaction{
+		Request:  req,
+		Response: Response{Status: httpResp.StatusCode, Header: httpResp.Header.Clone(), Body: body},
+	})
+	return httpResp, nil
+}
+
+func toHTTPResponse(r *http.Request, resp Response) *http.Response {
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       r,
+	}
+}
@@ -0,0 +1,89 @@
+package shared
+
+// Version est la version sémantique de la bibliothèque orkestra-shared.
+// Elle est transmise par les plugins dans GetInfo pour diagnostiquer les
+// écarts entre le moteur et les plugins déployés.
+const Version = "0.8.0"
+
+// ProtocolVersion est la version du protocole négociée lors du handshake.
+const ProtocolVersion = 1
+
+// Feature identifie une fonctionnalité optionnelle du protocole.
+type Feature string
+
+const (
+	// FeatureGetInfo indique que le plugin répond à l'RPC GetInfo.
+	FeatureGetInfo Feature = "get-info"
+	// FeatureItemStreaming indique que le plugin sait produire ses éléments
+	// en flux via ExecuteStream.
+	FeatureItemStreaming Feature = "item-streaming"
+	// FeaturePreflight indique que le plugin sait vérifier ses dépendances
+	// externes via Preflight.
+	FeaturePreflight Feature = "preflight"
+	// FeatureStats indique que le plugin rapporte sa consommation de
+	// ressources via GetStats.
+	FeatureStats Feature = "stats"
+	// FeatureConfigure indique que le plugin accepte une configuration à
+	// chaud via Configure.
+	FeatureConfigure Feature = "configure"
+	// FeatureControl indique que le plugin accepte le flux de contrôle
+	// bidirectionnel ouvert via Control.
+	FeatureControl Feature = "control"
+	// FeatureCompression indique que le plugin accepte TriggerData et
+	// NodeOutputs compressés (ExecutionContext.payload_encoding).
+	FeatureCompression Feature = "compression"
+	// FeatureZstd indique que le plugin accepte aussi TriggerData et
+	// NodeOutputs compressés en zstd, préféré alors à gzip.
+	FeatureZstd Feature = "compression-zstd"
+	// FeatureOutputsDelta indique que le plugin accepte NodeOutputs sous
+	// forme de changements depuis l'appel précédent de la même exécution.
+	FeatureOutputsDelta Feature = "outputs-delta"
+	// FeatureCostEstimate indique que le plugin sait estimer le coût d'un
+	// nœud via EstimateCost.
+	FeatureCostEstimate Feature = "cost-estimate"
+	// FeatureSelfTest indique que le plugin sait lancer ses propres
+	// diagnostics via SelfTest.
+	FeatureSelfTest Feature = "self-test"
+	// FeatureListOptions indique que le plugin sait proposer les valeurs
+	// d'un champ via ListOptions.
+	FeatureListOptions Feature = "list-options"
+	// FeatureSampleOutput indique que le plugin sait produire une sortie
+	// d'exemple via SampleOutput.
+	FeatureSampleOutput Feature = "sample-output"
+	// FeaturePoll indique que le plugin fournit des déclencheurs par relevé
+	// périodique via Poll.
+	FeaturePoll Feature = "poll"
+	// FeatureAck indique que le plugin attend la confirmation des
+	// événements de ses déclencheurs via Ack et Nack.
+	FeatureAck Feature = "ack"
+	// FeatureProtoPayloads indique que le plugin lit les charges encodées
+	// par ProtoCodec.
+	FeatureProtoPayloads Feature = "proto-payloads"
+)
+
+// featureSpec décrit une fonctionnalité pour le contrôle de compatibilité.
+type featureSpec struct {
+	// since est la première version du moteur qui sait l'utiliser.
+	since string
+	// description est utilisée dans les messages d'erreur.
+	description string
+}
+
+var features = map[Feature]featureSpec{
+	FeatureGetInfo:       {since: "0.8.0", description: "plugin info"},
+	FeatureItemStreaming: {since: "0.9.0", description: "streaming results"},
+	FeaturePreflight:     {since: "0.9.0", description: "preflight checks"},
+	FeatureStats:         {since: "0.9.0", description: "resource usage stats"},
+	FeatureConfigure:     {since: "0.9.0", description: "runtime configuration"},
+	FeatureControl:       {since: "0.9.0", description: "control stream"},
+	FeatureCompression:   {since: "0.9.0", description: "payload compression"},
+	FeatureZstd:          {since: "0.9.0", description: "zstd payload compression"},
+	FeatureOutputsDelta:  {since: "0.9.0", description: "node outputs delta"},
+	FeatureCostEstimate:  {since: "0.9.0", description: "cost estimates"},
+	FeatureSelfTest:      {since: "0.9.0", description: "self-test"},
+	FeatureListOptions:   {since: "0.9.0", description: "dynamic field options"},
+	FeatureSampleOutput:  {since: "0.9.0", description: "sample outputs"},
+	FeaturePoll:          {since: "0.9.0", description: "polling triggers"},
+	FeatureAck:           {since: "0.9.0", description: "trigger event acknowledgements"},
+	FeatureProtoPayloads: {since: "0.9.0", description: "protobuf payloads"},
+}
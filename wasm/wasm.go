@@ -0,0 +1,170 @@
+// Package wasm charge des plugins compilés en WebAssembly qui implémentent le
+// contrat NodeExecutor via une ABI définie ci-dessous, sans lancer de
+// processus. Le moteur WebAssembly lui-même (wazero, wasmtime...) est fourni
+// par l'appelant au travers de l'interface Engine.
+//
+// ABI (version 1). Le module exporte :
+//
+//	memory                                    la mémoire linéaire
+//	orkestra_abi_version() -> i32             renvoie 1
+//	orkestra_alloc(size i32) -> i32           alloue size octets
+//	orkestra_free(ptr i32, size i32)          libère une allocation
+//	orkestra_capabilities() -> i64            liste JSON des capacités
+//	orkestra_execute(ptr i32, len i32) -> i64 exécute la requête JSON
+//
+// Les fonctions qui renvoient un i64 désignent un tampon alloué par le module
+// (pointeur dans les 32 bits de poids fort, longueur dans ceux de poids
+// faible), que l'hôte libère après lecture. La requête est
+// {"version": 1, "node": ..., "context": ...}, où "node" et "context" ont la
+// forme du paquet internal/jsonwire dont "version" porte le numéro, et la
+// réponse {"value": ...} ou {"error": "..."}.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	shared "github.com/orkestra-io/orkestra-shared"
+	"github.com/orkestra-io/orkestra-shared/internal/jsonwire"
+)
+
+// ABIVersion est la version de l'ABI implémentée par ce paquet.
+const ABIVersion = 1
+
+// Engine instancie des modules WebAssembly. Une implémentation adapte un
+// moteur existant ; elle doit refuser tout accès au système hôte non
+// explicitement accordé (WASI restreint ou absent).
+type Engine interface {
+	Instantiate(ctx context.Context, module []byte) (Instance, error)
+}
+
+// Instance est un module instancié.
+type Instance interface {
+	// Call appelle la fonction exportée name.
+	Call(ctx context.Context, name string, args ...uint64) ([]uint64, error)
+	// Read renvoie une copie de size octets de la mémoire à partir de ptr.
+	Read(ptr, size uint32) ([]byte, bool)
+	// Write écrit data dans la mémoire à partir de ptr.
+	Write(ptr uint32, data []byte) bool
+	Close(ctx context.Context) error
+}
+
+// Executor est un plugin WebAssembly vu comme un shared.NodeExecutor. Une
+// instance WebAssembly étant monothread, les appels sont sérialisés.
+type Executor struct {
+	mu       sync.Mutex
+	instance Instance
+}
+
+var _ shared.NodeExecutor = (*Executor)(nil)
+
+// Load instancie module avec engine et vérifie sa version d'ABI.
+func Load(ctx context.Context, engine Engine, module []byte) (*Executor, error) {
+	inst, err := engine.Instantiate(ctx, module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm module: %w", err)
+	}
+	res, err := inst.Call(ctx, "orkestra_abi_version")
+	if err != nil || len(res) != 1 {
+		inst.Close(ctx)
+		return nil, fmt.Errorf("wasm module does not export orkestra_abi_version: %v", err)
+	}
+	if v := uint32(res[0]); v != ABIVersion {
+		inst.Close(ctx)
+		return nil, fmt.Errorf("wasm module uses ABI version %d, expected %d", v, ABIVersion)
+	}
+	return &Executor{instance: inst}, nil
+}
+
+// Close libère l'instance.
+func (e *Executor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.instance.Close(context.Background())
+}
+
+type response struct {
+	Value interface{} `json:"value"`
+	Error string      `json:"error,omitempty"`
+}
+
+func (e *Executor) Execute(node shared.Node, ctx shared.ExecutionContext) (interface{}, error) {
+	in, err := json.Marshal(jsonwire.NewRequest(node, ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode wasm request: %w", err)
+	}
+	callCtx := context.Background()
+	if !ctx.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithDeadline(callCtx, ctx.Deadline)
+		defer cancel()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ptr, err := e.write(callCtx, in)
+	if err != nil {
+		return nil, err
+	}
+	defer e.instance.Call(callCtx, "orkestra_free", uint64(ptr), uint64(len(in)))
+	out, err := e.call(callCtx, "orkestra_execute", uint64(ptr), uint64(len(in)))
+	if err != nil {
+		return nil, err
+	}
+	var resp response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("invalid wasm response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Value, nil
+}
+
+func (e *Executor) GetCapabilities() ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out, err := e.call(context.Background(), "orkestra_capabilities")
+	if err != nil {
+		return nil, err
+	}
+	var uses []string
+	if err := json.Unmarshal(out, &uses); err != nil {
+		return nil, fmt.Errorf("invalid wasm capabilities: %w", err)
+	}
+	return uses, nil
+}
+
+// write copie data dans un tampon alloué par le module.
+func (e *Executor) write(ctx context.Context, data []byte) (uint32, error) {
+	res, err := e.instance.Call(ctx, "orkestra_alloc", uint64(len(data)))
+	if err != nil || len(res) != 1 {
+		return 0, fmt.Errorf("wasm orkestra_alloc failed: %v", err)
+	}
+	ptr := uint32(res[0])
+	if !e.instance.Write(ptr, data) {
+		return 0, fmt.Errorf("wasm memory write out of range")
+	}
+	return ptr, nil
+}
+
+// call appelle une fonction qui renvoie un tampon et en lit le contenu.
+func (e *Executor) call(ctx context.Context, name string, args ...uint64) ([]byte, error) {
+	res, err := e.instance.Call(ctx, name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("wasm %s failed: %w", name, err)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("wasm %s returned %d values", name, len(res))
+	}
+	ptr, size := uint32(res[0]>>32), uint32(res[0])
+	out, ok := e.instance.Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("wasm %s returned an out of range buffer", name)
+	}
+	e.instance.Call(ctx, "orkestra_free", uint64(ptr), uint64(size))
+	return out, nil
+}
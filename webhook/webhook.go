@@ -0,0 +1,130 @@
+// Package webhook signe et vérifie les corps de requêtes HTTP échangés avec
+// des tiers : notifications émises par le moteur, webhooks reçus par les
+// plugins de déclenchement.
+//
+// La signature est portée par l'en-tête X-Orkestra-Signature :
+//
+//	t=<unix>,v1=<hex>[,v1=<hex>...]
+//
+// où chaque v1 est le HMAC-SHA256 de "<t>.<corps>". Plusieurs v1 permettent
+// la rotation d'un secret : l'émetteur signe avec l'ancien et le nouveau
+// secret le temps que les destinataires basculent.
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/hash"
+)
+
+// SignatureHeader est l'en-tête portant la signature.
+const SignatureHeader = "X-Orkestra-Signature"
+
+// DefaultTolerance est l'écart maximal accepté entre l'horodatage signé et
+// l'horloge locale. Il borne la fenêtre pendant laquelle une requête
+// interceptée peut être rejouée.
+const DefaultTolerance = 5 * time.Minute
+
+// MaxBodyBytes borne la taille des corps lus par VerifyRequest.
+const MaxBodyBytes = 32 << 20
+
+var (
+	ErrMalformedSignature = errors.New("malformed signature header")
+	ErrTimestampTolerance = errors.New("signature timestamp outside tolerance")
+	ErrSignatureMismatch  = errors.New("signature mismatch")
+)
+
+// Sign calcule la valeur de SignatureHeader pour body à l'instant t, avec
+// une signature v1 par secret.
+func Sign(t time.Time, body []byte, secrets ...[]byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	var b strings.Builder
+	b.WriteString("t=" + ts)
+	for _, secret := range secrets {
+		b.WriteString(",v1=" + mac(secret, ts, body))
+	}
+	return b.String()
+}
+
+func mac(secret []byte, ts string, body []byte) string {
+	return hash.HMAC(secret, []byte(ts), []byte("."), body)
+}
+
+// Verify vérifie la signature header de body : au moins une signature v1
+// doit correspondre à l'un des secrets, et l'horodatage ne doit pas
+// s'écarter de plus de tolerance de now. Les erreurs renvoyées sont
+// ErrMalformedSignature, ErrTimestampTolerance ou ErrSignatureMismatch.
+func Verify(header string, body []byte, now time.Time, tolerance time.Duration, secrets ...[]byte) error {
+	var ts string
+	var sigs []string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	if ts == "" || len(sigs) == 0 {
+		return ErrMalformedSignature
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrMalformedSignature
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return ErrTimestampTolerance
+	}
+	for _, secret := range secrets {
+		expected := mac(secret, ts, body)
+		for _, sig := range sigs {
+			if hash.Equal(sig, expected) {
+				return nil
+			}
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// SignRequest signe req, dont le corps est body, à l'instant présent.
+func SignRequest(req *http.Request, body []byte, secrets ...[]byte) {
+	req.Header.Set(SignatureHeader, Sign(time.Now(), body, secrets...))
+}
+
+// VerifyRequest lit le corps de r (au plus MaxBodyBytes), vérifie sa
+// signature avec DefaultTolerance et renvoie le corps. r.Body est remplacé
+// par une copie pour les traitements suivants.
+func VerifyRequest(r *http.Request, secrets ...[]byte) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxBodyBytes {
+		return nil, fmt.Errorf("request body exceeds %d bytes", MaxBodyBytes)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := Verify(r.Header.Get(SignatureHeader), body, time.Now(), DefaultTolerance, secrets...); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// Middleware refuse avec 401 les requêtes dont la signature est absente ou
+// invalide et transmet les autres à next, corps intact.
+func Middleware(next http.Handler, secrets ...[]byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := VerifyRequest(r, secrets...); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,296 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/orkestra-io/orkestra-shared/internal/proto"
+)
+
+// Valeurs par défaut de WireLogOptions.
+const (
+	DefaultWireLogFileBytes  = 10 << 20
+	DefaultWireLogFiles      = 3
+	DefaultWireLogEntryBytes = 16 << 10
+)
+
+// redacted remplace les valeurs des secrets dans le journal des échanges.
+const redacted = "[REDACTED]"
+
+// WireLogOptions paramètre la journalisation des échanges : chaque requête
+// Execute reçue et chaque réponse envoyée sont écrites, telles que
+// décodées par le codec du serveur, dans un fichier JSON Lines à rotation ;
+// les éléments d'ExecuteStream le sont un par un. Les valeurs des secrets
+// sont masquées partout où elles apparaissent.
+type WireLogOptions struct {
+	Enabled bool
+	// Path n'est pris en compte que dans ServerOptions.WireLog : un
+	// Configure reçu du moteur ne peut pas choisir le fichier écrit par le
+	// plugin, et doit laisser Path vide ou identique.
+	Path string
+	// MaxFileBytes déclenche la rotation, DefaultWireLogFileBytes par défaut.
+	MaxFileBytes int64
+	// MaxFiles est le nombre de fichiers conservés, rotation comprise,
+	// DefaultWireLogFiles par défaut.
+	MaxFiles int
+	// MaxEntryBytes tronque chaque charge utile, DefaultWireLogEntryBytes
+	// par défaut.
+	MaxEntryBytes int
+}
+
+func (o WireLogOptions) withDefaults() WireLogOptions {
+	if o.MaxFileBytes <= 0 {
+		o.MaxFileBytes = DefaultWireLogFileBytes
+	}
+	if o.MaxFiles <= 0 {
+		o.MaxFiles = DefaultWireLogFiles
+	}
+	if o.MaxEntryBytes <= 0 {
+		o.MaxEntryBytes = DefaultWireLogEntryBytes
+	}
+	return o
+}
+
+// wireLogger écrit le journal des échanges.
+type wireLogger struct {
+	opts  WireLogOptions
+	codec Codec
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newWireLogger(opts WireLogOptions, codec Codec) (*wireLogger, error) {
+	opts = opts.withDefaults()
+	if opts.Path == "" {
+		return nil, fmt.Errorf("wire log requires a path")
+	}
+	l := &wireLogger{opts: opts, codec: codecOrDefault(codec)}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *wireLogger) open() error {
+	f, err := os.OpenFile(l.opts.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open wire log: %w", err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, st.Size()
+	return nil
+}
+
+// rotate décale path.N-1 vers path.N, ..., path vers path.1, puis rouvre
+// path. Si un renommage échoue, path est rouvert tel quel pour que la
+// journalisation continue, et l'erreur est renvoyée.
+func (l *wireLogger) rotate() error {
+	var errs []error
+	errs = append(errs, l.file.Close())
+	l.file = nil
+	for i := l.opts.MaxFiles - 1; i > 0; i-- {
+		from := l.opts.Path
+		if i > 1 {
+			from = fmt.Sprintf("%s.%d", l.opts.Path, i-1)
+		}
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", l.opts.Path, i)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to rotate wire log: %w", err))
+		}
+	}
+	if l.opts.MaxFiles == 1 {
+		if err := os.Remove(l.opts.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to rotate wire log: %w", err))
+		}
+	}
+	errs = append(errs, l.open())
+	return errors.Join(errs...)
+}
+
+func (l *wireLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+type wireLogEntry struct {
+	Time      time.Time       `json:"time"`
+	Direction string          `json:"direction"`
+	Method    string          `json:"method"`
+	NodeID    string          `json:"nodeId,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Truncated string          `json:"truncated,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// logRequest journalise une requête reçue. Il est sans effet sur un
+// journal nil.
+func (l *wireLogger) logRequest(method string, req *proto.ExecuteRequest) {
+	if l == nil {
+		return
+	}
+	n, c := req.GetNode(), req.GetContext()
+	secretKeys := map[string]string{}
+	for k := range c.GetSecrets() {
+		secretKeys[k] = redacted
+	}
+	triggerData, nodeOutputs := c.GetTriggerData(), c.GetNodeOutputs()
+	if decompress := decompressor(c.GetPayloadEncoding()); decompress != nil {
+		// Le journal montre les données, pas leur forme compressée.
+		triggerData, _ = decompress(triggerData)
+		nodeOutputs, _ = decompress(nodeOutputs)
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"node": map[string]interface{}{
+			"id":      n.GetId(),
+			"uses":    n.GetUses(),
+			"with":    l.value(n.GetWith()),
+			"retries": rawJSON(n.GetRetries()),
+		},
+		"context": map[string]interface{}{
+			"triggerData": l.value(triggerData),
+			"nodeOutputs": l.value(nodeOutputs),
+			"currentItem": l.value(c.GetCurrentItem()),
+			"failureData": l.value(c.GetFailureData()),
+			"cursor":      c.GetCursor(),
+			"secrets":     secretKeys,
+		},
+	})
+	l.write(wireLogEntry{Direction: "request", Method: method, NodeID: n.GetId()}, payload, c.GetSecrets())
+}
+
+// logResponse journalise la réponse à une requête.
+func (l *wireLogger) logResponse(method string, req *proto.ExecuteRequest, resp *proto.ExecuteResponse, err error) {
+	if l == nil {
+		return
+	}
+	entry := wireLogEntry{Direction: "response", Method: method, NodeID: req.GetNode().GetId()}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	var payload []byte
+	if resp != nil {
+		payload, _ = json.Marshal(map[string]interface{}{
+			"result":     l.value(resp.Result),
+			"nextCursor": resp.NextCursor,
+			"outputKind": resp.OutputKind,
+		})
+	}
+	l.write(entry, payload, req.GetContext().GetSecrets())
+}
+
+// logStreamItem journalise un élément envoyé par ExecuteStream.
+func (l *wireLogger) logStreamItem(req *proto.ExecuteRequest, item []byte) {
+	if l == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]interface{}{"item": l.value(item)})
+	l.write(wireLogEntry{Direction: "response", Method: "ExecuteStream", NodeID: req.GetNode().GetId()}, payload, req.GetContext().GetSecrets())
+}
+
+// value décode b, encodé par le codec du serveur, pour le journal.
+func (l *wireLogger) value(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := l.codec.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+func (l *wireLogger) write(entry wireLogEntry, payload []byte, secrets map[string]string) {
+	entry.Time = time.Now().UTC()
+	payload = redactSecrets(payload, secrets)
+	if entry.Error != "" {
+		entry.Error = string(redactSecrets([]byte(entry.Error), secrets))
+	}
+	if len(payload) > l.opts.MaxEntryBytes || (payload != nil && !json.Valid(payload)) {
+		// Le masquage d'un secret très court peut atteindre la syntaxe
+		// JSON : la charge est alors conservée comme texte.
+		if len(payload) > l.opts.MaxEntryBytes {
+			payload = payload[:l.opts.MaxEntryBytes]
+		}
+		entry.Truncated = string(payload)
+		payload = nil
+	}
+	entry.Payload = payload
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.size+int64(len(line)) > l.opts.MaxFileBytes && l.size > 0 {
+		// Un échec de rotation n'interrompt pas le journal : il continue
+		// dans path, si du moins il a pu être rouvert.
+		l.rotate()
+	}
+	if l.file == nil {
+		return
+	}
+	n, _ := l.file.Write(line)
+	l.size += int64(n)
+}
+
+// rawJSON renvoie b tel quel s'il s'agit de JSON valide, nil sinon.
+func rawJSON(b []byte) json.RawMessage {
+	if len(b) == 0 || !json.Valid(b) {
+		return nil
+	}
+	return b
+}
+
+// redactSecrets masque dans b toutes les occurrences des valeurs de
+// secrets, telles quelles et sous leur forme échappée en JSON, quelle que
+// soit leur longueur : un secret court abîme le journal mais n'y fuit pas.
+func redactSecrets(b []byte, secrets map[string]string) []byte {
+	for _, v := range secrets {
+		if v == "" {
+			continue
+		}
+		b = bytes.ReplaceAll(b, []byte(v), []byte(redacted))
+		if quoted, err := json.Marshal(v); err == nil {
+			escaped := quoted[1 : len(quoted)-1]
+			b = bytes.ReplaceAll(b, escaped, []byte(redacted))
+		}
+	}
+	return b
+}
+
+func toProtoWireLog(o WireLogOptions) *proto.WireLogConfig {
+	return &proto.WireLogConfig{
+		Enabled:       o.Enabled,
+		Path:          o.Path,
+		MaxFileBytes:  o.MaxFileBytes,
+		MaxFiles:      int32(o.MaxFiles),
+		MaxEntryBytes: int32(o.MaxEntryBytes),
+	}
+}
+
+func fromProtoWireLog(p *proto.WireLogConfig) WireLogOptions {
+	return WireLogOptions{
+		Enabled:       p.Enabled,
+		Path:          p.Path,
+		MaxFileBytes:  p.MaxFileBytes,
+		MaxFiles:      int(p.MaxFiles),
+		MaxEntryBytes: int(p.MaxEntryBytes),
+	}
+}
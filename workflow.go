@@ -0,0 +1,60 @@
+package shared
+
+import "time"
+
+// Workflow est la définition d'un workflow : ses nœuds de premier niveau et
+// les valeurs par défaut qui s'appliquent à chacun d'eux.
+type Workflow struct {
+	Name     string
+	Defaults Defaults
+	Nodes    []*Node
+}
+
+// Defaults regroupe les réglages hérités par les nœuds d'un workflow qui ne
+// les définissent pas eux-mêmes.
+type Defaults struct {
+	Retries *Retries
+	Timeout time.Duration
+	// Env et Labels sont fusionnés clé par clé avec ceux du nœud ; la valeur
+	// du nœud l'emporte.
+	Env    map[string]string
+	Labels map[string]string
+}
+
+// ApplyDefaults applique w.Defaults à tous les nœuds du workflow, y compris
+// les nœuds imbriqués (Do, OnFailure, Compensate). Les nœuds sont modifiés en place.
+func (w *Workflow) ApplyDefaults() {
+	ApplyDefaults(w.Nodes, w.Defaults)
+}
+
+// ApplyDefaults complète chaque nœud de l'arbre avec d : Retries et Timeout
+// ne sont renseignés que s'ils sont absents du nœud, Env et Labels sont
+// fusionnés. Les nœuds ne partagent pas de données avec d après l'appel.
+func ApplyDefaults(nodes []*Node, d Defaults) {
+	walkNodes(nodes, func(n *Node) {
+		if n.Retries == nil && d.Retries != nil {
+			r := *d.Retries
+			n.Retries = &r
+		}
+		if n.Timeout == 0 {
+			n.Timeout = d.Timeout
+		}
+		n.Env = mergeDefaults(n.Env, d.Env)
+		n.Labels = mergeDefaults(n.Labels, d.Labels)
+	})
+}
+
+// mergeDefaults renvoie une copie de defaults surchargée par values.
+func mergeDefaults(values, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+	out := make(map[string]string, len(values)+len(defaults))
+	for k, v := range defaults {
+		out[k] = v
+	}
+	for k, v := range values {
+		out[k] = v
+	}
+	return out
+}